@@ -0,0 +1,28 @@
+package common
+
+import "github.com/gin-gonic/gin"
+
+// APIVersion là key lưu version của API (theo prefix của route group) trong gin.Context
+const APIVersion = "api_version"
+
+const (
+	APIVersion1 = "v1"
+	APIVersion2 = "v2"
+)
+
+// SetAPIVersion gắn version của route group vào context để handler đổi response theo version
+func SetAPIVersion(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(APIVersion, version)
+		c.Next()
+	}
+}
+
+// GetAPIVersion trả về version của request, route không nằm trong group có version thì coi là v1
+func GetAPIVersion(c *gin.Context) string {
+	if version := c.GetString(APIVersion); version != "" {
+		return version
+	}
+
+	return APIVersion1
+}
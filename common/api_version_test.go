@@ -0,0 +1,44 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/v1/version", SetAPIVersion(APIVersion1), func(c *gin.Context) { c.String(http.StatusOK, GetAPIVersion(c)) })
+	r.GET("/v2/version", SetAPIVersion(APIVersion2), func(c *gin.Context) { c.String(http.StatusOK, GetAPIVersion(c)) })
+	r.GET("/version", func(c *gin.Context) { c.String(http.StatusOK, GetAPIVersion(c)) })
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       string
+	}{
+		{"v1 group", "/v1/version", http.StatusOK, APIVersion1},
+		{"v2 group", "/v2/version", http.StatusOK, APIVersion2},
+		{"no group defaults to v1", "/version", http.StatusOK, APIVersion1},
+		{"unknown version", "/v3/version", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			if tt.want != "" && w.Body.String() != tt.want {
+				t.Errorf("version = %q, want %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
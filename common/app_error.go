@@ -0,0 +1,248 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var RecordNotFound = errors.New("record not found")
+
+type AppError struct {
+	StatusCode int    `json:"status_code"`
+	RootErr    error  `json:"-"`
+	Message    string `json:"message"`
+	// Log là lỗi gốc (có thể chứa lỗi của DB/driver), chỉ được log phía server, không trả cho client
+	Log string `json:"-"`
+	Key string `json:"error_key"`
+	// Details là lỗi theo từng field (field -> message), chỉ có với lỗi validation.
+	// Details luôn là tiếng Anh, Localize không dịch (client dựa vào tên field để hiển thị)
+	Details map[string]string `json:"details,omitempty"`
+	// Params là giá trị điền vào placeholder {tên} trong message của catalog
+	Params map[string]string `json:"-"`
+}
+
+func NewFullErrorResponse(statusCode int, root error, msg, key string) *AppError {
+	return &AppError{
+		StatusCode: statusCode,
+		RootErr:    root,
+		Message:    msg,
+		Log:        errorLog(root),
+		Key:        key,
+	}
+}
+
+func NewErrorResponse(root error, msg, key string) *AppError {
+	return NewFullErrorResponse(http.StatusBadRequest, root, msg, key)
+}
+
+func NewUnauthorized(root error, msg, key string) *AppError {
+	return NewFullErrorResponse(http.StatusUnauthorized, root, msg, key)
+}
+
+// NewCustomError giữ lại status code của AppError bên trong (ví dụ lỗi DB là 500), mặc định là 400
+func NewCustomError(root error, msg, key string) *AppError {
+	if root == nil {
+		return NewErrorResponse(errors.New(msg), msg, key)
+	}
+
+	var appErr *AppError
+
+	if errors.As(root, &appErr) {
+		return NewFullErrorResponse(appErr.StatusCode, root, msg, key)
+	}
+
+	return NewErrorResponse(root, msg, key)
+}
+
+// RootError đi xuyên qua các AppError lồng nhau để lấy lỗi gốc
+func (e *AppError) RootError() error {
+	var err *AppError
+
+	if errors.As(e.RootErr, &err) {
+		return err.RootError()
+	}
+
+	return e.RootErr
+}
+
+func (e *AppError) Error() string {
+	if root := e.RootError(); root != nil {
+		return root.Error()
+	}
+
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.RootErr
+}
+
+// ToAppError trả về AppError nằm trong err, nếu không có thì bọc lại thành lỗi 500
+func ToAppError(err error) *AppError {
+	var appErr *AppError
+
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return ErrInternal(err)
+}
+
+func errorLog(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// ErrDB trả về 503 nếu query bị huỷ do context (timeout hoặc client ngắt kết nối),
+// 409 nếu vi phạm unique index, còn lại là 500
+func ErrDB(err error) *AppError {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrServiceUnavailable(err)
+	}
+
+	if IsDuplicateKeyError(err) {
+		return ErrDuplicateKey(err)
+	}
+
+	return NewFullErrorResponse(http.StatusInternalServerError, err, "something went wrong with DB", "DB_ERROR")
+}
+
+func ErrServiceUnavailable(err error) *AppError {
+	return NewFullErrorResponse(http.StatusServiceUnavailable, err, "service is temporarily unavailable", "ErrServiceUnavailable")
+}
+
+// ErrInvalidRequest trả về 413 nếu lỗi bind là do body vượt quá BodyLimit,
+// 422 kèm lỗi từng field nếu là ValidationError, còn lại là 400
+func ErrInvalidRequest(err error) *AppError {
+	var maxBytesErr *http.MaxBytesError
+
+	if errors.As(err, &maxBytesErr) {
+		return ErrRequestTooLarge(err)
+	}
+
+	var validationErr *ValidationError
+
+	if errors.As(err, &validationErr) {
+		return ErrValidation(err)
+	}
+
+	return NewErrorResponse(err, "invalid request", "ErrInvalidRequest")
+}
+
+func ErrRequestTooLarge(err error) *AppError {
+	return NewFullErrorResponse(http.StatusRequestEntityTooLarge, err, "request body is too large", "ErrRequestTooLarge")
+}
+
+func ErrInternal(err error) *AppError {
+	return NewFullErrorResponse(http.StatusInternalServerError, err, "something went wrong in the server", "ErrInternal")
+}
+
+func ErrCannotListEntity(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("cannot list %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrCannotList%s", entity),
+	)
+}
+
+func ErrCannotGetEntity(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("cannot get %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrCannotGet%s", entity),
+	)
+}
+
+func ErrCannotCreateEntity(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("cannot create %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrCannotCreate%s", entity),
+	)
+}
+
+func ErrCannotUpdateEntity(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("cannot update %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrCannotUpdate%s", entity),
+	)
+}
+
+func ErrCannotDeleteEntity(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("cannot delete %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrCannotDelete%s", entity),
+	)
+}
+
+func ErrEntityDeleted(entity string, err error) *AppError {
+	return NewCustomError(
+		err,
+		fmt.Sprintf("%s deleted", strings.ToLower(entity)),
+		fmt.Sprintf("Err%sDeleted", entity),
+	)
+}
+
+// ErrEntityConflict dùng khi entity đã bị người khác sửa trong lúc đang update (version không khớp)
+func ErrEntityConflict(entity string, err error) *AppError {
+	return NewFullErrorResponse(
+		http.StatusConflict,
+		err,
+		fmt.Sprintf("%s was modified by someone else", strings.ToLower(entity)),
+		fmt.Sprintf("Err%sConflict", entity),
+	)
+}
+
+// ErrInvalidStateTransition dùng khi entity không được đổi từ trạng thái from sang to
+func ErrInvalidStateTransition(entity string, from, to string, err error) *AppError {
+	appErr := NewFullErrorResponse(
+		http.StatusConflict,
+		err,
+		fmt.Sprintf("cannot change %s status from %s to %s", strings.ToLower(entity), from, to),
+		fmt.Sprintf("ErrInvalid%sStatusTransition", entity),
+	)
+	appErr.Params = map[string]string{"from": from, "to": to}
+
+	return appErr
+}
+
+// ErrQuotaExceeded dùng khi requester đã dùng hết hạn mức (ví dụ số item được tạo trong ngày)
+func ErrQuotaExceeded(err error) *AppError {
+	return NewFullErrorResponse(http.StatusTooManyRequests, err, "quota exceeded, please retry later", "ErrQuotaExceeded")
+}
+
+func ErrEntityExisted(entity string, err error) *AppError {
+	return NewFullErrorResponse(
+		http.StatusConflict,
+		err,
+		fmt.Sprintf("%s already exists", strings.ToLower(entity)),
+		fmt.Sprintf("Err%sExisted", entity),
+	)
+}
+
+// ErrForbidden dùng khi entity có tồn tại nhưng requester không có quyền truy cập
+func ErrForbidden(entity string, err error) *AppError {
+	return NewFullErrorResponse(
+		http.StatusForbidden,
+		err,
+		fmt.Sprintf("you have no permission to access this %s", strings.ToLower(entity)),
+		fmt.Sprintf("ErrNoPermission%s", entity),
+	)
+}
+
+func ErrEntityNotFound(entity string, err error) *AppError {
+	return NewFullErrorResponse(
+		http.StatusNotFound,
+		err,
+		fmt.Sprintf("%s not found", strings.ToLower(entity)),
+		fmt.Sprintf("Err%sNotFound", entity),
+	)
+}
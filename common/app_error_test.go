@@ -0,0 +1,60 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAppErrorJSON(t *testing.T) {
+	validationErr := NewValidationError()
+	validationErr.Add("title", errors.New("title cannot be blank"))
+
+	tests := []struct {
+		name       string
+		err        *AppError
+		wantStatus int
+		wantKey    string
+		// hidden là nội dung của lỗi gốc, không được xuất hiện trong body
+		hidden string
+	}{
+		{"validation", ErrInvalidRequest(validationErr.Err()), http.StatusUnprocessableEntity, "ErrValidation", ""},
+		{"invalid request", ErrInvalidRequest(errors.New("unexpected EOF")), http.StatusBadRequest, "ErrInvalidRequest", "EOF"},
+		{"db", ErrDB(errors.New("Error 1146: Table 'todo.todo_items' doesn't exist")), http.StatusInternalServerError, "DB_ERROR", "todo_items"},
+		{"not found", ErrEntityNotFound("Item", RecordNotFound), http.StatusNotFound, "ErrItemNotFound", "record not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", tt.err.StatusCode, tt.wantStatus)
+			}
+
+			body, err := json.Marshal(tt.err)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]interface{}
+
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.name == "validation" && got["details"] == nil {
+				t.Errorf("validation error without details: %s", body)
+			}
+
+			if got["error_key"] != tt.wantKey {
+				t.Errorf("error_key = %v, want %s", got["error_key"], tt.wantKey)
+			}
+
+			if _, ok := got["log"]; ok || (tt.hidden != "" && strings.Contains(string(body), tt.hidden)) {
+				t.Errorf("root error leaked to client: %s", body)
+			}
+		})
+	}
+}
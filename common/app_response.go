@@ -1,13 +1,55 @@
 package common
 
+import (
+	"encoding/xml"
+	"reflect"
+)
+
 type successRes struct {
-	Data   interface{} `json:"data"`
-	Paging interface{} `json:"paging,omitempty"`
-	Filter interface{} `json:"filter,omitempty"`
+	XMLName xml.Name    `json:"-" xml:"response"`
+	Data    interface{} `json:"data" xml:"data>item"`
+	Paging  interface{} `json:"paging,omitempty" xml:"paging,omitempty"`
+	Filter  interface{} `json:"filter,omitempty" xml:"filter,omitempty"`
+	Links   interface{} `json:"links,omitempty" xml:"links,omitempty"`
+	DryRun  bool        `json:"dry_run,omitempty" xml:"dry_run,omitempty"`
+	// APIVersion chỉ có từ v2, response của v1 giữ nguyên như cũ
+	APIVersion string `json:"api_version,omitempty" xml:"api_version,omitempty"`
 }
 
+// NewSuccessResponse trả về envelope data/paging/filter, paging hoặc filter nil
+// (kể cả con trỏ nil như (*Paging)(nil)) thì bị bỏ khỏi JSON
 func NewSuccessResponse(data interface{}, paging interface{}, filter interface{}) *successRes {
-	return &successRes{Data: data, Paging: paging, Filter: filter}
+	return &successRes{Data: data, Paging: nilIfNilPointer(paging), Filter: nilIfNilPointer(filter)}
+}
+
+// nilIfNilPointer đổi con trỏ nil bọc trong interface thành nil thật để omitempty có tác dụng
+func nilIfNilPointer(v interface{}) interface{} {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	return v
+}
+
+// WithLinks gắn link phân trang (self/next/prev) vào response
+func (r *successRes) WithLinks(links *PagingLinks) *successRes {
+	r.Links = links
+	return r
+}
+
+// WithDryRun đánh dấu data chỉ là kết quả thử, không có gì được ghi xuống DB
+func (r *successRes) WithDryRun() *successRes {
+	r.DryRun = true
+	return r
+}
+
+// WithAPIVersion đánh dấu response thuộc version nào, v1 không đổi gì
+func (r *successRes) WithAPIVersion(version string) *successRes {
+	if version != APIVersion1 {
+		r.APIVersion = version
+	}
+
+	return r
 }
 
 func SimpleSuccessResponse(data interface{}) *successRes {
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSuccessResponseEnvelope(t *testing.T) {
+	type listFilter struct {
+		Status []string `json:"status,omitempty"`
+	}
+
+	var nilPaging *Paging
+
+	tests := []struct {
+		name string
+		res  *successRes
+		want map[string]interface{}
+	}{
+		{
+			"filtered paginated list",
+			NewSuccessResponse(
+				[]map[string]string{{"title": "a"}},
+				&Paging{Page: 2, Limit: 10, Total: 11},
+				&listFilter{Status: []string{"Doing"}},
+			),
+			map[string]interface{}{
+				"data":   []interface{}{map[string]interface{}{"title": "a"}},
+				"paging": map[string]interface{}{"page": 2.0, "limit": 10.0, "total": 11.0},
+				"filter": map[string]interface{}{"status": []interface{}{"Doing"}},
+			},
+		},
+		{
+			"single object",
+			SimpleSuccessResponse(map[string]string{"title": "a"}),
+			map[string]interface{}{"data": map[string]interface{}{"title": "a"}},
+		},
+		{
+			"nil paging and filter omitted",
+			NewSuccessResponse([]int{}, nil, nil),
+			map[string]interface{}{"data": []interface{}{}},
+		},
+		{
+			"nil pointer paging and filter omitted",
+			NewSuccessResponse([]int{}, nilPaging, (*listFilter)(nil)),
+			map[string]interface{}{"data": []interface{}{}},
+		},
+		{
+			"v1 has no version marker",
+			NewSuccessResponse([]int{}, nil, nil).WithAPIVersion(APIVersion1),
+			map[string]interface{}{"data": []interface{}{}},
+		},
+		{
+			"v2 is marked",
+			NewSuccessResponse([]int{}, nil, nil).WithAPIVersion(APIVersion2),
+			map[string]interface{}{"data": []interface{}{}, "api_version": "v2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.res)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]interface{}
+
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("body = %s, want %v", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// BodyLimit giới hạn body request ở maxBytes byte, đọc quá giới hạn thì bind lỗi và
+// ErrInvalidRequest trả 413 thay vì đọc hết body vào bộ nhớ. maxBytes <= 0 là không giới hạn
+func BodyLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBytes > 0 && c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		c.Next()
+	}
+}
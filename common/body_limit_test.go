@@ -0,0 +1,57 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/", BodyLimit(64), func(c *gin.Context) {
+		var data struct {
+			Title string `json:"title"`
+		}
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, SimpleSuccessResponse(data.Title))
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantKey    string
+	}{
+		{"under the limit", `{"title":"buy milk"}`, http.StatusOK, ""},
+		{"over the limit", `{"title":"` + strings.Repeat("a", 100) + `"}`, http.StatusRequestEntityTooLarge, "ErrRequestTooLarge"},
+		{"malformed under the limit", `{"title":`, http.StatusBadRequest, "ErrInvalidRequest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", MIMEJSON)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if tt.wantKey != "" && !strings.Contains(w.Body.String(), `"error_key":"`+tt.wantKey+`"`) {
+				t.Errorf("body = %s, want error key %s", w.Body, tt.wantKey)
+			}
+		})
+	}
+}
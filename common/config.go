@@ -0,0 +1,333 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingDBDsn     = errors.New("missing required env DB_DSN")
+	ErrMissingJWTSecret = errors.New("missing required env JWT_SECRET")
+)
+
+type Config struct {
+	// DBDriver là "mysql" (mặc định) hoặc "sqlite"
+	DBDriver string
+	DBDsn    string
+	// DBReplicaDsn là DSN của read replica (cùng DBDriver), rỗng là mọi query đều đi primary
+	DBReplicaDsn string
+	// DBLogLevel là "silent", "error", "warn" (mặc định) hoặc "info"
+	DBLogLevel string
+	// Query chạy lâu hơn DBSlowThreshold bị log ở mức WARN, 0 là tắt
+	DBSlowThreshold time.Duration
+	// Thời gian tối đa cho các query của một request, 0 là không giới hạn
+	DBStatementTimeout time.Duration
+	AutoMigrate        bool
+	Port               string
+	Env                string
+	JWTSecret          string
+	ShutdownTimeout    time.Duration
+	// Thời gian tối đa cho mỗi lần ping DB ở /healthz
+	HealthCheckTimeout time.Duration
+	// Giới hạn request tạo item cho mỗi requester/IP, RateLimitRPS = 0 là không giới hạn
+	RateLimitRPS   int
+	RateLimitBurst int
+	// Mỗi PurgeInterval xoá hẳn các item đã xoá mềm lâu hơn PurgeRetention, PurgeInterval = 0 là tắt
+	PurgeInterval  time.Duration
+	PurgeRetention time.Duration
+	// Mỗi ReminderInterval nhắc các item sẽ đến hạn trong ReminderWindow tới, ReminderInterval = 0 là tắt
+	ReminderInterval time.Duration
+	ReminderWindow   time.Duration
+	// Mỗi RecurrenceInterval sinh lần lặp kế tiếp cho các item lặp lại đã Done, RecurrenceInterval = 0 là tắt
+	RecurrenceInterval time.Duration
+	// Mỗi OutboxRelayInterval gửi các event trong outbox chưa được gửi (cả websocket), OutboxRelayInterval = 0 là tắt.
+	// Event đã gửi được giữ OutboxRetention rồi bị xoá, job xoá chạy mỗi PurgeInterval
+	OutboxRelayInterval time.Duration
+	OutboxRetention     time.Duration
+	// ItemCacheBackend là "none" (mặc định), "memory" (tối đa ItemCacheSize item mỗi instance) hoặc "redis",
+	// mỗi item được cache trong ItemCacheTTL
+	ItemCacheBackend string
+	ItemCacheSize    int
+	ItemCacheTTL     time.Duration
+	RedisAddr        string
+	RedisPassword    string
+	RedisDB          int
+	// Thời gian giữ Idempotency-Key của request tạo item
+	IdempotencyKeyTTL time.Duration
+	// Số item tối đa mỗi user được tạo trong một ngày (tính theo UTC), 0 là không giới hạn
+	DailyCreateQuota int
+	// DefaultItemStatus là status của item mới khi client không gửi status (Doing hoặc Done)
+	DefaultItemStatus string
+	// HTMLSanitizeMode là "strip" (mặc định, bỏ tag HTML) hoặc "escape", áp dụng cho title/description của item
+	HTMLSanitizeMode string
+	// Số ký tự tối đa của title/description của item, 0 là không giới hạn
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	// Số gợi ý tối đa của GET /v1/items/suggest
+	SuggestLimit int
+	// Item có tồn tại nhưng thuộc user khác thì trả 404 như không tồn tại (mặc định) hay trả 403
+	HideForbiddenAsNotFound bool
+	// Webhook được trỏ tới localhost/IP nội bộ hay không, mặc định là không ở mọi APP_ENV
+	WebhookAllowPrivateURLs bool
+	// Kích thước tối đa (byte) của body request gửi lên API item
+	MaxBodySize int64
+	// Response nhỏ hơn GzipMinSize byte thì không nén
+	GzipMinSize int
+	// Danh sách origin cho phép gọi API từ trình duyệt, "*" là mọi origin
+	CORSAllowedOrigins []string
+	// UploadProvider là "local" (lưu vào UploadDir, truy cập qua UploadBaseURL) hoặc "s3"
+	UploadProvider string
+	UploadDir      string
+	UploadBaseURL  string
+	UploadMaxSize  int64
+	S3Bucket       string
+	S3Region       string
+	S3AccessKey    string
+	S3SecretKey    string
+	// S3BaseURL để trống thì dùng URL public của bucket
+	S3BaseURL string
+}
+
+// LoadConfig đọc cấu hình từ biến môi trường, giá trị bắt buộc mà thiếu thì trả về lỗi
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		// DB_CONN_STR là tên cũ, vẫn đọc để không làm hỏng các môi trường đang chạy
+		DBDriver: getEnv("DB_DRIVER", DBDriverMySQL),
+		DBDsn:    getEnv("DB_DSN", os.Getenv("DB_CONN_STR")),
+		Port:     getEnv("PORT", "8080"),
+		Env:      getEnv("APP_ENV", "development"),
+
+		DBReplicaDsn: os.Getenv("DB_REPLICA_DSN"),
+
+		DBLogLevel: getEnv("DB_LOG_LEVEL", "warn"),
+
+		JWTSecret: os.Getenv("JWT_SECRET"),
+
+		CORSAllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS"),
+
+		UploadProvider: getEnv("UPLOAD_PROVIDER", UploadProviderLocal),
+		UploadDir:      getEnv("UPLOAD_DIR", "static"),
+		UploadBaseURL:  getEnv("UPLOAD_BASE_URL", "/static"),
+		S3Bucket:       os.Getenv("S3_BUCKET"),
+		S3Region:       os.Getenv("S3_REGION"),
+		S3AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:    os.Getenv("S3_SECRET_KEY"),
+		S3BaseURL:      os.Getenv("S3_BASE_URL"),
+
+		ItemCacheBackend: getEnv("ITEM_CACHE_BACKEND", "none"),
+		RedisAddr:        getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:    os.Getenv("REDIS_PASSWORD"),
+
+		HTMLSanitizeMode:  getEnv("HTML_SANITIZE_MODE", HTMLSanitizeStrip),
+		DefaultItemStatus: getEnv("DEFAULT_ITEM_STATUS", "Doing"),
+	}
+
+	if cfg.DBDsn == "" {
+		return nil, ErrMissingDBDsn
+	}
+
+	if cfg.JWTSecret == "" {
+		return nil, ErrMissingJWTSecret
+	}
+
+	var err error
+
+	if _, err = ParseGormLogLevel(cfg.DBLogLevel); err != nil {
+		return nil, err
+	}
+
+	if cfg.DBSlowThreshold, err = getEnvDuration("DB_SLOW_THRESHOLD", 200*time.Millisecond); err != nil {
+		return nil, err
+	}
+
+	if cfg.DBStatementTimeout, err = getEnvDuration("DB_STATEMENT_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.IdempotencyKeyTTL, err = getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.PurgeInterval, err = getEnvDuration("PURGE_INTERVAL", time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.PurgeRetention, err = getEnvDuration("PURGE_RETENTION", 30*24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReminderInterval, err = getEnvDuration("REMINDER_INTERVAL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReminderWindow, err = getEnvDuration("REMINDER_WINDOW", time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.RecurrenceInterval, err = getEnvDuration("RECURRENCE_INTERVAL", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.OutboxRelayInterval, err = getEnvDuration("OUTBOX_RELAY_INTERVAL", time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.OutboxRetention, err = getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour); err != nil {
+		return nil, err
+	}
+
+	if cfg.ShutdownTimeout, err = getEnvDuration("SHUTDOWN_TIMEOUT", 10*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.HealthCheckTimeout, err = getEnvDuration("HEALTHCHECK_TIMEOUT", 2*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.AutoMigrate, err = getEnvBool("AUTO_MIGRATE", false); err != nil {
+		return nil, err
+	}
+
+	if cfg.HideForbiddenAsNotFound, err = getEnvBool("HIDE_FORBIDDEN_AS_NOT_FOUND", true); err != nil {
+		return nil, err
+	}
+
+	if cfg.WebhookAllowPrivateURLs, err = getEnvBool("WEBHOOK_ALLOW_PRIVATE_URLS", false); err != nil {
+		return nil, err
+	}
+
+	uploadMaxSize, err := getEnvInt("UPLOAD_MAX_SIZE", 5<<20)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.UploadMaxSize = int64(uploadMaxSize)
+
+	if cfg.GzipMinSize, err = getEnvInt("GZIP_MIN_SIZE", 1024); err != nil {
+		return nil, err
+	}
+
+	maxBodySize, err := getEnvInt("MAX_BODY_SIZE", 1<<20)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.MaxBodySize = int64(maxBodySize)
+
+	if cfg.RedisDB, err = getEnvInt("REDIS_DB", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.ItemCacheSize, err = getEnvInt("ITEM_CACHE_SIZE", 10000); err != nil {
+		return nil, err
+	}
+
+	if cfg.ItemCacheTTL, err = getEnvDuration("ITEM_CACHE_TTL", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.DailyCreateQuota, err = getEnvInt("DAILY_CREATE_QUOTA", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.SuggestLimit, err = getEnvInt("SUGGEST_LIMIT", 5); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxTitleLength, err = getEnvInt("MAX_TITLE_LENGTH", 200); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxDescriptionLength, err = getEnvInt("MAX_DESCRIPTION_LENGTH", 10000); err != nil {
+		return nil, err
+	}
+
+	if cfg.RateLimitRPS, err = getEnvInt("RATE_LIMIT_RPS", 5); err != nil {
+		return nil, err
+	}
+
+	if cfg.RateLimitBurst, err = getEnvInt("RATE_LIMIT_BURST", 10); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *Config) Addr() string {
+	return ":" + cfg.Port
+}
+
+func getEnv(key, defaultValue string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	v := os.Getenv(key)
+
+	if v == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(v)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid env %s=%q: %w", key, v, err)
+	}
+
+	return d, nil
+}
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	v := os.Getenv(key)
+
+	if v == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(v)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid env %s=%q: %w", key, v, err)
+	}
+
+	return i, nil
+}
+
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	v := os.Getenv(key)
+
+	if v == "" {
+		return defaultValue, nil
+	}
+
+	b, err := strconv.ParseBool(v)
+
+	if err != nil {
+		return false, fmt.Errorf("invalid env %s=%q: %w", key, v, err)
+	}
+
+	return b, nil
+}
+
+// getEnvList đọc danh sách phân cách bởi dấu phẩy, bỏ qua phần tử rỗng
+func getEnvList(key string) []string {
+	var result []string
+
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
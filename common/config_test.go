@@ -0,0 +1,172 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// setTestEnv đặt env cho một test, key không có trong env được đặt rỗng để không bị ảnh hưởng bởi môi trường chạy test
+func setTestEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for _, key := range []string{"DB_DSN", "DB_CONN_STR", "JWT_SECRET", "PORT", "SHUTDOWN_TIMEOUT", "AUTO_MIGRATE", "RATE_LIMIT_RPS", "MAX_BODY_SIZE", "HIDE_FORBIDDEN_AS_NOT_FOUND", "MAX_TITLE_LENGTH", "MAX_DESCRIPTION_LENGTH", "APP_ENV", "WEBHOOK_ALLOW_PRIVATE_URLS"} {
+		t.Setenv(key, env[key])
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr error
+		check   func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "parsed values",
+			env: map[string]string{
+				"DB_DSN":           "root:secret@tcp(db:3306)/todo",
+				"JWT_SECRET":       "secret",
+				"PORT":             "3000",
+				"SHUTDOWN_TIMEOUT": "30s",
+				"AUTO_MIGRATE":     "true",
+				"RATE_LIMIT_RPS":   "0",
+			},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.DBDsn != "root:secret@tcp(db:3306)/todo" {
+					t.Errorf("DBDsn = %q", cfg.DBDsn)
+				}
+
+				if cfg.Addr() != ":3000" {
+					t.Errorf("Addr = %q, want :3000", cfg.Addr())
+				}
+
+				if cfg.ShutdownTimeout != 30*time.Second {
+					t.Errorf("ShutdownTimeout = %v, want 30s", cfg.ShutdownTimeout)
+				}
+
+				if !cfg.AutoMigrate {
+					t.Error("AutoMigrate = false, want true")
+				}
+
+				if cfg.RateLimitRPS != 0 {
+					t.Errorf("RateLimitRPS = %d, want 0", cfg.RateLimitRPS)
+				}
+			},
+		},
+		{
+			name: "defaults",
+			env:  map[string]string{"DB_DSN": "dsn", "JWT_SECRET": "secret"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Port != "8080" {
+					t.Errorf("Port = %q, want 8080", cfg.Port)
+				}
+
+				if cfg.ShutdownTimeout != 10*time.Second {
+					t.Errorf("ShutdownTimeout = %v, want 10s", cfg.ShutdownTimeout)
+				}
+
+				if cfg.AutoMigrate {
+					t.Error("AutoMigrate = true, want false")
+				}
+
+				if !cfg.HideForbiddenAsNotFound {
+					t.Error("HideForbiddenAsNotFound = false, want true")
+				}
+
+				if cfg.MaxBodySize != 1<<20 {
+					t.Errorf("MaxBodySize = %d, want 1MB", cfg.MaxBodySize)
+				}
+
+				if cfg.WebhookAllowPrivateURLs {
+					t.Error("WebhookAllowPrivateURLs = true, want false")
+				}
+
+				if cfg.MaxTitleLength != 200 || cfg.MaxDescriptionLength != 10000 {
+					t.Errorf("MaxTitleLength, MaxDescriptionLength = %d, %d, want 200, 10000", cfg.MaxTitleLength, cfg.MaxDescriptionLength)
+				}
+			},
+		},
+		{
+			name: "private webhook urls stay off outside production",
+			env:  map[string]string{"DB_DSN": "dsn", "JWT_SECRET": "secret", "APP_ENV": "development"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.WebhookAllowPrivateURLs {
+					t.Error("WebhookAllowPrivateURLs = true, want false")
+				}
+			},
+		},
+		{
+			name: "private webhook urls opted in",
+			env:  map[string]string{"DB_DSN": "dsn", "JWT_SECRET": "secret", "WEBHOOK_ALLOW_PRIVATE_URLS": "true"},
+			check: func(t *testing.T, cfg *Config) {
+				if !cfg.WebhookAllowPrivateURLs {
+					t.Error("WebhookAllowPrivateURLs = false, want true")
+				}
+			},
+		},
+		{
+			name: "legacy DB_CONN_STR",
+			env:  map[string]string{"DB_CONN_STR": "legacy", "JWT_SECRET": "secret"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.DBDsn != "legacy" {
+					t.Errorf("DBDsn = %q, want legacy", cfg.DBDsn)
+				}
+			},
+		},
+		{
+			name:    "missing DB_DSN",
+			env:     map[string]string{"JWT_SECRET": "secret"},
+			wantErr: ErrMissingDBDsn,
+		},
+		{
+			name:    "missing JWT_SECRET",
+			env:     map[string]string{"DB_DSN": "dsn"},
+			wantErr: ErrMissingJWTSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestEnv(t, tt.env)
+
+			cfg, err := LoadConfig()
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadConfigInvalidValue(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"SHUTDOWN_TIMEOUT", "10"},
+		{"AUTO_MIGRATE", "maybe"},
+		{"RATE_LIMIT_RPS", "five"},
+		{"MAX_TITLE_LENGTH", "long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			setTestEnv(t, map[string]string{"DB_DSN": "dsn", "JWT_SECRET": "secret", tt.key: tt.value})
+
+			if _, err := LoadConfig(); err == nil {
+				t.Errorf("LoadConfig with %s=%q: want error", tt.key, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	MIMEJSON = "application/json"
+	MIMEXML  = "application/xml"
+
+	// ResponseFormat là key trong gin context lưu định dạng response đã chọn theo header Accept
+	ResponseFormat = "response_format"
+)
+
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// ContentNegotiation đọc header Accept và chọn JSON hoặc XML cho response.
+// Không có Accept hoặc Accept là */* thì dùng JSON, XML chỉ được chọn khi client ưu tiên hơn JSON.
+// Accept không chấp nhận cả hai thì trả 406. Response phụ thuộc Accept nên luôn có Vary: Accept
+func ContentNegotiation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept")
+
+		format, ok := negotiateFormat(c.GetHeader("Accept"))
+
+		if !ok {
+			appErr := NewFullErrorResponse(
+				http.StatusNotAcceptable,
+				ErrNotAcceptable,
+				"only application/json and application/xml are supported",
+				"ErrNotAcceptable",
+			)
+			c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+			return
+		}
+
+		c.Set(ResponseFormat, format)
+		c.Next()
+	}
+}
+
+// NegotiatedFormat trả về định dạng ContentNegotiation đã chọn, mặc định là JSON
+func NegotiatedFormat(c *gin.Context) string {
+	if c.GetString(ResponseFormat) == MIMEXML {
+		return MIMEXML
+	}
+
+	return MIMEJSON
+}
+
+// Render trả response theo định dạng ContentNegotiation đã chọn, mặc định là JSON
+func Render(c *gin.Context, code int, obj interface{}) {
+	if NegotiatedFormat(c) == MIMEXML {
+		c.XML(code, obj)
+		return
+	}
+
+	c.JSON(code, obj)
+}
+
+func negotiateFormat(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return MIMEJSON, true
+	}
+
+	jsonQ, xmlQ := acceptQuality(accept, MIMEJSON), acceptQuality(accept, MIMEXML)
+
+	switch {
+	case jsonQ == 0 && xmlQ == 0:
+		return "", false
+	case xmlQ > jsonQ:
+		return MIMEXML, true
+	default:
+		return MIMEJSON, true
+	}
+}
+
+// acceptQuality trả về q của mime trong header Accept, media range cụ thể nhất được ưu tiên
+// (application/json > application/* > */*), không khớp thì trả 0
+func acceptQuality(accept, mime string) float64 {
+	mainType, _, _ := strings.Cut(mime, "/")
+	quality, specificity := 0.0, -1
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+		var s int
+
+		switch mediaRange {
+		case mime:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		default:
+			continue
+		}
+
+		if s < specificity {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = v
+			}
+		}
+
+		quality, specificity = q, s
+	}
+
+	return quality
+}
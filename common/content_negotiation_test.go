@@ -0,0 +1,67 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContentNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		accept      string
+		wantStatus  int
+		wantContent string
+	}{
+		{"no accept", "", http.StatusOK, MIMEJSON},
+		{"any", "*/*", http.StatusOK, MIMEJSON},
+		{"json", "application/json", http.StatusOK, MIMEJSON},
+		{"xml", "application/xml", http.StatusOK, MIMEXML},
+		{"json preferred by q", "application/xml;q=0.5, application/json", http.StatusOK, MIMEJSON},
+		{"xml preferred by q", "application/json;q=0.5, application/xml", http.StatusOK, MIMEXML},
+		{"not acceptable", "text/html", http.StatusNotAcceptable, MIMEJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/", ContentNegotiation(), func(c *gin.Context) {
+				Render(c, http.StatusOK, SimpleSuccessResponse(true))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			if got := w.Header().Get("Content-Type"); got != tt.wantContent+"; charset=utf-8" {
+				t.Errorf("content type = %q, want %s", got, tt.wantContent)
+			}
+
+			if vary := w.Header().Values("Vary"); !containsString(vary, "Accept") {
+				t.Errorf("vary = %v, want Accept", vary)
+			}
+		})
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
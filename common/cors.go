@@ -0,0 +1,67 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+const (
+	corsAllowMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders = "Authorization, Content-Type, X-Request-Id, Idempotency-Key, If-None-Match"
+	// Header trong response mà JS trên trình duyệt được đọc, ngoài các header mặc định
+	corsExposeHeaders = HeaderRequestId + ", Retry-After, " + HeaderETag
+	corsMaxAge        = "600"
+)
+
+// CORS trả header Access-Control-* cho origin nằm trong allowedOrigins và trả 204 cho preflight.
+// Origin "*" cho phép mọi nơi nhưng khi đó không gửi Allow-Credentials (trình duyệt không cho phép kết hợp).
+func CORS(allowedOrigins []string) gin.HandlerFunc {
+	allowAll := false
+	allowed := make(map[string]bool, len(allowedOrigins))
+
+	for _, origin := range allowedOrigins {
+		origin = strings.TrimSpace(origin)
+
+		if origin == "*" {
+			allowAll = true
+			continue
+		}
+
+		if origin != "" {
+			allowed[origin] = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+
+		if origin != "" {
+			c.Writer.Header().Add("Vary", "Origin")
+
+			switch {
+			case allowed[origin]:
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Access-Control-Allow-Credentials", "true")
+			case allowAll:
+				c.Header("Access-Control-Allow-Origin", "*")
+			default:
+				origin = ""
+			}
+
+			if origin != "" {
+				c.Header("Access-Control-Allow-Methods", corsAllowMethods)
+				c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+				c.Header("Access-Control-Expose-Headers", corsExposeHeaders)
+				c.Header("Access-Control-Max-Age", corsMaxAge)
+			}
+		}
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		allowedOrigins  []string
+		method          string
+		origin          string
+		wantStatus      int
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{"allowed origin", []string{"https://app.example.com"}, http.MethodGet, "https://app.example.com", http.StatusOK, "https://app.example.com", "true"},
+		{"disallowed origin", []string{"https://app.example.com"}, http.MethodGet, "https://evil.example.com", http.StatusOK, "", ""},
+		{"wildcard without credentials", []string{"*"}, http.MethodGet, "https://any.example.com", http.StatusOK, "*", ""},
+		{"preflight", []string{"https://app.example.com"}, http.MethodOptions, "https://app.example.com", http.StatusNoContent, "https://app.example.com", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(CORS(tt.allowedOrigins))
+			r.GET("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/items", nil)
+			req.Header.Set("Origin", tt.origin)
+
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+
+			if tt.wantOrigin == "" && w.Header().Get("Access-Control-Allow-Methods") != "" {
+				t.Error("CORS headers set for a disallowed origin")
+			}
+		})
+	}
+}
+
+func TestCORSExposeHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(CORS([]string{"*"}))
+	r.GET("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	exposed := w.Header().Get("Access-Control-Expose-Headers")
+
+	for _, header := range []string{HeaderRequestId, "Retry-After", HeaderETag} {
+		if !strings.Contains(exposed, header) {
+			t.Errorf("Expose-Headers = %q, want it to contain %s", exposed, header)
+		}
+	}
+}
+
+func TestCORSPreflightAllowHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(CORS([]string{"https://app.example.com"}))
+	r.POST("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"auth", "Authorization"},
+		{"idempotency key", "Idempotency-Key"},
+		{"conditional get", HeaderIfNoneMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", tt.header)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d, want 204", w.Code)
+			}
+
+			if allowed := w.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(allowed, tt.header) {
+				t.Errorf("Allow-Headers = %q, want it to contain %s", allowed, tt.header)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"fmt"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+	"log/slog"
+	"time"
+)
+
+const (
+	DBDriverMySQL  = "mysql"
+	DBDriverSQLite = "sqlite"
+)
+
+// NewDatabase mở kết nối GORM theo cfg.DBDriver: mysql cho production, sqlite cho môi trường dev.
+// cfg.DBReplicaDsn khác rỗng thì đăng ký thêm replica (cùng driver) qua dbresolver: câu SELECT đi replica,
+// câu ghi và transaction đi primary. Luồng cần đọc dữ liệu vừa ghi thì dùng Primary
+func NewDatabase(cfg Config) (*gorm.DB, error) {
+	dialector, err := openDialector(cfg.DBDriver, cfg.DBDsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logLevel, err := ParseGormLogLevel(cfg.DBLogLevel)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: NewGormLogger(slog.Default(), logLevel, cfg.DBSlowThreshold),
+		// Giờ lưu xuống DB luôn là UTC, kể cả khi GORM tự set created_at/updated_at
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+
+	if err != nil || cfg.DBReplicaDsn == "" {
+		return db, err
+	}
+
+	replica, err := openDialector(cfg.DBDriver, cfg.DBReplicaDsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{Replicas: []gorm.Dialector{replica}})); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Primary trả về DB mà cả câu SELECT cũng đi primary, dùng cho luồng đọc rồi ghi và nơi nạp cache
+// vì replica có thể trễ hơn primary một chút. Không cấu hình replica thì giống hệt db
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
+
+func openDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DBDriverMySQL, "":
+		return mysql.Open(dsn), nil
+	case DBDriverSQLite:
+		return sqlite.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", driver)
+	}
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+// Mã lỗi MySQL khi vi phạm unique index hoặc primary key
+const mysqlErrDuplicateEntry = 1062
+
+// IsDuplicateKeyError báo lỗi vi phạm unique index/primary key của MySQL (1062) hoặc SQLite
+func IsDuplicateKeyError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrDuplicateEntry
+	}
+
+	var sqliteErr sqlite3.Error
+
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+			sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	return false
+}
+
+func ErrDuplicateKey(err error) *AppError {
+	return NewFullErrorResponse(http.StatusConflict, err, "record already exists", "ErrDuplicateKey")
+}
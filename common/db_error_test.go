@@ -0,0 +1,87 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"net/http"
+	"testing"
+)
+
+type testUniqueNote struct {
+	Id    int    `gorm:"column:id;"`
+	Title string `gorm:"column:title;uniqueIndex;"`
+}
+
+// sqliteConstraintErrors trả về lỗi thật của SQLite khi trùng unique index và khi trùng primary key
+func sqliteConstraintErrors(t *testing.T) (unique, primaryKey error) {
+	t.Helper()
+
+	db, err := NewDatabase(Config{DBDriver: DBDriverSQLite, DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&testUniqueNote{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Create(&testUniqueNote{Id: 1, Title: "a"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	unique = db.Create(&testUniqueNote{Id: 2, Title: "a"}).Error
+	primaryKey = db.Create(&testUniqueNote{Id: 1, Title: "b"}).Error
+
+	if unique == nil || primaryKey == nil {
+		t.Fatalf("duplicate inserts succeeded: %v, %v", unique, primaryKey)
+	}
+
+	return unique, primaryKey
+}
+
+func TestIsDuplicateKeyError(t *testing.T) {
+	unique, primaryKey := sqliteConstraintErrors(t)
+
+	tests := []struct {
+		name       string
+		err        error
+		want       bool
+		wantStatus int
+	}{
+		{"sqlite unique index", unique, true, http.StatusConflict},
+		{"sqlite primary key", primaryKey, true, http.StatusConflict},
+		{"wrapped sqlite error", fmt.Errorf("insert: %w", unique), true, http.StatusConflict},
+		{"gorm translated", gorm.ErrDuplicatedKey, true, http.StatusConflict},
+		{"mysql 1062", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, true, http.StatusConflict},
+		{"other mysql error", &mysql.MySQLError{Number: 1146, Message: "Table doesn't exist"}, false, http.StatusInternalServerError},
+		{"generic error", errors.New("connection refused"), false, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDuplicateKeyError(tt.err); got != tt.want {
+				t.Errorf("IsDuplicateKeyError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+
+			if status := ErrDB(tt.err).StatusCode; status != tt.wantStatus {
+				t.Errorf("ErrDB status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+
+	if IsDuplicateKeyError(nil) {
+		t.Error("IsDuplicateKeyError(nil) = true")
+	}
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+type testNote struct {
+	Id   int    `gorm:"column:id;"`
+	Body string `gorm:"column:body;"`
+}
+
+func TestNewDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"sqlite", Config{DBDriver: DBDriverSQLite, DBDsn: "file::memory:"}, false},
+		{"unsupported driver", Config{DBDriver: "postgres", DBDsn: "dsn"}, true},
+		{"invalid log level", Config{DBDriver: DBDriverSQLite, DBDsn: "file::memory:", DBLogLevel: "loud"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := NewDatabase(tt.cfg)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			closeTestDB(t, db, false)
+
+			sqlDB, _ := db.DB()
+			sqlDB.SetMaxOpenConns(1)
+
+			if err := db.AutoMigrate(&testNote{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.Create(&testNote{Body: "hello"}).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			var got testNote
+
+			if err := db.First(&got).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Id == 0 || got.Body != "hello" {
+				t.Errorf("read back %+v", got)
+			}
+		})
+	}
+}
+
+// newTestSQLiteFile tạo file sqlite có bảng testNote với một dòng body, đứng thay cho primary hoặc replica
+func newTestSQLiteFile(t *testing.T, name, body string) string {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), name+".db")
+	db, err := NewDatabase(Config{DBDriver: DBDriverSQLite, DBDsn: dsn})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closeTestDB(t, db, false)
+
+	if err := db.AutoMigrate(&testNote{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Create(&testNote{Body: body}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return dsn
+}
+
+func TestNewDatabaseReplica(t *testing.T) {
+	primaryDsn := newTestSQLiteFile(t, "primary", "primary")
+	replicaDsn := newTestSQLiteFile(t, "replica", "replica")
+
+	open := func(t *testing.T, replica string) *gorm.DB {
+		t.Helper()
+
+		db, err := NewDatabase(Config{DBDriver: DBDriverSQLite, DBDsn: primaryDsn, DBReplicaDsn: replica})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		closeTestDB(t, db, false)
+
+		return db
+	}
+
+	withReplica := open(t, replicaDsn)
+	withoutReplica := open(t, "")
+
+	tests := []struct {
+		name string
+		read func(note *testNote) error
+		want string
+	}{
+		{"select goes to the replica", func(note *testNote) error { return withReplica.First(note).Error }, "replica"},
+		{"Primary reads the primary", func(note *testNote) error { return Primary(withReplica).First(note).Error }, "primary"},
+		{"transaction reads the primary", func(note *testNote) error {
+			return withReplica.Transaction(func(tx *gorm.DB) error { return tx.First(note).Error })
+		}, "primary"},
+		{"no replica configured", func(note *testNote) error { return withoutReplica.First(note).Error }, "primary"},
+		{"Primary without replica", func(note *testNote) error { return Primary(withoutReplica).First(note).Error }, "primary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var note testNote
+
+			if err := tt.read(&note); err != nil {
+				t.Fatal(err)
+			}
+
+			if note.Body != tt.want {
+				t.Errorf("read %q, want %q", note.Body, tt.want)
+			}
+		})
+	}
+
+	if err := withReplica.Create(&testNote{Body: "written"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var written int64
+
+	if err := Primary(withReplica).Model(&testNote{}).Where("body = ?", "written").Count(&written).Error; err != nil || written != 1 {
+		t.Errorf("primary has %d written rows (%v), want 1", written, err)
+	}
+
+	if err := withReplica.Model(&testNote{}).Where("body = ?", "written").Count(&written).Error; err != nil || written != 0 {
+		t.Errorf("replica has %d written rows (%v), want the write to go to the primary only", written, err)
+	}
+}
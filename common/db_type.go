@@ -0,0 +1,9 @@
+package common
+
+const (
+	DbTypeItem    = 1
+	DbTypeUser    = 2
+	DbTypeComment = 3
+	DbTypeSubtask = 4
+	DbTypeWebhook = 5
+)
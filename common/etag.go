@@ -0,0 +1,52 @@
+package common
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// NewETag tạo strong ETag (đã có dấu nháy) từ các giá trị thể hiện phiên bản của entity
+func NewETag(parts ...interface{}) string {
+	h := sha1.New()
+
+	for _, p := range parts {
+		fmt.Fprintf(h, "%v|", p)
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil))[:20] + `"`
+}
+
+// MatchETag kiểm tra header If-None-Match có chứa etag không.
+// Header sai định dạng (thiếu dấu nháy...) coi như không khớp
+func MatchETag(ifNoneMatch, etag string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	if ifNoneMatch == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+
+		if tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
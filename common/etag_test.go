@@ -0,0 +1,29 @@
+package common
+
+import "testing"
+
+func TestMatchETag(t *testing.T) {
+	etag := NewETag(1, "2024-01-01T00:00:00Z", 3)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"empty", "", false},
+		{"exact", etag, true},
+		{"weak", "W/" + etag, true},
+		{"in a list", `"other", ` + etag, true},
+		{"wildcard", "*", true},
+		{"different", `"other"`, false},
+		{"malformed without quotes", etag[1 : len(etag)-1], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchETag(tt.ifNoneMatch, etag); got != tt.want {
+				t.Errorf("MatchETag(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package common
+
+import "sync"
+
+// Số event được giữ lại cho mỗi subscriber chưa kịp đọc, đầy thì event mới bị bỏ
+const eventBufferSize = 32
+
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// EventBus là pub/sub trong bộ nhớ theo từng user, chỉ dùng được khi chạy một instance
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]map[*Subscription]struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[int]map[*Subscription]struct{})}
+}
+
+type Subscription struct {
+	bus    *EventBus
+	userId int
+	events chan Event
+	once   sync.Once
+}
+
+// Events trả về channel nhận event, channel bị đóng khi gọi Close
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close huỷ đăng ký, gọi nhiều lần vẫn an toàn
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+
+		delete(s.bus.subscribers[s.userId], s)
+
+		if len(s.bus.subscribers[s.userId]) == 0 {
+			delete(s.bus.subscribers, s.userId)
+		}
+
+		close(s.events)
+	})
+}
+
+func (b *EventBus) Subscribe(userId int) *Subscription {
+	sub := &Subscription{bus: b, userId: userId, events: make(chan Event, eventBufferSize)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[userId] == nil {
+		b.subscribers[userId] = make(map[*Subscription]struct{})
+	}
+
+	b.subscribers[userId][sub] = struct{}{}
+
+	return sub
+}
+
+// Publish gửi event tới mọi subscriber của user, không chặn người ghi khi subscriber đọc chậm
+func (b *EventBus) Publish(userId int, event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[userId] {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// SubscriberCount trả về số subscriber đang mở của user
+func (b *EventBus) SubscriberCount(userId int) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers[userId])
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+)
+
+// EventDispatcher gửi event ra ngoài (webhook, message queue...), lỗi trả về để event được gửi lại sau
+type EventDispatcher interface {
+	Dispatch(ctx context.Context, userId int, event Event) error
+}
+
+type logEventDispatcher struct{}
+
+// NewLogEventDispatcher là EventDispatcher mặc định, chỉ ghi log event chứ không gửi đi đâu
+func NewLogEventDispatcher() EventDispatcher {
+	return logEventDispatcher{}
+}
+
+func (logEventDispatcher) Dispatch(ctx context.Context, userId int, event Event) error {
+	slog.InfoContext(ctx, "dispatch event", slog.Int("user_id", userId), slog.String("type", event.Type))
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PickFields encode data ra JSON rồi chỉ giữ lại các key trong fields, dùng cho sparse fieldset (?fields=)
+func PickFields(data interface{}, fields []string) (map[string]interface{}, error) {
+	b, err := json.Marshal(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&all); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(fields))
+
+	for _, name := range fields {
+		if v, ok := all[name]; ok {
+			result[name] = v
+		}
+	}
+
+	return result, nil
+}
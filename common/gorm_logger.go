@@ -0,0 +1,105 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// GormLogger đẩy log của GORM qua slog, query chạy lâu hơn SlowThreshold được log ở mức WARN
+type GormLogger struct {
+	logger        *slog.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+func NewGormLogger(logger *slog.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) *GormLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &GormLogger{logger: logger, level: level, slowThreshold: slowThreshold}
+}
+
+// ParseGormLogLevel nhận "silent", "error", "warn" hoặc "info", rỗng thì mặc định là warn
+func ParseGormLogLevel(s string) (gormlogger.LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "silent":
+		return gormlogger.Silent, nil
+	case "error":
+		return gormlogger.Error, nil
+	case "warn", "":
+		return gormlogger.Warn, nil
+	case "info":
+		return gormlogger.Info, nil
+	}
+
+	return 0, fmt.Errorf("invalid DB log level %q", s)
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	newLogger := *l
+	newLogger.level = level
+
+	return &newLogger
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.logger.InfoContext(ctx, fmt.Sprintf(msg, data...), l.requestAttr(ctx)...)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.logger.WarnContext(ctx, fmt.Sprintf(msg, data...), l.requestAttr(ctx)...)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.logger.ErrorContext(ctx, fmt.Sprintf(msg, data...), l.requestAttr(ctx)...)
+	}
+}
+
+// Trace được GORM gọi sau mỗi query
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	attrs := func() []any {
+		sql, rows := fc()
+
+		return append(l.requestAttr(ctx),
+			slog.String("sql", sql),
+			slog.Duration("duration", elapsed),
+			slog.Int64("rows", rows),
+		)
+	}
+
+	switch {
+	// Không tìm thấy record là chuyện bình thường, biz tự xử lý
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		l.logger.ErrorContext(ctx, "query error", append(attrs(), slog.String("error", err.Error()))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.logger.WarnContext(ctx, "slow query", append(attrs(), slog.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormlogger.Info:
+		l.logger.InfoContext(ctx, "query", attrs()...)
+	}
+}
+
+func (l *GormLogger) requestAttr(ctx context.Context) []any {
+	if requestId := RequestIDFromContext(ctx); requestId != "" {
+		return []any{slog.String("request_id", requestId)}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// captureHandler giữ lại các record slog để test kiểm tra
+type captureHandler struct {
+	records []slog.Record
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *captureHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func TestGormLoggerTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     gormlogger.LogLevel
+		elapsed   time.Duration
+		err       error
+		wantLevel slog.Level
+		wantMsg   string
+	}{
+		{"slow query flagged", gormlogger.Warn, time.Second, nil, slog.LevelWarn, "slow query"},
+		{"fast query not logged at warn", gormlogger.Warn, time.Millisecond, nil, 0, ""},
+		{"fast query logged at info", gormlogger.Info, time.Millisecond, nil, slog.LevelInfo, "query"},
+		{"query error", gormlogger.Warn, time.Millisecond, errors.New("syntax error"), slog.LevelError, "query error"},
+		{"record not found is not an error", gormlogger.Warn, time.Millisecond, gorm.ErrRecordNotFound, 0, ""},
+		{"silent", gormlogger.Silent, time.Second, errors.New("syntax error"), 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &captureHandler{}
+			logger := NewGormLogger(slog.New(handler), tt.level, 100*time.Millisecond)
+
+			logger.Trace(context.Background(), time.Now().Add(-tt.elapsed), func() (string, int64) {
+				return "SELECT count(*) FROM todo_items", 3
+			}, tt.err)
+
+			if tt.wantMsg == "" {
+				if len(handler.records) != 0 {
+					t.Fatalf("logged %d records, want none", len(handler.records))
+				}
+
+				return
+			}
+
+			if len(handler.records) != 1 {
+				t.Fatalf("logged %d records, want 1", len(handler.records))
+			}
+
+			record := handler.records[0]
+
+			if record.Level != tt.wantLevel || record.Message != tt.wantMsg {
+				t.Errorf("record = %s %q, want %s %q", record.Level, record.Message, tt.wantLevel, tt.wantMsg)
+			}
+
+			attrs := map[string]slog.Value{}
+			record.Attrs(func(a slog.Attr) bool {
+				attrs[a.Key] = a.Value
+				return true
+			})
+
+			if attrs["rows"].Int64() != 3 || attrs["duration"].Duration() < tt.elapsed {
+				t.Errorf("attrs = %v, want rows 3 and duration >= %v", attrs, tt.elapsed)
+			}
+		})
+	}
+}
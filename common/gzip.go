@@ -0,0 +1,185 @@
+package common
+
+import (
+	"compress/gzip"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// Các content type đã được nén sẵn, nén thêm chỉ tốn CPU
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+}
+
+// Gzip nén response bằng gzip khi client gửi Accept-Encoding: gzip.
+// Body nhỏ hơn minSize byte được trả nguyên (nén không lợi), content type đã nén sẵn (ảnh, zip...)
+// và request websocket được bỏ qua
+func Gzip(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		if !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &gzipWriter{ResponseWriter: original, minSize: minSize}
+		c.Writer = writer
+
+		defer func() {
+			c.Writer = original
+			writer.finish()
+		}()
+
+		c.Next()
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" || r.Header.Get("Range") != "" {
+		return false
+	}
+
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		if strings.TrimSpace(encoding) == "gzip" {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+
+	return false
+}
+
+// gzipWriter giữ body trong buffer cho tới khi đủ minSize mới quyết định có nén hay không
+type gzipWriter struct {
+	gin.ResponseWriter
+	minSize int
+	buf     []byte
+	gz      *gzip.Writer
+	plain   bool
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+
+	if w.plain {
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !w.compressible() {
+		w.plain = true
+
+		if err := w.flushBuffer(); err != nil {
+			return 0, err
+		}
+
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+
+	if len(w.buf) < w.minSize {
+		return len(data), nil
+	}
+
+	if err := w.startGzip(); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// startGzip set header rồi nén phần body đã buffer, phải gọi trước khi header được gửi đi
+func (w *gzipWriter) startGzip() error {
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	_, err := w.gz.Write(w.buf)
+	w.buf = nil
+
+	return err
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written tính cả phần body đang nằm trong buffer
+func (w *gzipWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush khi chưa quyết định được thì nén luôn (nếu content type cho phép) vì header sắp được gửi đi
+func (w *gzipWriter) Flush() {
+	switch {
+	case w.gz == nil && !w.plain && w.compressible():
+		_ = w.startGzip()
+	case w.gz == nil:
+		w.plain = true
+		_ = w.flushBuffer()
+	}
+
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) compressible() bool {
+	header := w.Header()
+
+	if header.Get("Content-Encoding") != "" || w.Status() == http.StatusPartialContent {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+
+	if strings.HasPrefix(contentType, "image/svg+xml") {
+		return true
+	}
+
+	for _, t := range incompressibleTypes {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w *gzipWriter) flushBuffer() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+
+	return err
+}
+
+// finish ghi nốt body còn trong buffer (nhỏ hơn minSize thì không nén) và đóng gzip writer
+func (w *gzipWriter) finish() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+		return
+	}
+
+	_ = w.flushBuffer()
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"compress/gzip"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	large := strings.Repeat(`{"title":"buy milk"},`, 100)
+
+	r := gin.New()
+	r.Use(Gzip(1024))
+	r.GET("/large", func(c *gin.Context) { c.Data(http.StatusOK, MIMEJSON, []byte(large)) })
+	r.GET("/small", func(c *gin.Context) { c.Data(http.StatusOK, MIMEJSON, []byte(`{"data":true}`)) })
+	r.GET("/image", func(c *gin.Context) { c.Data(http.StatusOK, "image/png", []byte(large)) })
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{"large json", "/large", "gzip, deflate, br", true},
+		{"client without gzip", "/large", "", false},
+		{"gzip refused with q=0", "/large", "gzip;q=0", false},
+		{"under the threshold", "/small", "gzip", false},
+		{"already compressed type", "/image", "gzip", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+
+			if gzipped := w.Header().Get("Content-Encoding") == "gzip"; gzipped != tt.wantGzip {
+				t.Fatalf("Content-Encoding = %q, want gzip %v", w.Header().Get("Content-Encoding"), tt.wantGzip)
+			}
+
+			body := w.Body.String()
+
+			if tt.wantGzip {
+				gz, err := gzip.NewReader(w.Body)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				b, err := io.ReadAll(gz)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				body = string(b)
+			}
+
+			if tt.path == "/large" && body != large {
+				t.Errorf("body has %d bytes, want the original %d bytes", len(body), len(large))
+			}
+		})
+	}
+}
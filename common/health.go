@@ -0,0 +1,37 @@
+package common
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+// HealthCheck ping DB trong khoảng timeout, dùng cho load balancer / k8s probe
+func HealthCheck(db *gorm.DB, timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := pingDB(c.Request.Context(), db, timeout); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status": "unavailable",
+				"error":  err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+func pingDB(ctx context.Context, db *gorm.DB, timeout time.Duration) error {
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		closeDB    bool
+		wantStatus int
+		wantBody   string
+	}{
+		{"db reachable", false, http.StatusOK, `"status":"ok"`},
+		{"db closed", true, http.StatusServiceUnavailable, `"status":"unavailable"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := NewDatabase(Config{DBDriver: DBDriverSQLite, DBDsn: "file::memory:"})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			closeTestDB(t, db, tt.closeDB)
+
+			r := gin.New()
+			r.GET("/healthz", HealthCheck(db, time.Second))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want %s", w.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+// closeTestDB đóng DB ngay nếu now, còn không thì đóng khi test xong
+func closeTestDB(t *testing.T, db *gorm.DB, now bool) {
+	t.Helper()
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if now {
+		_ = sqlDB.Close()
+		return
+	}
+
+	t.Cleanup(func() { _ = sqlDB.Close() })
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+const (
+	HTMLSanitizeStrip  = "strip"
+	HTMLSanitizeEscape = "escape"
+)
+
+// Strip + unescape có thể lộ ra tag mới (ví dụ "&lt;script&gt;"), lặp lại tối đa từng này lần cho tới khi ổn định
+const maxStripPasses = 5
+
+// HTMLSanitizer làm sạch text người dùng nhập để không lưu HTML/script vào DB.
+// Mode "strip" bỏ hết tag (giữ lại phần chữ), "escape" giữ nguyên nội dung nhưng escape các ký tự HTML.
+// HTMLSanitizer nil thì trả text nguyên vẹn
+type HTMLSanitizer struct {
+	mode   string
+	policy *bluemonday.Policy
+}
+
+// NewHTMLSanitizer nhận mode "strip" (mặc định) hoặc "escape"
+func NewHTMLSanitizer(mode string) (*HTMLSanitizer, error) {
+	switch mode {
+	case HTMLSanitizeStrip, "":
+		return &HTMLSanitizer{mode: HTMLSanitizeStrip, policy: bluemonday.StrictPolicy()}, nil
+	case HTMLSanitizeEscape:
+		return &HTMLSanitizer{mode: HTMLSanitizeEscape}, nil
+	default:
+		return nil, fmt.Errorf("unsupported HTML_SANITIZE_MODE %q", mode)
+	}
+}
+
+func (s *HTMLSanitizer) Sanitize(text string) string {
+	if s == nil {
+		return text
+	}
+
+	// Unescape trước để text đã escape (client gửi lại title/description vừa nhận) không bị escape lần nữa thành &amp;amp;
+	if s.mode == HTMLSanitizeEscape {
+		return html.EscapeString(html.UnescapeString(text))
+	}
+
+	// StrictPolicy escape cả ký tự thường như & và ', unescape lại để text thuần (ví dụ "Tom & Jerry") giữ nguyên
+	for i := 0; i < maxStripPasses; i++ {
+		stripped := html.UnescapeString(s.policy.Sanitize(text))
+
+		if stripped == text {
+			return text
+		}
+
+		text = stripped
+	}
+
+	// Chưa ổn định thì trả bản đã escape, không còn tag nào
+	return s.policy.Sanitize(text)
+}
@@ -0,0 +1,43 @@
+package common
+
+import "testing"
+
+func TestHTMLSanitizerRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  string
+		input string
+		want  string
+	}{
+		{"escape tag", HTMLSanitizeEscape, "<b>hi</b>", "&lt;b&gt;hi&lt;/b&gt;"},
+		{"escape ampersand", HTMLSanitizeEscape, "Tom & Jerry", "Tom &amp; Jerry"},
+		{"escape already escaped", HTMLSanitizeEscape, "Tom &amp; Jerry", "Tom &amp; Jerry"},
+		{"escape quotes", HTMLSanitizeEscape, `say "hi" it's`, "say &#34;hi&#34; it&#39;s"},
+		{"strip tag", HTMLSanitizeStrip, "<script>x</script>hi", "hi"},
+		{"strip keeps ampersand", HTMLSanitizeStrip, "Tom & Jerry", "Tom & Jerry"},
+		{"strip escaped tag", HTMLSanitizeStrip, "&lt;b&gt;hi&lt;/b&gt;", "hi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sanitizer, err := NewHTMLSanitizer(tt.mode)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := sanitizer.Sanitize(tt.input)
+
+			if got != tt.want {
+				t.Fatalf("Sanitize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+
+			// Client gửi lại giá trị vừa nhận (PATCH/PUT) thì không được đổi nữa
+			for i := 0; i < 3; i++ {
+				if again := sanitizer.Sanitize(got); again != got {
+					t.Fatalf("pass %d: Sanitize(%q) = %q, want unchanged", i+2, got, again)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Image được lưu vào cột của entity dạng JSON
+type Image struct {
+	Url       string `json:"url" xml:"url"`
+	Width     int    `json:"width" xml:"width"`
+	Height    int    `json:"height" xml:"height"`
+	Extension string `json:"extension,omitempty" xml:"extension,omitempty"`
+}
+
+func (Image) GormDataType() string {
+	return "text"
+}
+
+func (j *Image) Scan(value interface{}) error {
+	var bytes []byte
+
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	}
+
+	var img Image
+
+	if err := json.Unmarshal(bytes, &img); err != nil {
+		return err
+	}
+
+	*j = img
+
+	return nil
+}
+
+func (j *Image) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(j)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+type jwtTokenizer struct {
+	secret string
+}
+
+func NewJWTTokenizer(secret string) *jwtTokenizer {
+	return &jwtTokenizer{secret: secret}
+}
+
+type jwtClaims struct {
+	Payload TokenPayload `json:"payload"`
+	jwt.RegisteredClaims
+}
+
+func (j *jwtTokenizer) Generate(data TokenPayload, expiry int) (*Token, error) {
+	now := time.Now()
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+		Payload: data,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Second * time.Duration(expiry))),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+
+	token, err := t.SignedString([]byte(j.secret))
+
+	if err != nil {
+		return nil, errors.Join(ErrEncodingToken, err)
+	}
+
+	return &Token{
+		Token:   token,
+		Created: now,
+		Expiry:  expiry,
+	}, nil
+}
+
+func (j *jwtTokenizer) Validate(token string) (*TokenPayload, error) {
+	var claims jwtClaims
+
+	t, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(j.secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+
+		return nil, ErrInvalidToken
+	}
+
+	if !t.Valid || claims.Payload.UserId <= 0 {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims.Payload, nil
+}
@@ -0,0 +1,18 @@
+package common
+
+// ListResult là kết quả của một use case list: các phần tử của trang hiện tại,
+// paging (đã có Total) và filter đã được áp dụng để handler trả lại cho client
+type ListResult[T any] struct {
+	Items  []T
+	Paging *Paging
+	Filter interface{}
+}
+
+// NewListResult không bao giờ trả về Items nil, không có phần tử nào thì là slice rỗng
+func NewListResult[T any](items []T, paging *Paging, filter interface{}) *ListResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return &ListResult[T]{Items: items, Paging: paging, Filter: filter}
+}
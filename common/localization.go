@@ -0,0 +1,144 @@
+package common
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLanguage là ngôn ngữ của Message có sẵn trong AppError, không cần file catalog
+	DefaultLanguage = "en"
+
+	// Language là key trong gin context lưu ngôn ngữ đã chọn theo header Accept-Language
+	Language = "language"
+
+	messageCatalogKey = "message_catalog"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// MessageCatalog là message của lỗi theo từng ngôn ngữ (ngôn ngữ -> error key -> message)
+type MessageCatalog struct {
+	messages map[string]map[string]string
+}
+
+// LoadMessageCatalog đọc các file locales/<ngôn ngữ>.json, gọi một lần lúc khởi động
+func LoadMessageCatalog() (*MessageCatalog, error) {
+	files, err := localeFiles.ReadDir("locales")
+
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &MessageCatalog{messages: map[string]map[string]string{}}
+
+	for _, f := range files {
+		data, err := localeFiles.ReadFile(path.Join("locales", f.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		var messages map[string]string
+
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("locale %s: %w", f.Name(), err)
+		}
+
+		catalog.messages[strings.TrimSuffix(f.Name(), path.Ext(f.Name()))] = messages
+	}
+
+	return catalog, nil
+}
+
+// Supports cho biết có message cho ngôn ngữ lang không, tiếng Anh luôn có
+func (c *MessageCatalog) Supports(lang string) bool {
+	_, ok := c.messages[lang]
+
+	return ok || lang == DefaultLanguage
+}
+
+// Message trả về message của key theo lang, ngôn ngữ hoặc key không có trong catalog thì trả về fallback (tiếng Anh).
+// Placeholder {tên} trong message được thay bằng params[tên]
+func (c *MessageCatalog) Message(lang, key, fallback string, params map[string]string) string {
+	msg, ok := c.messages[lang][key]
+
+	if !ok {
+		return fallback
+	}
+
+	for name, value := range params {
+		msg = strings.ReplaceAll(msg, "{"+name+"}", value)
+	}
+
+	return msg
+}
+
+// Localization chọn ngôn ngữ cho message lỗi theo header Accept-Language, không hỗ trợ thì dùng tiếng Anh.
+// Phải đứng trước các middleware và handler trả lỗi để Localize đọc được ngôn ngữ
+func Localization(catalog *MessageCatalog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Set(Language, negotiateLanguage(c.GetHeader("Accept-Language"), catalog))
+		c.Set(messageCatalogKey, catalog)
+		c.Next()
+	}
+}
+
+// Localize trả về bản sao của appErr với Message theo ngôn ngữ của request, Key giữ nguyên để client so sánh.
+// Chỉ Message được dịch, Details của lỗi validation giữ tiếng Anh. Lỗi gốc được gắn vào gin context để RequestLogger log phía server
+func Localize(c *gin.Context, appErr *AppError) *AppError {
+	_ = c.Error(appErr)
+
+	catalog, ok := c.Value(messageCatalogKey).(*MessageCatalog)
+
+	if !ok {
+		return appErr
+	}
+
+	localized := *appErr
+	localized.Message = catalog.Message(c.GetString(Language), appErr.Key, appErr.Message, appErr.Params)
+
+	return &localized
+}
+
+// negotiateLanguage chọn ngôn ngữ có q cao nhất mà catalog hỗ trợ, vi-VN được coi là vi
+func negotiateLanguage(acceptLanguage string, catalog *MessageCatalog) string {
+	lang, quality := DefaultLanguage, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(params[0]))
+		base, _, _ := strings.Cut(tag, "-")
+
+		if !catalog.Supports(base) {
+			continue
+		}
+
+		q := 1.0
+
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+
+			if strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = v
+			}
+		}
+
+		if q > quality {
+			lang, quality = base, q
+		}
+	}
+
+	return lang
+}
@@ -0,0 +1,158 @@
+package common
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func newTestCatalog(t *testing.T) *MessageCatalog {
+	t.Helper()
+
+	catalog, err := LoadMessageCatalog()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return catalog
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{"empty", "", DefaultLanguage},
+		{"vi", "vi", "vi"},
+		{"region", "vi-VN", "vi"},
+		{"upper case", "VI-vn", "vi"},
+		{"unsupported falls back to en", "fr-FR, de;q=0.8", DefaultLanguage},
+		{"higher q wins", "en;q=0.5, vi;q=0.9", "vi"},
+		{"en preferred over vi", "vi;q=0.3, en", DefaultLanguage},
+		{"q=0 is not acceptable", "vi;q=0", DefaultLanguage},
+		{"unsupported first", "fr, vi;q=0.7", "vi"},
+		{"invalid q counts as 1", "en;q=0.5, vi;q=abc", "vi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := negotiateLanguage(tt.acceptLanguage, catalog); got != tt.want {
+				t.Errorf("negotiateLanguage(%q) = %q, want %q", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantMessage    string
+	}{
+		{"default", "", "cannot change item status from Done to Doing"},
+		{"vi", "vi-VN,vi;q=0.9,en;q=0.8", "không thể đổi trạng thái công việc từ Done sang Doing"},
+		{"unsupported", "ja", "cannot change item status from Done to Doing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Localization(catalog))
+			r.GET("/", func(c *gin.Context) {
+				appErr := ErrInvalidStateTransition("Item", "Done", "Doing", errors.New("invalid transition"))
+				c.JSON(appErr.StatusCode, Localize(c, appErr))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Vary"); got != "Accept-Language" {
+				t.Errorf("Vary = %q, want Accept-Language", got)
+			}
+
+			if !strings.Contains(w.Body.String(), `"message":"`+tt.wantMessage+`"`) {
+				t.Errorf("body = %s, want message %q", w.Body.String(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestMessageCatalogKeys(t *testing.T) {
+	catalog := newTestCatalog(t)
+	placeholder := regexp.MustCompile(`\{(\w+)\}`)
+
+	// params là các placeholder mà AppError có key tương ứng điền vào
+	params := map[string][]string{
+		"ErrInvalidItemStatusTransition": {"from", "to"},
+	}
+
+	for lang, messages := range catalog.messages {
+		for key, msg := range messages {
+			t.Run(lang+"/"+key, func(t *testing.T) {
+				if msg == "" {
+					t.Fatal("empty message")
+				}
+
+				values := map[string]string{}
+
+				for _, name := range params[key] {
+					values[name] = "<" + name + ">"
+				}
+
+				got := catalog.Message(lang, key, "fallback", values)
+
+				if left := placeholder.FindAllString(got, -1); len(left) > 0 {
+					t.Errorf("message %q has unfilled placeholders %v", got, left)
+				}
+
+				for name, value := range values {
+					if !strings.Contains(got, value) {
+						t.Errorf("message %q does not contain {%s}", got, name)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestMessageCatalogFallback(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		name string
+		lang string
+		key  string
+		want string
+	}{
+		{"default language", DefaultLanguage, "ErrItemNotFound", "fallback"},
+		{"unknown language", "fr", "ErrItemNotFound", "fallback"},
+		{"unknown key", "vi", "ErrUnknown", "fallback"},
+		{"translated", "vi", "ErrItemNotFound", "không tìm thấy công việc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Message(tt.lang, tt.key, "fallback", nil); got != tt.want {
+				t.Errorf("Message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		keys     []int
+		touch    int
+		wantKeys []int
+		wantGone []int
+	}{
+		{"evicts least recently used", time.Minute, []int{1, 2, 3, 4}, 1, []int{1, 3, 4}, []int{2}},
+		{"within size", time.Minute, []int{1, 2}, 0, []int{1, 2}, nil},
+		{"expired entries", time.Nanosecond, []int{1, 2}, 0, nil, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewLRUCache[int, string](3, tt.ttl)
+
+			for i, key := range tt.keys {
+				cache.Set(key, "value")
+
+				// Đọc lại touch sau khi set phần tử thứ 3 để nó thành phần tử mới dùng gần nhất
+				if i == 2 && tt.touch != 0 {
+					cache.Get(tt.touch)
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			for _, key := range tt.wantKeys {
+				if _, ok := cache.Get(key); !ok {
+					t.Errorf("key %d missing", key)
+				}
+			}
+
+			for _, key := range tt.wantGone {
+				if _, ok := cache.Get(key); ok {
+					t.Errorf("key %d still cached", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUCacheDelete(t *testing.T) {
+	cache := NewLRUCache[int, int](10, time.Minute)
+
+	for i := 1; i <= 4; i++ {
+		cache.Set(i, i%2)
+	}
+
+	cache.Delete(1)
+	cache.DeleteFunc(func(_ int, value int) bool { return value == 0 })
+
+	if _, ok := cache.Get(3); !ok || cache.Len() != 1 {
+		t.Errorf("len = %d, want only key 3 left", cache.Len())
+	}
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	cache := NewLRUCache[int, int](16, time.Minute)
+
+	var wg sync.WaitGroup
+
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+				cache.Set((g*i)%32, i)
+				cache.Get(i % 32)
+
+				if i%100 == 0 {
+					cache.Delete(i % 32)
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if cache.Len() > 16 {
+		t.Errorf("len = %d, want at most 16", cache.Len())
+	}
+}
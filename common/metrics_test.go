@@ -0,0 +1,51 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/metrics", MetricsHandler())
+	r.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/items/1", "/items/2", "/nope"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	body := w.Body.String()
+
+	tests := []struct {
+		name    string
+		series  string
+		present bool
+	}{
+		{"requests by route template", `http_requests_total{method="GET",route="/items/:id",status="200"}`, true},
+		{"unmatched route", `http_requests_total{method="GET",route="unmatched",status="404"}`, true},
+		{"latency histogram", `http_request_duration_seconds_count{method="GET",route="/items/:id"}`, true},
+		{"raw path", `route="/items/1"`, false},
+		{"raw unmatched path", `route="/nope"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.Contains(body, tt.series) != tt.present {
+				t.Errorf("series %s present = %v, want %v", tt.series, !tt.present, tt.present)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Notifier gửi thông báo cho user (email, push...), lỗi trả về để job thử lại ở lần chạy sau
+type Notifier interface {
+	Notify(ctx context.Context, userId int, msg string) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier là Notifier mặc định, chỉ ghi log thông báo chứ không gửi đi đâu
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) Notify(ctx context.Context, userId int, msg string) error {
+	slog.InfoContext(ctx, "notify", slog.Int("user_id", userId), slog.String("message", msg))
+
+	return nil
+}
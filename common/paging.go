@@ -1,17 +1,56 @@
 package common
 
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	defaultPagingLimit = 10
+	maxPagingLimit     = 100
+)
+
+var ErrInvalidPaging = errors.New("invalid paging")
+
 type Paging struct {
-	Page  int   `json:"page" form:"page"`
-	Limit int   `json:"limit" form:"limit"`
-	Total int64 `json:"total" form:"-"`
+	Page  int   `json:"page" xml:"page" form:"page"`
+	Limit int   `json:"limit" xml:"limit" form:"limit"`
+	Total int64 `json:"total" xml:"total" form:"-"`
+	// Cursor là fake id (base58) của item cuối cùng client đã thấy.
+	// Khi có cursor thì storage phân trang theo id thay vì OFFSET.
+	FakeCursor string `json:"cursor,omitempty" xml:"cursor,omitempty" form:"cursor"`
+	// NextCursor chỉ có ở chế độ cursor và khi còn trang sau
+	NextCursor string `json:"next_cursor,omitempty" xml:"next_cursor,omitempty" form:"-"`
+	// CursorMode là phân trang theo cursor, bật khi request có query cursor (?cursor= rỗng là trang đầu tiên)
+	CursorMode bool `json:"-" xml:"-" form:"-"`
 }
 
-func (p *Paging) Process() {
-	if p.Page <= 0 {
+// Process trả lỗi 400 nếu page hoặc limit âm. Không gửi (bằng 0) thì lấy mặc định,
+// limit lớn hơn maxPagingLimit thì bị giảm về maxPagingLimit
+func (p *Paging) Process() error {
+	if p.Page < 0 {
+		return ErrInvalidRequest(fmt.Errorf("%w: page must not be negative, got %d", ErrInvalidPaging, p.Page))
+	}
+
+	if p.Limit < 0 {
+		return ErrInvalidRequest(fmt.Errorf("%w: limit must not be negative, got %d", ErrInvalidPaging, p.Limit))
+	}
+
+	if p.Page == 0 {
 		p.Page = 1
 	}
 
-	if p.Limit <= 0 || p.Limit >= 100 {
-		p.Limit = 10
+	if p.Limit == 0 {
+		p.Limit = defaultPagingLimit
+	}
+
+	if p.Limit > maxPagingLimit {
+		p.Limit = maxPagingLimit
 	}
+
+	if p.FakeCursor != "" {
+		p.CursorMode = true
+	}
+
+	return nil
 }
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PagingLinks là URL tuyệt đối của trang hiện tại, trang sau và trang trước
+type PagingLinks struct {
+	Self string `json:"self" xml:"self"`
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
+	Prev string `json:"prev,omitempty" xml:"prev,omitempty"`
+}
+
+// NewPagingLinks dựng link từ request hiện tại (giữ nguyên các query khác) và paging sau khi đã query.
+// Phân trang theo cursor thì next dùng NextCursor (không còn trang sau thì không có next) và không có prev
+func NewPagingLinks(r *http.Request, paging *Paging) *PagingLinks {
+	base := requestBaseURL(r) + r.URL.Path
+
+	links := PagingLinks{Self: base + queryString(r.URL.Query())}
+
+	if paging.CursorMode {
+		if paging.NextCursor != "" {
+			query := r.URL.Query()
+			query.Del("page")
+			query.Set("cursor", paging.NextCursor)
+			links.Next = base + queryString(query)
+		}
+
+		return &links
+	}
+
+	if int64(paging.Page*paging.Limit) < paging.Total {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(paging.Page+1))
+		links.Next = base + queryString(query)
+	}
+
+	if paging.Page > 1 {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(paging.Page-1))
+		links.Prev = base + queryString(query)
+	}
+
+	return &links
+}
+
+// requestBaseURL trả về scheme://host, ưu tiên X-Forwarded-Proto/X-Forwarded-Host khi chạy sau proxy
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if v := firstHeaderValue(r, "X-Forwarded-Proto"); v != "" {
+		scheme = v
+	}
+
+	host := r.Host
+
+	if v := firstHeaderValue(r, "X-Forwarded-Host"); v != "" {
+		host = v
+	}
+
+	return scheme + "://" + host
+}
+
+// firstHeaderValue lấy giá trị đầu tiên khi header có nhiều giá trị phân cách bởi dấu phẩy (qua nhiều proxy)
+func firstHeaderValue(r *http.Request, key string) string {
+	v, _, _ := strings.Cut(r.Header.Get(key), ",")
+
+	return strings.TrimSpace(v)
+}
+
+func queryString(query url.Values) string {
+	encoded := query.Encode()
+
+	if encoded == "" {
+		return ""
+	}
+
+	return "?" + encoded
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewPagingLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		headers  map[string]string
+		paging   Paging
+		wantSelf string
+		wantNext string
+		wantPrev string
+	}{
+		{
+			name:     "first page",
+			url:      "/v1/items?page=1&limit=2&status=Doing",
+			paging:   Paging{Page: 1, Limit: 2, Total: 5},
+			wantSelf: "http://example.com/v1/items?limit=2&page=1&status=Doing",
+			wantNext: "http://example.com/v1/items?limit=2&page=2&status=Doing",
+		},
+		{
+			name:     "middle page",
+			url:      "/v1/items?page=2&limit=2",
+			paging:   Paging{Page: 2, Limit: 2, Total: 5},
+			wantSelf: "http://example.com/v1/items?limit=2&page=2",
+			wantNext: "http://example.com/v1/items?limit=2&page=3",
+			wantPrev: "http://example.com/v1/items?limit=2&page=1",
+		},
+		{
+			name:     "last page",
+			url:      "/v1/items?page=3&limit=2",
+			paging:   Paging{Page: 3, Limit: 2, Total: 5},
+			wantSelf: "http://example.com/v1/items?limit=2&page=3",
+			wantPrev: "http://example.com/v1/items?limit=2&page=2",
+		},
+		{
+			name:     "behind proxy",
+			url:      "/v1/items",
+			headers:  map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.example.org, internal:8080"},
+			paging:   Paging{Page: 1, Limit: 10, Total: 3},
+			wantSelf: "https://api.example.org/v1/items",
+		},
+		{
+			name:     "cursor with next",
+			url:      "/v1/items?cursor=&page=4",
+			paging:   Paging{Page: 1, Limit: 2, CursorMode: true, NextCursor: "abc"},
+			wantSelf: "http://example.com/v1/items?cursor=&page=4",
+			wantNext: "http://example.com/v1/items?cursor=abc",
+		},
+		{
+			name:     "cursor on last page",
+			url:      "/v1/items?cursor=abc",
+			paging:   Paging{Page: 1, Limit: 2, CursorMode: true},
+			wantSelf: "http://example.com/v1/items?cursor=abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			links := NewPagingLinks(req, &tt.paging)
+
+			if links.Self != tt.wantSelf || links.Next != tt.wantNext || links.Prev != tt.wantPrev {
+				t.Errorf("links = %+v, want self %q next %q prev %q", links, tt.wantSelf, tt.wantNext, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPagingProcess(t *testing.T) {
+	tests := []struct {
+		name      string
+		paging    Paging
+		wantPage  int
+		wantLimit int
+	}{
+		{"defaults", Paging{}, 1, defaultPagingLimit},
+		{"limit 0 falls back to default", Paging{Page: 2, Limit: 0}, 2, defaultPagingLimit},
+		{"kept", Paging{Page: 3, Limit: 25}, 3, 25},
+		{"max limit", Paging{Page: 1, Limit: maxPagingLimit}, 1, maxPagingLimit},
+		{"limit capped", Paging{Page: 1, Limit: 1000}, 1, maxPagingLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := tt.paging
+
+			if err := paging.Process(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if paging.Page != tt.wantPage || paging.Limit != tt.wantLimit {
+				t.Errorf("page, limit = %d, %d, want %d, %d", paging.Page, paging.Limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestPagingProcessNegative(t *testing.T) {
+	tests := []struct {
+		name   string
+		paging Paging
+	}{
+		{"negative page", Paging{Page: -1, Limit: 10}},
+		{"negative limit", Paging{Page: 1, Limit: -5}},
+		{"negative limit with defaults", Paging{Limit: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := tt.paging
+			err := paging.Process()
+
+			if !errors.Is(err, ErrInvalidPaging) {
+				t.Fatalf("err = %v, want %v", err, ErrInvalidPaging)
+			}
+
+			if status := ToAppError(err).StatusCode; status != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", status)
+			}
+
+			if paging != tt.paging {
+				t.Errorf("paging = %+v, want it left as %+v", paging, tt.paging)
+			}
+		})
+	}
+}
+
+func TestPagingCursorMode(t *testing.T) {
+	tests := []struct {
+		name   string
+		paging Paging
+		want   bool
+	}{
+		{"offset", Paging{Page: 2}, false},
+		{"cursor", Paging{FakeCursor: "abc"}, true},
+		{"empty cursor param", Paging{CursorMode: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := tt.paging
+
+			if err := paging.Process(); err != nil {
+				t.Fatal(err)
+			}
+
+			if paging.CursorMode != tt.want {
+				t.Errorf("cursor mode = %v, want %v", paging.CursorMode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limiter của key không còn request nào trong khoảng này sẽ bị xoá để map không phình mãi
+const rateLimiterIdleTTL = 10 * time.Minute
+
+var ErrTooManyRequests = errors.New("too many requests")
+
+type visitor struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type rateLimiterStore struct {
+	mu        sync.Mutex
+	visitors  map[string]*visitor
+	rps       rate.Limit
+	burst     int
+	ttl       time.Duration
+	lastSweep time.Time
+}
+
+func (s *rateLimiterStore) get(key string, now time.Time) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > s.ttl {
+		for k, v := range s.visitors {
+			if now.Sub(v.lastSeen) > s.ttl {
+				delete(s.visitors, k)
+			}
+		}
+
+		s.lastSweep = now
+	}
+
+	v, ok := s.visitors[key]
+
+	if !ok {
+		v = &visitor{limiter: rate.NewLimiter(s.rps, s.burst)}
+		s.visitors[key] = v
+	}
+
+	v.lastSeen = now
+
+	return v.limiter
+}
+
+// RateLimiter giới hạn theo token bucket cho mỗi requester (nếu đã xác thực) hoặc mỗi IP.
+// rps <= 0 thì không giới hạn.
+func RateLimiter(rps int, burst int) gin.HandlerFunc {
+	if rps <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	if burst <= 0 {
+		burst = rps
+	}
+
+	store := &rateLimiterStore{
+		visitors:  make(map[string]*visitor),
+		rps:       rate.Limit(rps),
+		burst:     burst,
+		ttl:       rateLimiterIdleTTL,
+		lastSweep: time.Now(),
+	}
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		reservation := store.get(rateLimitKey(c), now).ReserveN(now, 1)
+
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+
+			appErr := NewFullErrorResponse(http.StatusTooManyRequests, ErrTooManyRequests, "too many requests, please retry later", "ErrTooManyRequests")
+			c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitKey(c *gin.Context) string {
+	if v, ok := c.Get(CurrentUser); ok {
+		if requester, ok := v.(Requester); ok {
+			return fmt.Sprintf("user:%d", requester.GetUserId())
+		}
+	}
+
+	return "ip:" + c.ClientIP()
+}
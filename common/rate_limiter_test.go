@@ -0,0 +1,111 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		rps       int
+		burst     int
+		requests  int
+		wantLimit bool
+	}{
+		{"burst exceeded", 1, 3, 4, true},
+		{"within burst", 1, 3, 3, false},
+		{"disabled", 0, 0, 20, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/items", RateLimiter(tt.rps, tt.burst), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			var last *httptest.ResponseRecorder
+
+			for i := 0; i < tt.requests; i++ {
+				last = httptest.NewRecorder()
+				r.ServeHTTP(last, httptest.NewRequest(http.MethodPost, "/items", nil))
+
+				if i < tt.requests-1 && last.Code != http.StatusOK {
+					t.Fatalf("request %d: status = %d, want 200", i, last.Code)
+				}
+			}
+
+			if !tt.wantLimit {
+				if last.Code != http.StatusOK {
+					t.Errorf("last status = %d, want 200", last.Code)
+				}
+
+				return
+			}
+
+			if last.Code != http.StatusTooManyRequests {
+				t.Fatalf("last status = %d, want 429", last.Code)
+			}
+
+			if last.Header().Get("Retry-After") == "" {
+				t.Error("429 without Retry-After")
+			}
+		})
+	}
+}
+
+func TestRateLimiterKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user == "2" {
+			c.Set(CurrentUser, NewRequester(2))
+		}
+	})
+	r.POST("/items", RateLimiter(1, 1), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Mỗi key có bucket riêng: IP đã hết lượt không làm user 2 (cùng IP) bị chặn
+	steps := []struct {
+		name       string
+		user       string
+		wantStatus int
+	}{
+		{"ip first", "", http.StatusOK},
+		{"ip second", "", http.StatusTooManyRequests},
+		{"user first", "2", http.StatusOK},
+		{"user second", "2", http.StatusTooManyRequests},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, "/items", nil)
+		req.Header.Set("X-Test-User", step.user)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d", step.name, w.Code, step.wantStatus)
+		}
+	}
+}
+
+func TestRateLimiterStoreEvictsIdleKeys(t *testing.T) {
+	now := time.Now()
+	store := &rateLimiterStore{visitors: map[string]*visitor{}, rps: 1, burst: 1, ttl: time.Minute, lastSweep: now}
+
+	store.get("ip:1", now)
+	store.get("ip:2", now.Add(90*time.Second))
+
+	if _, ok := store.visitors["ip:1"]; ok {
+		t.Error("idle key ip:1 was not evicted")
+	}
+
+	if _, ok := store.visitors["ip:2"]; !ok {
+		t.Error("active key ip:2 was evicted")
+	}
+}
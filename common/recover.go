@@ -0,0 +1,35 @@
+package common
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"runtime/debug"
+)
+
+// Recover bắt panic trong handler và trả về AppError dạng JSON thay vì trang 500 mặc định của Gin
+func Recover() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("panic recovered: %v\n%s", r, debug.Stack())
+
+				if appErr, ok := r.(*AppError); ok {
+					c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+					return
+				}
+
+				err, ok := r.(error)
+
+				if !ok {
+					err = fmt.Errorf("%v", r)
+				}
+
+				appErr := ErrInternal(err)
+				c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+			}
+		}()
+
+		c.Next()
+	}
+}
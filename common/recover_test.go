@@ -0,0 +1,64 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Recover())
+	r.GET("/app-error", func(c *gin.Context) {
+		panic(ErrEntityNotFound("Item", RecordNotFound))
+	})
+	r.GET("/error", func(c *gin.Context) {
+		panic(errors.New("nil map write in handler"))
+	})
+	r.GET("/value", func(c *gin.Context) {
+		panic("something odd")
+	})
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantKey    string
+		// hidden là nội dung của panic, không được lộ ra response
+		hidden string
+	}{
+		{"/app-error", http.StatusNotFound, "ErrItemNotFound", "record not found"},
+		{"/error", http.StatusInternalServerError, "ErrInternal", "nil map"},
+		{"/value", http.StatusInternalServerError, "ErrInternal", "something odd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			var body AppError
+
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not AppError JSON: %s", w.Body)
+			}
+
+			if body.Key != tt.wantKey || body.StatusCode != tt.wantStatus {
+				t.Errorf("body = %s, want key %s", w.Body, tt.wantKey)
+			}
+
+			if strings.Contains(w.Body.String(), tt.hidden) {
+				t.Errorf("body leaks the panic value: %s", w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+const HeaderRequestId = "X-Request-Id"
+
+type requestIdKey struct{}
+
+// RequestLogger gắn request id cho mỗi request (lấy lại từ header nếu client đã gửi),
+// trả id đó qua header X-Request-Id và log kết quả sau khi handler chạy xong
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestId := c.GetHeader(HeaderRequestId)
+
+		if requestId == "" {
+			requestId = uuid.NewString()
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), requestIdKey{}, requestId))
+		c.Header(HeaderRequestId, requestId)
+
+		c.Next()
+
+		attrs := []any{
+			slog.String("request_id", requestId),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+		}
+
+		// Lỗi gốc không còn nằm trong response nên chỉ xem được ở đây
+		if err := c.Errors.Last(); err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+
+			var appErr *AppError
+
+			if errors.As(err.Err, &appErr) {
+				attrs = append(attrs, slog.String("error_key", appErr.Key))
+			}
+		}
+
+		slog.InfoContext(c.Request.Context(), "request", attrs...)
+	}
+}
+
+// RequestIDFromContext lấy request id do RequestLogger gắn vào, rỗng nếu không có
+func RequestIDFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(requestIdKey{}).(string); ok {
+		return v
+	}
+
+	return ""
+}
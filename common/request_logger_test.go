@@ -0,0 +1,56 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		requestId string
+	}{
+		{"generated id", ""},
+		{"id from client", "client-request-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+
+			r := gin.New()
+			r.Use(RequestLogger())
+			r.GET("/", func(c *gin.Context) {
+				fromContext = RequestIDFromContext(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if tt.requestId != "" {
+				req.Header.Set(HeaderRequestId, tt.requestId)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(HeaderRequestId)
+
+			if got == "" {
+				t.Fatal("X-Request-Id header is empty")
+			}
+
+			if tt.requestId != "" && got != tt.requestId {
+				t.Errorf("X-Request-Id = %q, want %q", got, tt.requestId)
+			}
+
+			if fromContext != got {
+				t.Errorf("RequestIDFromContext = %q, want %q", fromContext, got)
+			}
+		})
+	}
+}
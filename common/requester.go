@@ -0,0 +1,20 @@
+package common
+
+// CurrentUser là key lưu Requester trong gin.Context
+const CurrentUser = "current_user"
+
+type Requester interface {
+	GetUserId() int
+}
+
+type simpleRequester struct {
+	userId int
+}
+
+func NewRequester(userId int) Requester {
+	return &simpleRequester{userId: userId}
+}
+
+func (r *simpleRequester) GetUserId() int {
+	return r.userId
+}
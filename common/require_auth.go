@@ -0,0 +1,84 @@
+package common
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+const (
+	// WebsocketTokenQuery là query chứa token khi mở websocket: /ws/items?access_token=<token>
+	WebsocketTokenQuery = "access_token"
+	// WebsocketTokenProtocol là subprotocol đi trước token: new WebSocket(url, ["bearer", token]),
+	// server chọn lại subprotocol này khi upgrade
+	WebsocketTokenProtocol = "bearer"
+)
+
+// RequireAuth xác thực header "Authorization: Bearer <token>" và gắn Requester vào context
+func RequireAuth(tokenizer Tokenizer) gin.HandlerFunc {
+	return requireAuth(tokenizer, func(c *gin.Context) (string, error) {
+		return extractBearerToken(c.GetHeader("Authorization"))
+	})
+}
+
+// RequireWebsocketAuth giống RequireAuth nhưng trình duyệt không set được header Authorization cho websocket,
+// nên không có header thì token được đọc từ query access_token hoặc subprotocol "bearer, <token>"
+func RequireWebsocketAuth(tokenizer Tokenizer) gin.HandlerFunc {
+	return requireAuth(tokenizer, extractWebsocketToken)
+}
+
+func requireAuth(tokenizer Tokenizer, extract func(c *gin.Context) (string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := extract(c)
+
+		if err != nil {
+			appErr := NewUnauthorized(err, "missing or malformed authorization header", "ErrNoToken")
+			c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+			return
+		}
+
+		payload, err := tokenizer.Validate(token)
+
+		if err != nil {
+			appErr := NewUnauthorized(err, err.Error(), "ErrInvalidToken")
+			c.AbortWithStatusJSON(appErr.StatusCode, Localize(c, appErr))
+			return
+		}
+
+		c.Set(CurrentUser, Requester(payload))
+		c.Next()
+	}
+}
+
+func extractBearerToken(header string) (string, error) {
+	parts := strings.Fields(header)
+
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", ErrTokenNotFound
+	}
+
+	return parts[1], nil
+}
+
+func extractWebsocketToken(c *gin.Context) (string, error) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		return extractBearerToken(header)
+	}
+
+	if token := c.Query(WebsocketTokenQuery); token != "" {
+		return token, nil
+	}
+
+	var protocols []string
+
+	for _, p := range strings.Split(c.GetHeader("Sec-WebSocket-Protocol"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			protocols = append(protocols, p)
+		}
+	}
+
+	if len(protocols) == 2 && protocols[0] == WebsocketTokenProtocol {
+		return protocols[1], nil
+	}
+
+	return "", ErrTokenNotFound
+}
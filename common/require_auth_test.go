@@ -0,0 +1,150 @@
+package common
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeTokenizer chỉ chấp nhận token "good" của user 7
+type fakeTokenizer struct{}
+
+func (fakeTokenizer) Generate(data TokenPayload, expiry int) (*Token, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeTokenizer) Validate(token string) (*TokenPayload, error) {
+	if token != "good" {
+		return nil, ErrInvalidToken
+	}
+
+	return &TokenPayload{UserId: 7}, nil
+}
+
+func TestRequireWebsocketAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		header     string
+		query      string
+		protocol   string
+		wantStatus int
+	}{
+		{"bearer header", "Bearer good", "", "", http.StatusOK},
+		{"query param", "", "?access_token=good", "", http.StatusOK},
+		{"subprotocol", "", "", "bearer, good", http.StatusOK},
+		{"invalid query token", "", "?access_token=bad", "", http.StatusUnauthorized},
+		{"subprotocol without bearer", "", "", "chat, good", http.StatusUnauthorized},
+		{"malformed header wins over query", "good", "?access_token=good", "", http.StatusUnauthorized},
+		{"no token", "", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/ws", RequireWebsocketAuth(fakeTokenizer{}), func(c *gin.Context) {
+				c.JSON(http.StatusOK, c.MustGet(CurrentUser).(Requester).GetUserId())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/ws"+tt.query, nil)
+
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			if tt.protocol != "" {
+				req.Header.Set("Sec-WebSocket-Protocol", tt.protocol)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if tt.wantStatus == http.StatusOK && w.Body.String() != "7" {
+				t.Errorf("requester = %s, want 7", w.Body)
+			}
+		})
+	}
+}
+
+func TestRequireAuthIgnoresQueryToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/", RequireAuth(fakeTokenizer{}), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?access_token=good", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", w.Code)
+	}
+}
+
+// mintTestToken ký token thật bằng jwtTokenizer, expiry tính bằng giây (âm là đã hết hạn)
+func mintTestToken(t *testing.T, secret string, userId, expiry int) string {
+	t.Helper()
+
+	token, err := NewJWTTokenizer(secret).Generate(TokenPayload{UserId: userId}, expiry)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token.Token
+}
+
+func TestRequireAuthJWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const secret = "test-secret"
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantKey    string
+	}{
+		{"valid", "Bearer " + mintTestToken(t, secret, 7, 60), http.StatusOK, ""},
+		{"missing", "", http.StatusUnauthorized, "ErrNoToken"},
+		{"expired", "Bearer " + mintTestToken(t, secret, 7, -60), http.StatusUnauthorized, "ErrInvalidToken"},
+		{"wrong secret", "Bearer " + mintTestToken(t, "other-secret", 7, 60), http.StatusUnauthorized, "ErrInvalidToken"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/", RequireAuth(NewJWTTokenizer(secret)), func(c *gin.Context) {
+				c.JSON(http.StatusOK, c.MustGet(CurrentUser).(Requester).GetUserId())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if tt.wantStatus == http.StatusOK && w.Body.String() != "7" {
+				t.Errorf("requester = %s, want 7", w.Body)
+			}
+
+			if tt.wantKey != "" && !strings.Contains(w.Body.String(), `"error_key":"`+tt.wantKey+`"`) {
+				t.Errorf("body = %s, want error_key %s", w.Body, tt.wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"io"
+	"math/rand"
+	"syscall"
+	"time"
+)
+
+const (
+	retryBaseDelay = 50 * time.Millisecond
+	retryMaxDelay  = time.Second
+)
+
+// Mã lỗi MySQL có thể thành công nếu chạy lại
+var transientMySQLErrors = map[uint16]bool{
+	1205: true, // Lock wait timeout exceeded
+	1213: true, // Deadlock found when trying to get lock
+}
+
+// WithRetry chạy fn tối đa attempts lần, chỉ chạy lại khi lỗi là lỗi tạm thời (IsTransientDBError),
+// giữa các lần chờ theo exponential backoff có jitter. Lỗi khác hoặc ctx bị huỷ thì trả về ngay
+func WithRetry(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil || !IsTransientDBError(err) || i == attempts-1 {
+			return err
+		}
+
+		delay := retryBaseDelay << i
+
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+
+	return err
+}
+
+// IsTransientDBError báo lỗi deadlock, lock wait timeout hoặc mất kết nối tới DB
+func IsTransientDBError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+
+	if errors.As(err, &mysqlErr) {
+		return transientMySQLErrors[mysqlErr.Number]
+	}
+
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
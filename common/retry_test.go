@@ -0,0 +1,71 @@
+package common
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"testing"
+)
+
+// failingFn trả lần lượt errs rồi thành công, calls là số lần đã được gọi
+type failingFn struct {
+	errs  []error
+	calls int
+}
+
+func (f *failingFn) call() error {
+	f.calls++
+
+	if f.calls <= len(f.errs) {
+		return f.errs[f.calls-1]
+	}
+
+	return nil
+}
+
+func TestWithRetry(t *testing.T) {
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		attempts  int
+		wantErr   error
+		wantCalls int
+	}{
+		{"transient twice then success", []error{deadlock, fmt.Errorf("update: %w", driver.ErrBadConn)}, 3, nil, 3},
+		{"non-transient is not retried", []error{duplicate}, 3, duplicate, 1},
+		{"gives up after attempts", []error{deadlock, deadlock, deadlock}, 3, deadlock, 3},
+		{"first try succeeds", nil, 3, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := &failingFn{errs: tt.errs}
+
+			err := WithRetry(context.Background(), tt.attempts, fn.call)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if fn.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", fn.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fn := &failingFn{errs: []error{driver.ErrBadConn, driver.ErrBadConn}}
+
+	if err := WithRetry(ctx, 3, fn.call); !errors.Is(err, driver.ErrBadConn) || fn.calls != 1 {
+		t.Errorf("err = %v after %d calls, want ErrBadConn after 1 call", err, fn.calls)
+	}
+}
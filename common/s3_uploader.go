@@ -0,0 +1,76 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"net/http"
+	"strings"
+)
+
+// S3PutObjectAPI là phần của s3.Client mà uploader dùng, tách ra để có thể mock
+type S3PutObjectAPI interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+type S3Uploader struct {
+	client  S3PutObjectAPI
+	bucket  string
+	baseURL string
+}
+
+// NewS3Uploader upload vào bucket, URL trả về là baseURL/key.
+// baseURL rỗng thì dùng URL public mặc định của bucket.
+func NewS3Uploader(client S3PutObjectAPI, bucket, region, baseURL string) *S3Uploader {
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	}
+
+	return &S3Uploader{client: client, bucket: bucket, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (u *S3Uploader) SaveFile(ctx context.Context, data []byte, dst string) (string, error) {
+	key := strings.TrimLeft(dst, "/")
+
+	if _, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(u.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(http.DetectContentType(data)),
+	}); err != nil {
+		return "", NewFullErrorResponse(http.StatusInternalServerError, err, "cannot upload file to storage", "ErrCannotUploadFile")
+	}
+
+	return u.baseURL + "/" + key, nil
+}
+
+// NewUploader chọn uploader theo cfg.UploadProvider: "local" (mặc định) hoặc "s3"
+func NewUploader(ctx context.Context, cfg Config) (Uploader, error) {
+	switch cfg.UploadProvider {
+	case UploadProviderLocal, "":
+		return NewLocalUploader(cfg.UploadDir, cfg.UploadBaseURL), nil
+	case UploadProviderS3:
+		opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(cfg.S3Region)}
+
+		// Không cấu hình key thì dùng credential chain mặc định của AWS (env, IAM role...)
+		if cfg.S3AccessKey != "" {
+			opts = append(opts, awsconfig.WithCredentialsProvider(
+				credentials.NewStaticCredentialsProvider(cfg.S3AccessKey, cfg.S3SecretKey, ""),
+			))
+		}
+
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return NewS3Uploader(s3.NewFromConfig(awsCfg), cfg.S3Bucket, cfg.S3Region, cfg.S3BaseURL), nil
+	default:
+		return nil, fmt.Errorf("unsupported UPLOAD_PROVIDER %q", cfg.UploadProvider)
+	}
+}
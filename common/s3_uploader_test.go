@@ -0,0 +1,73 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"net/http"
+	"testing"
+)
+
+// mockS3Client ghi lại input của lần PutObject cuối, err khác nil thì upload lỗi
+type mockS3Client struct {
+	input *s3.PutObjectInput
+	err   error
+}
+
+func (c *mockS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	c.input = params
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3UploaderSaveFile(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n")
+
+	tests := []struct {
+		name       string
+		baseURL    string
+		err        error
+		wantURL    string
+		wantStatus int
+	}{
+		{"public bucket url", "", nil, "https://todo.s3.ap-southeast-1.amazonaws.com/images/1.png", 0},
+		{"custom base url", "https://cdn.example.com/", nil, "https://cdn.example.com/images/1.png", 0},
+		{"upload error", "", errors.New("access denied"), "", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockS3Client{err: tt.err}
+			uploader := NewS3Uploader(client, "todo", "ap-southeast-1", tt.baseURL)
+
+			url, err := uploader.SaveFile(context.Background(), pngHeader, "/images/1.png")
+
+			if aws.ToString(client.input.Bucket) != "todo" || aws.ToString(client.input.Key) != "images/1.png" {
+				t.Errorf("bucket/key = %s/%s, want todo/images/1.png", aws.ToString(client.input.Bucket), aws.ToString(client.input.Key))
+			}
+
+			if got := aws.ToString(client.input.ContentType); got != "image/png" {
+				t.Errorf("content type = %q, want image/png", got)
+			}
+
+			if tt.wantStatus != 0 {
+				var appErr *AppError
+
+				if !errors.As(err, &appErr) || appErr.StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want AppError with status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil || url != tt.wantURL {
+				t.Errorf("url = %q, %v, want %q", url, err, tt.wantURL)
+			}
+		})
+	}
+}
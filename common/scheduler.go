@@ -0,0 +1,71 @@
+package common
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job là việc chạy định kỳ, lỗi trả về chỉ được log lại rồi chờ lần chạy sau
+type Job func(ctx context.Context) error
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler chạy các Job theo interval trong goroutine riêng, Stop huỷ context và chờ các job đang chạy xong
+type Scheduler struct {
+	jobs   []scheduledJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every đăng ký job, phải gọi trước Start. interval <= 0 thì bỏ qua job
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		return
+	}
+
+	s.jobs = append(s.jobs, scheduledJob{name: name, interval: interval, job: job})
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+
+		go func(j scheduledJob) {
+			defer s.wg.Done()
+
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := j.job(ctx); err != nil {
+						slog.ErrorContext(ctx, "scheduled job failed", slog.String("job", j.name), slog.String("error", err.Error()))
+					}
+				}
+			}
+		}(j)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.wg.Wait()
+}
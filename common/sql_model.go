@@ -3,7 +3,13 @@ package common
 import "time"
 
 type SQLModel struct {
-	Id        int        `json:"id" gorm:"column:id;"`
-	CreatedAt *time.Time `json:"created_at" gorm:"column:created_at;"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty" gorm:"column:updated_at;"`
+	Id        int        `json:"-" xml:"-" gorm:"column:id;"`
+	FakeId    *UID       `json:"id" xml:"id" gorm:"-"`
+	CreatedAt *time.Time `json:"created_at" xml:"created_at" gorm:"column:created_at;"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty" gorm:"column:updated_at;"`
+}
+
+func (m *SQLModel) Mask(dbType int) {
+	uid := NewUID(uint32(m.Id), dbType, 1)
+	m.FakeId = &uid
 }
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+// WithStatementTimeout tạo context con bị huỷ sau timeout, timeout <= 0 thì không giới hạn
+func WithStatementTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// StatementTimeout gắn timeout vào context của request, storage dùng context này (db.WithContext)
+// nên query chạy quá lâu hoặc client ngắt kết nối giữa chừng sẽ bị huỷ
+func StatementTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := WithStatementTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// LoadTimezone đọc tên timezone IANA (ví dụ Asia/Ho_Chi_Minh), rỗng là UTC
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+
+	return loc, nil
+}
+
+// StartOfDay trả về 0h của ngày chứa t theo giờ loc, đổi về UTC để so sánh với dữ liệu trong DB
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).UTC()
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		want     string
+		wantErr  error
+	}{
+		{"empty is UTC", "", "UTC", nil},
+		{"iana name", "America/Los_Angeles", "America/Los_Angeles", nil},
+		{"invalid", "Mars/Olympus", "", ErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadTimezone(tt.timezone)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && loc.String() != tt.want {
+				t.Errorf("location = %s, want %s", loc, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 05:00 UTC ngày 10/03 ở Los Angeles vẫn là tối 09/03
+	at := time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want time.Time
+	}{
+		{"utc", time.UTC, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"los angeles is still the previous day", la, time.Date(2024, 3, 9, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StartOfDay(at, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("StartOfDay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrInvalidToken  = errors.New("invalid token")
+	ErrTokenExpired  = errors.New("token has expired")
+	ErrEncodingToken = errors.New("cannot encode token")
+)
+
+type Tokenizer interface {
+	// Generate tạo token cho payload, expiry tính bằng giây
+	Generate(data TokenPayload, expiry int) (*Token, error)
+	Validate(token string) (*TokenPayload, error)
+}
+
+type Token struct {
+	Token   string    `json:"token"`
+	Created time.Time `json:"created"`
+	Expiry  int       `json:"expiry"`
+}
+
+type TokenPayload struct {
+	UserId int `json:"user_id"`
+}
+
+// TokenPayload chính là Requester sau khi token được xác thực
+func (p *TokenPayload) GetUserId() int {
+	return p.UserId
+}
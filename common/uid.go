@@ -0,0 +1,206 @@
+package common
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/btcsuite/btcutil/base58"
+	"strconv"
+	"strings"
+)
+
+// UID gói local id (id trong DB), loại object và shard id vào một số uint64
+// rồi encode base58, để client không đoán được id thật
+type UID struct {
+	localID    uint32
+	objectType int
+	shardID    uint32
+}
+
+func NewUID(localID uint32, objType int, shardID uint32) UID {
+	return UID{
+		localID:    localID,
+		objectType: objType,
+		shardID:    shardID,
+	}
+}
+
+// Local id: 1, Object: 1, Shard: 1 => 0001 0000000001 000000000000000001
+// localID chiếm các bit từ 28 trở lên, objectType 10 bit (18 - 27), shardID 18 bit cuối
+func (uid UID) String() string {
+	val := uint64(uid.localID)<<28 | uint64(uid.objectType)<<18 | uint64(uid.shardID)<<0
+	return base58.Encode([]byte(fmt.Sprintf("%v", val)))
+}
+
+func (uid UID) GetLocalID() uint32 {
+	return uid.localID
+}
+
+func (uid UID) GetShardID() uint32 {
+	return uid.shardID
+}
+
+func (uid UID) GetObjectType() int {
+	return uid.objectType
+}
+
+// DecomposeUID tách chuỗi số uint64 (đã decode base58) thành các thành phần của UID
+func DecomposeUID(s string) (UID, error) {
+	uid, err := strconv.ParseUint(s, 10, 64)
+
+	if err != nil {
+		return UID{}, err
+	}
+
+	if (1 << 18) > uid {
+		return UID{}, errors.New("wrong uid")
+	}
+
+	u := UID{
+		localID:    uint32(uid >> 28),
+		objectType: int(uid >> 18 & 0x3FF),
+		shardID:    uint32(uid >> 0 & 0x3FFFF),
+	}
+
+	return u, nil
+}
+
+func FromBase58(s string) (UID, error) {
+	return DecomposeUID(string(base58.Decode(s)))
+}
+
+var ErrWrongObjectType = errors.New("id belongs to another object type")
+
+// ParseLocalId nhận fake id (base58) từ path param, nếu không phải thì fallback về id số.
+// Fake id phải là của loại object dbType (ví dụ id của item không dùng làm id của user được)
+func ParseLocalId(s string, dbType int) (int, error) {
+	uid, err := FromBase58(s)
+
+	if err != nil {
+		return strconv.Atoi(s)
+	}
+
+	if uid.GetObjectType() != dbType {
+		// Chuỗi toàn số có thể vô tình decode được thành UID, vẫn coi là id số
+		if id, err := strconv.Atoi(s); err == nil {
+			return id, nil
+		}
+
+		return 0, fmt.Errorf("%w: %q", ErrWrongObjectType, s)
+	}
+
+	return int(uid.GetLocalID()), nil
+}
+
+func (uid UID) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("\"%s\"", uid.String())), nil
+}
+
+// MarshalText dùng khi render XML
+func (uid UID) MarshalText() ([]byte, error) {
+	return []byte(uid.String()), nil
+}
+
+func (uid *UID) UnmarshalJSON(data []byte) error {
+	decodeUID, err := FromBase58(strings.ReplaceAll(string(data), "\"", ""))
+
+	if err != nil {
+		return err
+	}
+
+	uid.localID = decodeUID.localID
+	uid.shardID = decodeUID.shardID
+	uid.objectType = decodeUID.objectType
+
+	return nil
+}
+
+func (uid *UID) Value() (driver.Value, error) {
+	if uid == nil {
+		return nil, nil
+	}
+
+	return int64(uid.localID), nil
+}
+
+func (uid *UID) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var i uint32
+
+	switch t := value.(type) {
+	case int:
+		i = uint32(t)
+	case int8:
+		i = uint32(t)
+	case int16:
+		i = uint32(t)
+	case int32:
+		i = uint32(t)
+	case int64:
+		i = uint32(t)
+	case uint8:
+		i = uint32(t)
+	case uint16:
+		i = uint32(t)
+	case uint32:
+		i = t
+	case uint64:
+		i = uint32(t)
+	case []byte:
+		a, err := strconv.Atoi(string(t))
+		if err != nil {
+			return err
+		}
+		i = uint32(a)
+	default:
+		return errors.New("invalid Scan Source")
+	}
+
+	*uid = NewUID(i, 0, 1)
+
+	return nil
+}
+
+// LocalIds nhận mảng id item trong body, mỗi phần tử là fake id (base58) của item hoặc id số giống ParseLocalId
+type LocalIds []int
+
+func (ids *LocalIds) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	result := make(LocalIds, 0, len(raw))
+
+	for _, r := range raw {
+		var s string
+
+		if err := json.Unmarshal(r, &s); err != nil {
+			var n int
+
+			if err := json.Unmarshal(r, &n); err != nil {
+				return fmt.Errorf("invalid id %s", r)
+			}
+
+			result = append(result, n)
+			continue
+		}
+
+		id, err := ParseLocalId(s, DbTypeItem)
+
+		if err != nil {
+			return fmt.Errorf("invalid id %q", s)
+		}
+
+		result = append(result, id)
+	}
+
+	*ids = result
+
+	return nil
+}
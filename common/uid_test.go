@@ -0,0 +1,57 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUIDRoundTrip(t *testing.T) {
+	uid := NewUID(1, DbTypeItem, 1)
+
+	got, err := FromBase58(uid.String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetLocalID() != 1 || got.GetObjectType() != DbTypeItem || got.GetShardID() != 1 {
+		t.Errorf("decoded %+v, want local 1, type %d, shard 1", got, DbTypeItem)
+	}
+}
+
+func TestParseLocalId(t *testing.T) {
+	itemUID := NewUID(7, DbTypeItem, 1).String()
+	userUID := NewUID(7, DbTypeUser, 1).String()
+
+	tests := []struct {
+		name    string
+		s       string
+		dbType  int
+		want    int
+		wantErr error
+	}{
+		{"item uid as item", itemUID, DbTypeItem, 7, nil},
+		{"user uid as user", userUID, DbTypeUser, 7, nil},
+		{"item uid as user", itemUID, DbTypeUser, 0, ErrWrongObjectType},
+		{"user uid as comment", userUID, DbTypeComment, 0, ErrWrongObjectType},
+		{"numeric id", "42", DbTypeItem, 42, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLocalId(tt.s, tt.dbType)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("id = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseLocalId("not-an-id", DbTypeItem); err == nil {
+		t.Error("garbage id accepted")
+	}
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	UploadProviderLocal = "local"
+	UploadProviderS3    = "s3"
+)
+
+type Uploader interface {
+	// SaveFile lưu data vào đường dẫn dst (tương đối) và trả về URL truy cập file
+	SaveFile(ctx context.Context, data []byte, dst string) (string, error)
+}
+
+type localUploader struct {
+	rootDir string
+	baseURL string
+}
+
+// NewLocalUploader lưu file vào rootDir trên ổ đĩa, URL trả về có dạng baseURL/dst
+func NewLocalUploader(rootDir, baseURL string) *localUploader {
+	return &localUploader{rootDir: rootDir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (u *localUploader) SaveFile(ctx context.Context, data []byte, dst string) (string, error) {
+	path := filepath.Join(u.rootDir, filepath.Clean("/"+dst))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+
+	return u.baseURL + "/" + strings.TrimLeft(filepath.ToSlash(dst), "/"), nil
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalUploaderSaveFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      string
+		wantPath string
+		wantURL  string
+	}{
+		{"nested", "images/1.png", "images/1.png", "/static/images/1.png"},
+		{"leading slash", "/images/1.png", "images/1.png", "/static/images/1.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+
+			url, err := NewLocalUploader(root, "/static/").SaveFile(context.Background(), []byte("data"), tt.dst)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if url != tt.wantURL {
+				t.Errorf("url = %q, want %q", url, tt.wantURL)
+			}
+
+			if got, err := os.ReadFile(filepath.Join(root, tt.wantPath)); err != nil || string(got) != "data" {
+				t.Errorf("file at %s = %q, %v", tt.wantPath, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ValidationError gom lỗi của từng field để client hiển thị hết một lần thay vì sửa từng lỗi
+type ValidationError struct {
+	Fields map[string]string
+	errs   []error
+}
+
+func NewValidationError() *ValidationError {
+	return &ValidationError{Fields: map[string]string{}}
+}
+
+// Add ghi lỗi cho field, field đã có lỗi thì giữ lỗi đầu tiên
+func (e *ValidationError) Add(field string, err error) {
+	if _, ok := e.Fields[field]; ok {
+		return
+	}
+
+	e.Fields[field] = err.Error()
+	e.errs = append(e.errs, err)
+}
+
+// WithPrefix trả về bản sao có tên field được thêm prefix, dùng khi validate một phần tử trong danh sách
+func (e *ValidationError) WithPrefix(prefix string) *ValidationError {
+	prefixed := &ValidationError{Fields: make(map[string]string, len(e.Fields)), errs: e.errs}
+
+	for field, msg := range e.Fields {
+		prefixed.Fields[prefix+field] = msg
+	}
+
+	return prefixed
+}
+
+// Err trả về nil nếu không có lỗi nào, tránh trả về *ValidationError nil bị coi là khác nil
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+
+	for field := range e.Fields {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for i, field := range fields {
+		fields[i] = e.Fields[field]
+	}
+
+	return strings.Join(fields, "; ")
+}
+
+// Unwrap để errors.Is vẫn nhận ra các lỗi gốc của từng field
+func (e *ValidationError) Unwrap() []error {
+	return e.errs
+}
+
+// ErrValidation trả 422, details lấy từ ValidationError nằm trong err (err có thể đã được wrap thêm ngữ cảnh)
+func ErrValidation(err error) *AppError {
+	appErr := NewFullErrorResponse(http.StatusUnprocessableEntity, err, "validation failed", "ErrValidation")
+
+	var validationErr *ValidationError
+
+	if errors.As(err, &validationErr) {
+		appErr.Details = validationErr.Fields
+	}
+
+	return appErr
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestValidationErrorWithPrefix(t *testing.T) {
+	errBlank := errors.New("title cannot be blank")
+
+	validationErr := NewValidationError()
+	validationErr.Add("title", errBlank)
+
+	tests := []struct {
+		name      string
+		err       *ValidationError
+		wantField string
+	}{
+		{"prefixed", validationErr.WithPrefix("[1]."), "[1].title"},
+		{"original untouched", validationErr, "title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.err.Fields) != 1 || tt.err.Fields[tt.wantField] != errBlank.Error() {
+				t.Errorf("fields = %v, want only %s", tt.err.Fields, tt.wantField)
+			}
+
+			if !errors.Is(tt.err, errBlank) {
+				t.Error("errors.Is does not find the field error")
+			}
+		})
+	}
+}
+
+func TestErrValidation(t *testing.T) {
+	errBlank := errors.New("title cannot be blank")
+	errInvalid := errors.New("invalid status")
+
+	empty := NewValidationError()
+	twoFields := NewValidationError()
+	twoFields.Add("title", errBlank)
+	twoFields.Add("status", errInvalid)
+	twoFields.Add("title", errors.New("title is too long"))
+
+	if empty.Err() != nil {
+		t.Fatalf("Err() without fields = %v, want nil", empty.Err())
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"validation error", twoFields},
+		{"wrapped", ErrInvalidRequest(twoFields)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := ErrValidation(tt.err)
+
+			if appErr.StatusCode != http.StatusUnprocessableEntity || len(appErr.Details) != 2 {
+				t.Fatalf("error = %d %v, want 422 with two fields", appErr.StatusCode, appErr.Details)
+			}
+
+			if appErr.Details["title"] != errBlank.Error() || appErr.Details["status"] != errInvalid.Error() {
+				t.Errorf("details = %v, want the first error of each field", appErr.Details)
+			}
+		})
+	}
+}
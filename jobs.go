@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/storage"
+)
+
+// registerJobs đăng ký các job chạy nền, interval = 0 trong config là tắt job đó
+func registerJobs(scheduler *common.Scheduler, db *gorm.DB, cfg common.Config, notifier common.Notifier, dispatcher common.EventDispatcher, bus *common.EventBus, cache storage.ItemCache) {
+	scheduler.Every("purge-deleted-items", cfg.PurgeInterval, func(ctx context.Context) error {
+		business := biz.NewPurgeDeletedItemsBiz(storage.NewCachedStorage(storage.NewSQLStorage(db), cache), cfg.PurgeRetention)
+
+		purged, err := business.PurgeDeletedItems(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "purged deleted items", slog.Int64("rows", purged))
+
+		return nil
+	})
+
+	scheduler.Every("remind-due-items", cfg.ReminderInterval, func(ctx context.Context) error {
+		business := biz.NewRemindDueItemsBiz(storage.NewSQLStorage(db), notifier, cfg.ReminderWindow)
+
+		sent, err := business.RemindDueItems(ctx)
+
+		if sent > 0 {
+			slog.InfoContext(ctx, "sent due date reminders", slog.Int("count", sent))
+		}
+
+		return err
+	})
+
+	scheduler.Every("generate-recurring-items", cfg.RecurrenceInterval, func(ctx context.Context) error {
+		business := biz.NewGenerateRecurringItemsBiz(storage.NewSQLStorage(db))
+
+		created, err := business.GenerateRecurringItems(ctx)
+
+		if created > 0 {
+			slog.InfoContext(ctx, "generated recurring items", slog.Int("count", created))
+		}
+
+		return err
+	})
+
+	scheduler.Every("relay-outbox-events", cfg.OutboxRelayInterval, func(ctx context.Context) error {
+		business := biz.NewRelayOutboxEventsBiz(storage.NewSQLStorage(db), bus, dispatcher)
+
+		published, err := business.RelayOutboxEvents(ctx)
+
+		if published > 0 {
+			slog.InfoContext(ctx, "relayed outbox events", slog.Int("count", published))
+		}
+
+		return err
+	})
+	scheduler.Every("prune-outbox-events", cfg.PurgeInterval, func(ctx context.Context) error {
+		business := biz.NewPruneOutboxEventsBiz(storage.NewSQLStorage(db), cfg.OutboxRetention)
+
+		pruned, err := business.PruneOutboxEvents(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "pruned published outbox events", slog.Int64("rows", pruned))
+
+		return nil
+	})
+}
@@ -1,49 +1,267 @@
 package main
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
-	"gorm.io/driver/mysql"
-	"gorm.io/gorm"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"social-todo-list/common"
+	gincomment "social-todo-list/modules/comment/transport/gin"
+	itemmodel "social-todo-list/modules/item/model"
+	itemstorage "social-todo-list/modules/item/storage"
 	ginitem "social-todo-list/modules/item/transport/gin"
+	ginsharelink "social-todo-list/modules/sharelink/transport/gin"
+	ginsubtask "social-todo-list/modules/subtask/transport/gin"
+	"social-todo-list/modules/upload/transport/ginupload"
+	ginuserlikeitem "social-todo-list/modules/userlikeitem/transport/gin"
+	webhookbiz "social-todo-list/modules/webhook/biz"
+	webhookstorage "social-todo-list/modules/webhook/storage"
+	ginwebhook "social-todo-list/modules/webhook/transport/gin"
+	"syscall"
 )
 
 func main() {
-	dsn := os.Getenv("DB_CONN_STR")
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	cfg, err := common.LoadConfig()
 
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	r := gin.Default()
+	db, err := common.NewDatabase(*cfg)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Câu SELECT trên db đi DB_REPLICA_DSN nếu có. Migration, job và các API đọc rồi ghi dùng primaryDB
+	// để không đọc phải bản cũ ngay sau khi ghi
+	primaryDB := common.Primary(db)
+
+	// `social-todo-list migrate` chỉ chạy migration rồi thoát
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrations(primaryDB); err != nil {
+			log.Fatalln(err)
+		}
+
+		return
+	}
+
+	if cfg.AutoMigrate {
+		if err := runMigrations(primaryDB); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	// `social-todo-list seed [--count=N] [--force]` tạo dữ liệu demo rồi thoát, bảng phải được migrate trước
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := runSeed(primaryDB, os.Args[2:]); err != nil {
+			log.Fatalln(err)
+		}
+
+		return
+	}
+
+	// Message lỗi được dịch theo Accept-Language (common/locales), không hỗ trợ thì giữ tiếng Anh
+	catalog, err := common.LoadMessageCatalog()
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// gin.New thay vì gin.Default: log và recover đã có RequestLogger và Recover, không cần Logger và Recovery của Gin
+	r := gin.New()
+	r.Use(common.Metrics(), common.RequestLogger(), common.Localization(catalog), common.Recover(), common.CORS(cfg.CORSAllowedOrigins), common.Gzip(cfg.GzipMinSize))
 
 	// CRUD: Create, Read, Update, Delete
-	// POST /v1/items/ (Create a new item)
-	// GET /v1/items (list items) v1/items?page=1
-	// GET /v1/items/:id (get item detail by id)
-	// (PUT || PATCH) /v1/items/:id (Update an item by id)
+	// POST /v1/items/ (Create a new item, optional Idempotency-Key header, ?allow_duplicate=true to skip the title check, ?dry_run=true to validate only)
+	// POST /v1/items/batch (Create many items at once, ?best_effort=true creates the valid ones and returns 207 with a result per item)
+	// POST /v1/items/import (Import a JSON array of items, invalid ones are skipped and reported)
+	// GET /v1/items (list items) v1/items?page=1, trả XML khi Accept ưu tiên application/xml, ?due_today=true&timezone=Asia/Ho_Chi_Minh, ?sort=smart xếp theo hạn, ?filter={"status":["Doing"],"due_before":"..."} là JSON filter
+	// GET /v1/items/stats (Item counts by status of the requester, ?timezone= decides the day boundaries)
+	// GET /v1/items/export (Download the requester's items as CSV)
+	// GET /v1/items/suggest?q= (Up to SUGGEST_LIMIT {id, title} of items whose title starts with q, newest first)
+	// GET /v1/items/views/today (Not-done items due today or overdue, by due date then priority, ?timezone=)
+	// GET /v1/items/views/upcoming?days=7 (Not-done items due in the next N days after today, N is capped at 90, ?timezone=)
+	// GET /v1/items/:id (get item detail by id, JSON hoặc XML theo Accept)
+	// PATCH /v1/items/:id (Update the given fields of an item, ?dry_run=true returns the result without saving)
+	// PUT /v1/items/:id (Replace an item, title/description/status are required and omitted fields are reset)
+	// PATCH /v1/items/status (Update status of many items at once)
 	// DELETE /v1/items/:id (Delete item by id)
+	// DELETE /v1/items (Soft-delete many items at once, body {"ids": [...]}, returns the number deleted)
+	// POST /v1/items/:id/restore (Restore a soft-deleted item)
+	// POST /v1/items/:id/archive (Hide an item from the default list, ?include_archived=true or ?only_archived=true shows it)
+	// DELETE /v1/items/:id/archive (Unarchive an item)
+	// POST /v1/items/:id/toggle (Flip Doing <-> Done and return the new status, body {"done": true|false} sets it explicitly)
+	// POST /v1/items/:id/clone (Copy an item as a new Doing item, ?title_suffix= defaults to " (copy)")
+	// POST /v1/items/:id/assign (Hand an item over to another user, body {"user_id"}, only the owner can do it)
+	// GET /v1/items/:id/history (Audit log of an item's changes, newest first)
+	// PATCH /v1/items/:id/position (Move an item right after {"after_id"}, no after_id moves it to the top; list with ?sort=position)
+	// POST /v1/items/:id/comments (Comment on an item)
+	// GET /v1/items/:id/comments (List comments of an item, oldest first)
+	// POST /v1/items/:id/like (Like an item, liking twice is a no-op)
+	// DELETE /v1/items/:id/like (Unlike an item)
+	// POST /v1/items/:id/subtasks (Add a subtask to an item's checklist)
+	// GET /v1/items/:id/subtasks (List subtasks in checklist order)
+	// POST /v1/items/:id/subtasks/:subtask_id/toggle (Toggle a subtask, ?auto_complete=true completes the item when all are done)
+	// POST /v1/upload (Upload an image, multipart field "file")
+	// POST /v1/share (Create or rotate the requester's read-only share token)
+	// DELETE /v1/share (Revoke the share token, the old link returns 404)
+	// POST /v1/webhooks (Register a webhook URL for item events, the signing secret is only returned here)
+	// GET /v1/webhooks (List the requester's webhooks, without secrets)
+	// DELETE /v1/webhooks/:id (Remove a webhook)
+	// GET /share/:token (Public, paginated non-deleted items of the token owner)
+	// GET /v2/items (Same as GET /v1/items, the envelope also carries "api_version": "v2")
+	// GET /metrics (Prometheus metrics)
+	// GET /ws/items (Websocket, push create/update/delete events of the requester's items, token in the Authorization header, ?access_token= or the subprotocols ["bearer", token])
+
+	tokenizer := common.NewJWTTokenizer(cfg.JWTSecret)
+	uploader, err := common.NewUploader(context.Background(), *cfg)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if cfg.UploadProvider == common.UploadProviderLocal {
+		r.Static("/static", cfg.UploadDir)
+	}
+
+	// Item tạo mới không gửi status thì lấy DEFAULT_ITEM_STATUS
+	defaultStatus, err := itemmodel.ParseDefaultStatus(cfg.DefaultItemStatus)
 
-	v1 := r.Group("/v1")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Title/description dài hơn MAX_TITLE_LENGTH/MAX_DESCRIPTION_LENGTH ký tự thì trả 422
+	lengthLimits := itemmodel.LengthLimits{Title: cfg.MaxTitleLength, Description: cfg.MaxDescriptionLength}
+
+	// Title/description của item được làm sạch HTML trước khi lưu, HTML_SANITIZE_MODE=strip|escape
+	sanitizer, err := common.NewHTMLSanitizer(cfg.HTMLSanitizeMode)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Job relay outbox đẩy event của các thay đổi đã commit lên bus, websocket subscribe theo từng user
+	bus := common.NewEventBus()
+
+	// Cache item theo id dùng chung cho mọi request, ITEM_CACHE_BACKEND=none (mặc định) là tắt
+	itemCache, err := itemstorage.NewItemCache(*cfg)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	r.GET("/metrics", common.MetricsHandler())
+	r.GET("/ws/items", common.RequireWebsocketAuth(tokenizer), ginitem.ItemFeed(bus))
+	r.GET("/share/:token", common.StatementTimeout(cfg.DBStatementTimeout), ginsharelink.ListSharedItems(db))
+
+	// Mỗi version là một route group, handler đọc common.APIVersion để đổi response theo version.
+	// Prefix version không có group nào thì gin trả 404
+	v1 := r.Group("/v1", common.SetAPIVersion(common.APIVersion1), common.StatementTimeout(cfg.DBStatementTimeout))
+	{
+		// Body upload là multipart, cho phép thêm 64KB ngoài UPLOAD_MAX_SIZE cho header và boundary
+		v1.POST("/upload", common.RequireAuth(tokenizer), common.BodyLimit(cfg.UploadMaxSize+64<<10), ginupload.UploadImage(uploader, cfg.UploadMaxSize))
+		v1.POST("/share", common.RequireAuth(tokenizer), ginsharelink.CreateShareLink(primaryDB))
+		v1.DELETE("/share", common.RequireAuth(tokenizer), ginsharelink.RevokeShareLink(primaryDB))
+
+		webhooks := v1.Group("/webhooks", common.RequireAuth(tokenizer), common.BodyLimit(cfg.MaxBodySize))
+		{
+			webhooks.POST("", ginwebhook.CreateWebhook(primaryDB, cfg.WebhookAllowPrivateURLs))
+			webhooks.GET("", ginwebhook.ListWebhooks(db))
+			webhooks.DELETE("/:id", ginwebhook.DeleteWebhook(primaryDB))
+		}
+
+		items := v1.Group("/items", common.RequireAuth(tokenizer), common.BodyLimit(cfg.MaxBodySize))
+		{
+			createLimiter := common.RateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+
+			items.POST("", createLimiter, ginitem.CreateItem(primaryDB, cfg.IdempotencyKeyTTL, cfg.DailyCreateQuota, defaultStatus, lengthLimits, sanitizer))
+			items.POST("/batch", createLimiter, ginitem.CreateItems(primaryDB, cfg.DailyCreateQuota, defaultStatus, lengthLimits, sanitizer))
+			items.POST("/import", createLimiter, ginitem.ImportItems(primaryDB, cfg.DailyCreateQuota, defaultStatus, lengthLimits, sanitizer))
+			items.GET("", common.ContentNegotiation(), ginitem.ListItem(db))
+			items.GET("/stats", ginitem.GetStats(db))
+			items.GET("/export", ginitem.ExportItems(db))
+			items.GET("/suggest", ginitem.SuggestTitles(db, cfg.SuggestLimit))
+			items.GET("/views/today", ginitem.ListTodayItems(db))
+			items.GET("/views/upcoming", ginitem.ListUpcomingItems(db))
+			// Get item nạp cache dùng chung, đọc replica ngay sau khi ghi sẽ giữ bản cũ trong cache cả TTL
+			items.GET("/:id", common.ContentNegotiation(), ginitem.GetItem(primaryDB, cfg.HideForbiddenAsNotFound, itemCache))
+			items.PATCH("/status", ginitem.UpdateItemsStatus(primaryDB, itemCache))
+			items.DELETE("", ginitem.DeleteItems(primaryDB, itemCache))
+			items.PATCH("/:id", ginitem.UpdateItem(primaryDB, cfg.HideForbiddenAsNotFound, lengthLimits, sanitizer, itemCache))
+			items.PUT("/:id", ginitem.ReplaceItem(primaryDB, cfg.HideForbiddenAsNotFound, lengthLimits, sanitizer, itemCache))
+			items.DELETE("/:id", ginitem.DeleteItem(primaryDB, cfg.HideForbiddenAsNotFound, itemCache))
+			items.POST("/:id/restore", ginitem.RestoreItem(primaryDB, itemCache))
+			items.POST("/:id/archive", ginitem.ArchiveItem(primaryDB, itemCache))
+			items.DELETE("/:id/archive", ginitem.UnarchiveItem(primaryDB, itemCache))
+			items.POST("/:id/toggle", ginitem.ToggleItem(primaryDB, cfg.HideForbiddenAsNotFound, itemCache))
+			items.POST("/:id/clone", createLimiter, ginitem.CloneItem(primaryDB, cfg.DailyCreateQuota, lengthLimits, sanitizer))
+			items.POST("/:id/assign", ginitem.AssignItem(primaryDB, itemCache))
+			items.PATCH("/:id/position", ginitem.ReorderItem(primaryDB, itemCache))
+			items.GET("/:id/history", ginitem.ListItemHistory(db))
+			items.POST("/:id/comments", gincomment.CreateComment(primaryDB))
+			items.GET("/:id/comments", gincomment.ListComments(db))
+			items.POST("/:id/like", ginuserlikeitem.LikeItem(primaryDB))
+			items.DELETE("/:id/like", ginuserlikeitem.UnlikeItem(primaryDB))
+			items.POST("/:id/subtasks", ginsubtask.CreateSubtask(primaryDB))
+			items.GET("/:id/subtasks", ginsubtask.ListSubtasks(db))
+			items.POST("/:id/subtasks/:subtask_id/toggle", ginsubtask.ToggleSubtask(primaryDB, itemCache))
+		}
+	}
+
+	// v2 mới có list item (response có thêm api_version), các API khác vẫn dùng /v1
+	v2 := r.Group("/v2", common.SetAPIVersion(common.APIVersion2), common.StatementTimeout(cfg.DBStatementTimeout))
 	{
-		items := v1.Group("/items")
+		items := v2.Group("/items", common.RequireAuth(tokenizer))
 		{
-			items.POST("", ginitem.CreateItem(db))
-			items.GET("", ginitem.ListItem(db))
-			items.GET("/:id", ginitem.GetItem(db))
-			items.PATCH("/:id", ginitem.UpdateItem(db))
-			items.DELETE("/:id", ginitem.DeleteItem(db))
+			items.GET("", common.ContentNegotiation(), ginitem.ListItem(db))
 		}
 	}
 
+	r.GET("/healthz", common.HealthCheck(db, cfg.HealthCheckTimeout))
+
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "item",
 		})
 	})
 
-	r.Run()
+	server := &http.Server{
+		Addr:    cfg.Addr(),
+		Handler: r,
+	}
+
+	scheduler := common.NewScheduler()
+	// Event trong outbox được gửi tới webhook của chủ item, body ký HMAC-SHA256 ở header X-Signature
+	dispatcher := webhookbiz.NewWebhookDispatcher(webhookstorage.NewSQLStorage(primaryDB), cfg.WebhookAllowPrivateURLs)
+	registerJobs(scheduler, primaryDB, *cfg, common.NewLogNotifier(), dispatcher, bus, itemCache)
+	scheduler.Start(context.Background())
+
+	listener, err := net.Listen("tcp", server.Addr)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	// Chờ SIGINT/SIGTERM rồi mới tắt server, để các request đang chạy được xử lý xong
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	if err := runServer(server, listener, quit, cfg.ShutdownTimeout); err != nil {
+		log.Println("server forced to shutdown:", err)
+	}
+
+	scheduler.Stop()
+
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			log.Println("cannot close database:", err)
+		}
+	}
+
+	log.Println("server exited")
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"gorm.io/gorm"
+	"log"
+	commentmodel "social-todo-list/modules/comment/model"
+	"social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+	subtaskmodel "social-todo-list/modules/subtask/model"
+	usermodel "social-todo-list/modules/user/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	webhookmodel "social-todo-list/modules/webhook/model"
+	"strings"
+)
+
+// migrationModels là danh sách model được AutoMigrate, thêm model mới vào đây
+var migrationModels = []interface{}{
+	&model.TodoItem{},
+	&model.IdempotencyKey{},
+	&model.ItemAuditLog{},
+	&model.OutboxEvent{},
+	&model.ItemUserLock{},
+	&commentmodel.Comment{},
+	&likemodel.Like{},
+	&subtaskmodel.Subtask{},
+	&usermodel.User{},
+	&sharelinkmodel.ShareLink{},
+	&webhookmodel.Webhook{},
+}
+
+func runMigrations(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if err := migrateItemStatus(db); err != nil {
+		return err
+	}
+
+	for _, m := range migrationModels {
+		stmt := &gorm.Statement{DB: db}
+
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+
+		table := stmt.Schema.Table
+		existed := migrator.HasTable(m)
+
+		if err := db.AutoMigrate(m); err != nil {
+			return err
+		}
+
+		if existed {
+			log.Printf("migrated table %s", table)
+		} else {
+			log.Printf("created table %s", table)
+		}
+	}
+
+	return nil
+}
+
+// migrateItemStatus đổi cột status của todo_items từ tên trạng thái ("Doing", "Done", "Deleted") sang số.
+// Bảng chưa có hoặc cột đã là số thì không làm gì, giá trị lạ thành NULL (item chưa có status)
+func migrateItemStatus(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if !migrator.HasTable(&model.TodoItem{}) {
+		return nil
+	}
+
+	columns, err := migrator.ColumnTypes(&model.TodoItem{})
+
+	if err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		if column.Name() == "status" && strings.Contains(strings.ToUpper(column.DatabaseTypeName()), "INT") {
+			return nil
+		}
+	}
+
+	statuses := []model.ItemStatus{model.ItemStatusDoing, model.ItemStatusDone, model.ItemStatusDeleted}
+	sql := "UPDATE todo_items SET status = CASE status"
+	vars := make([]interface{}, 0, 2*len(statuses))
+
+	for i := range statuses {
+		sql += " WHEN ? THEN ?"
+		vars = append(vars, statuses[i].String(), int(statuses[i]))
+	}
+
+	if err := db.Exec(sql+" ELSE NULL END", vars...).Error; err != nil {
+		return err
+	}
+
+	if err := migrator.AlterColumn(&model.TodoItem{}, "Status"); err != nil {
+		return err
+	}
+
+	log.Printf("migrated todo_items.status to int")
+
+	return nil
+}
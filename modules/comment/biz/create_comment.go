@@ -0,0 +1,55 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+	itemmodel "social-todo-list/modules/item/model"
+)
+
+type CreateCommentStorage interface {
+	CreateComment(ctx context.Context, data *model.CommentCreation) error
+}
+
+type createCommentBiz struct {
+	store      CreateCommentStorage
+	itemStore  ItemStorage
+	shareStore ShareLinkStorage
+	requester  common.Requester
+}
+
+func NewCreateCommentBiz(
+	store CreateCommentStorage,
+	itemStore ItemStorage,
+	shareStore ShareLinkStorage,
+	requester common.Requester,
+) *createCommentBiz {
+	return &createCommentBiz{store: store, itemStore: itemStore, shareStore: shareStore, requester: requester}
+}
+
+func (biz *createCommentBiz) CreateComment(ctx context.Context, itemId int, data *model.CommentCreation) error {
+	if err := data.Validate(); err != nil {
+		return common.ErrInvalidRequest(err)
+	}
+
+	if err := findParentItem(ctx, biz.itemStore, biz.shareStore, itemId, biz.requester.GetUserId()); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		if err == ErrItemNotShared {
+			return common.ErrForbidden(itemmodel.EntityName, err)
+		}
+
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	data.ItemId = itemId
+	data.UserId = biz.requester.GetUserId()
+
+	if err := biz.store.CreateComment(ctx, data); err != nil {
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+	itemmodel "social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+	"testing"
+)
+
+// mockItemStorage tìm item theo id giống storage của module item (không lọc theo user_id)
+type mockItemStorage struct {
+	items map[int]*itemmodel.TodoItem
+}
+
+func (s *mockItemStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error) {
+	if _, ok := cond["user_id"]; ok {
+		panic("parent item must be looked up by id only")
+	}
+
+	item, ok := s.items[cond["id"].(int)]
+
+	if !ok {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
+
+// mockShareLinkStorage coi sharedBy là danh sách user đang có link chia sẻ
+type mockShareLinkStorage struct {
+	sharedBy map[int]bool
+}
+
+func (s *mockShareLinkStorage) FindShareLink(ctx context.Context, cond map[string]interface{}) (*sharelinkmodel.ShareLink, error) {
+	userId := cond["user_id"].(int)
+
+	if !s.sharedBy[userId] {
+		return nil, common.RecordNotFound
+	}
+
+	return &sharelinkmodel.ShareLink{UserId: userId}, nil
+}
+
+type mockCreateCommentStorage struct {
+	created []*model.CommentCreation
+}
+
+func (s *mockCreateCommentStorage) CreateComment(ctx context.Context, data *model.CommentCreation) error {
+	s.created = append(s.created, data)
+	return nil
+}
+
+func newTestItem(id, userId int, status itemmodel.ItemStatus) *itemmodel.TodoItem {
+	item := &itemmodel.TodoItem{UserId: userId, Status: &status}
+	item.Id = id
+
+	return item
+}
+
+func TestCreateComment(t *testing.T) {
+	const ownerId, otherId = 1, 2
+
+	itemStore := &mockItemStorage{items: map[int]*itemmodel.TodoItem{
+		10: newTestItem(10, ownerId, itemmodel.ItemStatusDoing),
+		11: newTestItem(11, ownerId, itemmodel.ItemStatusDeleted),
+	}}
+
+	cases := []struct {
+		name       string
+		userId     int
+		itemId     int
+		shared     bool
+		wantStatus int
+	}{
+		{name: "owner", userId: ownerId, itemId: 10},
+		{name: "other user on shared item", userId: otherId, itemId: 10, shared: true},
+		{name: "other user on private item", userId: otherId, itemId: 10, wantStatus: http.StatusForbidden},
+		{name: "deleted item", userId: ownerId, itemId: 11, wantStatus: http.StatusNotFound},
+		{name: "missing item", userId: ownerId, itemId: 12, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockCreateCommentStorage{}
+			shareStore := &mockShareLinkStorage{sharedBy: map[int]bool{ownerId: tc.shared}}
+			business := NewCreateCommentBiz(store, itemStore, shareStore, common.NewRequester(tc.userId))
+
+			err := business.CreateComment(context.Background(), tc.itemId, &model.CommentCreation{Content: "hi"})
+
+			if tc.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(store.created) != 1 || store.created[0].UserId != tc.userId {
+					t.Fatalf("comment not created for user %d: %+v", tc.userId, store.created)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected status %d, got nil error", tc.wantStatus)
+			}
+
+			if got := common.ToAppError(err).StatusCode; got != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", got, tc.wantStatus)
+			}
+
+			if len(store.created) != 0 {
+				t.Fatal("comment must not be created")
+			}
+		})
+	}
+}
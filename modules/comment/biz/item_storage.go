@@ -0,0 +1,49 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+)
+
+var ErrItemNotShared = errors.New("item is not shared by its owner")
+
+// ItemStorage dùng để kiểm tra item cha có tồn tại, được implement bởi storage của module item
+type ItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error)
+}
+
+// ShareLinkStorage dùng để biết chủ item có đang chia sẻ danh sách item không, được implement bởi storage của module sharelink
+type ShareLinkStorage interface {
+	FindShareLink(ctx context.Context, cond map[string]interface{}) (*sharelinkmodel.ShareLink, error)
+}
+
+// findParentItem lấy item cha theo id (item đã xoá coi như không tồn tại) rồi mới kiểm tra quyền:
+// requester là chủ item, hoặc chủ item đang có link chia sẻ (item công khai) thì được xem và bình luận
+func findParentItem(ctx context.Context, store ItemStorage, shareStore ShareLinkStorage, itemId, userId int) error {
+	item, err := store.GetItem(ctx, map[string]interface{}{"id": itemId})
+
+	if err != nil {
+		return err
+	}
+
+	if item.Status != nil && *item.Status == itemmodel.ItemStatusDeleted {
+		return common.RecordNotFound
+	}
+
+	if item.UserId == userId {
+		return nil
+	}
+
+	if _, err := shareStore.FindShareLink(ctx, map[string]interface{}{"user_id": item.UserId}); err != nil {
+		if err == common.RecordNotFound {
+			return ErrItemNotShared
+		}
+
+		return err
+	}
+
+	return nil
+}
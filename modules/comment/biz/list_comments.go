@@ -0,0 +1,54 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+	itemmodel "social-todo-list/modules/item/model"
+)
+
+type ListCommentsStorage interface {
+	ListComments(
+		ctx context.Context,
+		cond map[string]interface{},
+		paging *common.Paging,
+	) ([]model.Comment, error)
+}
+
+type listCommentsBiz struct {
+	store      ListCommentsStorage
+	itemStore  ItemStorage
+	shareStore ShareLinkStorage
+	requester  common.Requester
+}
+
+func NewListCommentsBiz(
+	store ListCommentsStorage,
+	itemStore ItemStorage,
+	shareStore ShareLinkStorage,
+	requester common.Requester,
+) *listCommentsBiz {
+	return &listCommentsBiz{store: store, itemStore: itemStore, shareStore: shareStore, requester: requester}
+}
+
+func (biz *listCommentsBiz) ListComments(ctx context.Context, itemId int, paging *common.Paging) ([]model.Comment, error) {
+	if err := findParentItem(ctx, biz.itemStore, biz.shareStore, itemId, biz.requester.GetUserId()); err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		if err == ErrItemNotShared {
+			return nil, common.ErrForbidden(itemmodel.EntityName, err)
+		}
+
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	data, err := biz.store.ListComments(ctx, map[string]interface{}{"item_id": itemId}, paging)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"strings"
+	"time"
+)
+
+const (
+	EntityName = "Comment"
+)
+
+var (
+	ErrContentIsBlank = errors.New("content cannot be blank")
+)
+
+type Comment struct {
+	common.SQLModel
+	ItemId     int         `json:"-" gorm:"column:item_id;index;"`
+	UserId     int         `json:"-" gorm:"column:user_id;"`
+	FakeUserId *common.UID `json:"user_id" gorm:"-"`
+	Content    string      `json:"content" gorm:"column:content;type:text;"`
+}
+
+func (Comment) TableName() string { return "comments" }
+
+func (c *Comment) Mask() {
+	c.SQLModel.Mask(common.DbTypeComment)
+
+	uid := common.NewUID(uint32(c.UserId), common.DbTypeUser, 1)
+	c.FakeUserId = &uid
+}
+
+type CommentCreation struct {
+	Id        int        `json:"-" gorm:"column:id;"`
+	ItemId    int        `json:"-" gorm:"column:item_id;"`
+	UserId    int        `json:"-" gorm:"column:user_id;"`
+	Content   string     `json:"content" gorm:"column:content;"`
+	CreatedAt *time.Time `json:"-" gorm:"column:created_at;"`
+	UpdatedAt *time.Time `json:"-" gorm:"column:updated_at;"`
+}
+
+func (CommentCreation) TableName() string { return Comment{}.TableName() }
+
+func (c *CommentCreation) Validate() error {
+	c.Content = strings.TrimSpace(c.Content)
+
+	if c.Content == "" {
+		return ErrContentIsBlank
+	}
+
+	return nil
+}
+
+func (c *CommentCreation) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	c.CreatedAt = &now
+	c.UpdatedAt = &now
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+)
+
+func (s *sqlStore) ListComments(
+	ctx context.Context,
+	cond map[string]interface{},
+	paging *common.Paging,
+) ([]model.Comment, error) {
+	var result []model.Comment
+
+	db := s.db.WithContext(ctx).Table(model.Comment{}.TableName()).Where(cond)
+
+	if err := db.Count(&paging.Total).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	// Comment hiển thị theo thứ tự cũ trước, mới sau
+	if err := db.Order("id asc").
+		Offset((paging.Page - 1) * paging.Limit).
+		Limit(paging.Limit).Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+	"testing"
+)
+
+// newTestStore tạo store trên sqlite in-memory, một connection để mọi query thấy cùng một DB
+func newTestStore(t *testing.T) *sqlStore {
+	t.Helper()
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&model.Comment{}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewSQLStorage(db)
+}
+
+func TestListComments(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, data := range []model.CommentCreation{
+		{ItemId: 1, UserId: 1, Content: "first"},
+		{ItemId: 2, UserId: 1, Content: "other item"},
+		{ItemId: 1, UserId: 2, Content: "second"},
+		{ItemId: 1, UserId: 1, Content: "third"},
+	} {
+		if err := store.CreateComment(ctx, &data); err != nil {
+			t.Fatal(err)
+		}
+
+		if data.Id == 0 || data.CreatedAt == nil {
+			t.Fatalf("created comment = %+v, want id and created_at", data)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		itemId    int
+		page      int
+		limit     int
+		want      []string
+		wantTotal int64
+	}{
+		{"oldest first", 1, 1, 10, []string{"first", "second", "third"}, 3},
+		{"second page", 1, 2, 2, []string{"third"}, 3},
+		{"only the item's comments", 2, 1, 10, []string{"other item"}, 1},
+		{"no comments", 3, 1, 10, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{Page: tt.page, Limit: tt.limit}
+			_ = paging.Process()
+
+			result, err := store.ListComments(ctx, map[string]interface{}{"item_id": tt.itemId}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, len(result))
+
+			for i := range result {
+				got[i] = result[i].Content
+			}
+
+			if len(got) != len(tt.want) || paging.Total != tt.wantTotal {
+				t.Fatalf("comments = %v total %d, want %v total %d", got, paging.Total, tt.want, tt.wantTotal)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("comments = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
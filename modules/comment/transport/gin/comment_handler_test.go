@@ -0,0 +1,120 @@
+package gincomment
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/model"
+	itemmodel "social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+	"strings"
+	"testing"
+)
+
+func TestCommentRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&itemmodel.TodoItem{}, &sharelinkmodel.ShareLink{}, &model.Comment{}); err != nil {
+		t.Fatal(err)
+	}
+
+	doing, deleted := itemmodel.ItemStatusDoing, itemmodel.ItemStatusDeleted
+	own := itemmodel.TodoItem{Title: "own", UserId: 1, Status: &doing}
+	removed := itemmodel.TodoItem{Title: "removed", UserId: 1, Status: &deleted}
+	private := itemmodel.TodoItem{Title: "private", UserId: 2, Status: &doing}
+	shared := itemmodel.TodoItem{Title: "shared", UserId: 3, Status: &doing}
+
+	for _, item := range []*itemmodel.TodoItem{&own, &removed, &private, &shared} {
+		if err := db.Create(item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := db.Create(&sharelinkmodel.ShareLink{UserId: 3, Token: "token"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.POST("/items/:id/comments", CreateComment(db))
+	r.GET("/items/:id/comments", ListComments(db))
+
+	commentsPath := func(id int) string {
+		return "/items/" + common.NewUID(uint32(id), common.DbTypeItem, 1).String() + "/comments"
+	}
+
+	// Các bước chạy theo thứ tự, wantList là nội dung comment của item sau bước đó (nil là không kiểm tra list)
+	steps := []struct {
+		name       string
+		itemId     int
+		body       string
+		wantStatus int
+		wantList   []string
+	}{
+		{"comment on own item", own.Id, `{"content":" buy the 2L one "}`, http.StatusOK, []string{"buy the 2L one"}},
+		{"second comment", own.Id, `{"content":"or two 1L"}`, http.StatusOK, []string{"buy the 2L one", "or two 1L"}},
+		{"blank content", own.Id, `{"content":"   "}`, http.StatusBadRequest, []string{"buy the 2L one", "or two 1L"}},
+		{"comment on a shared item", shared.Id, `{"content":"nice"}`, http.StatusOK, []string{"nice"}},
+		{"other user's private item", private.Id, `{"content":"hi"}`, http.StatusForbidden, nil},
+		{"deleted item", removed.Id, `{"content":"hi"}`, http.StatusNotFound, nil},
+		{"item does not exist", shared.Id + 100, `{"content":"hi"}`, http.StatusNotFound, nil},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, commentsPath(step.itemId), strings.NewReader(step.body))
+		req.Header.Set("Content-Type", common.MIMEJSON)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, commentsPath(step.itemId), nil))
+
+		if step.wantList == nil {
+			if w.Code != step.wantStatus {
+				t.Errorf("%s: list status = %d, want %d", step.name, w.Code, step.wantStatus)
+			}
+
+			continue
+		}
+
+		var resp struct {
+			Data []struct {
+				Id      string `json:"id"`
+				UserId  string `json:"user_id"`
+				Content string `json:"content"`
+			} `json:"data"`
+		}
+
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || len(resp.Data) != len(step.wantList) {
+			t.Fatalf("%s: list status = %d, body = %s, want %v", step.name, w.Code, w.Body, step.wantList)
+		}
+
+		for i, comment := range resp.Data {
+			if comment.Content != step.wantList[i] || comment.Id == "" || comment.UserId != common.NewUID(1, common.DbTypeUser, 1).String() {
+				t.Errorf("%s: comment %d = %+v, want %q by user 1 with masked ids", step.name, i, comment, step.wantList[i])
+			}
+		}
+	}
+}
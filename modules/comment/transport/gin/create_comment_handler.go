@@ -0,0 +1,46 @@
+package gincomment
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/biz"
+	"social-todo-list/modules/comment/model"
+	"social-todo-list/modules/comment/storage"
+	itemstorage "social-todo-list/modules/item/storage"
+	sharelinkstorage "social-todo-list/modules/sharelink/storage"
+)
+
+func CreateComment(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var data model.CommentCreation
+
+		if err := c.ShouldBind(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		shareStore := sharelinkstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewCreateCommentBiz(store, itemStore, shareStore, requester)
+
+		if err := business.CreateComment(c.Request.Context(), itemId, &data); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(common.NewUID(uint32(data.Id), common.DbTypeComment, 1)))
+	}
+}
@@ -0,0 +1,56 @@
+package gincomment
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/comment/biz"
+	"social-todo-list/modules/comment/storage"
+	itemstorage "social-todo-list/modules/item/storage"
+	sharelinkstorage "social-todo-list/modules/sharelink/storage"
+)
+
+func ListComments(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var paging common.Paging
+
+		if err := c.ShouldBind(&paging); err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		if err := paging.Process(); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		shareStore := sharelinkstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListCommentsBiz(store, itemStore, shareStore, requester)
+
+		result, err := business.ListComments(c.Request.Context(), itemId, &paging)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil))
+	}
+}
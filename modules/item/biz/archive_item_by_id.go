@@ -0,0 +1,61 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type ArchiveItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error
+}
+
+type archiveItemBiz struct {
+	store     ArchiveItemStorage
+	requester common.Requester
+}
+
+func NewArchiveItemBiz(store ArchiveItemStorage, requester common.Requester) *archiveItemBiz {
+	return &archiveItemBiz{store: store, requester: requester}
+}
+
+// SetArchived ẩn (archived = true) hoặc hiện lại item trong list mặc định, status được giữ nguyên.
+// Item đã ở đúng trạng thái thì không làm gì
+func (biz *archiveItemBiz) SetArchived(ctx context.Context, id int, archived bool) error {
+	cond := map[string]interface{}{"id": id, "user_id": biz.requester.GetUserId()}
+
+	data, err := biz.store.GetItem(ctx, cond)
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
+		return common.ErrEntityDeleted(model.EntityName, model.ErrItemDeleted)
+	}
+
+	if data.Archived == archived {
+		return nil
+	}
+
+	nextVersion := data.Version + 1
+	updatedBy := biz.requester.GetUserId()
+	cond["version"] = data.Version
+
+	dataUpdate := &model.TodoItemUpdate{Archived: &archived, Version: &nextVersion, UpdatedBy: &updatedBy}
+
+	if err := biz.store.UpdateItem(ctx, cond, dataUpdate); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityConflict(model.EntityName, model.ErrVersionConflict)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestSetArchived(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     model.ItemStatus
+		archived   bool
+		archive    bool
+		wantStatus int
+		wantWrites int
+	}{
+		{"archive doing item", model.ItemStatusDoing, false, true, 0, 1},
+		{"archive done item", model.ItemStatusDone, false, true, 0, 1},
+		{"unarchive", model.ItemStatusDone, true, false, 0, 1},
+		{"already archived", model.ItemStatusDoing, true, true, 0, 0},
+		{"deleted item", model.ItemStatusDeleted, false, true, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, tt.status)
+			item.Archived = tt.archived
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}}
+
+			err := NewArchiveItemBiz(store, common.NewRequester(1)).SetArchived(context.Background(), 1, tt.archive)
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantStatus != 0 && common.ToAppError(err).StatusCode != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if len(store.writes) != tt.wantWrites {
+				t.Fatalf("writes = %d, want %d", len(store.writes), tt.wantWrites)
+			}
+
+			if tt.wantWrites == 1 && (*store.writes[0].Archived != tt.archive || store.writes[0].Status != nil) {
+				t.Errorf("write = %+v, want archived %v with status untouched", store.writes[0], tt.archive)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		store := &mockUpdateItemStorage{items: map[int]model.TodoItem{}}
+
+		err := NewArchiveItemBiz(store, common.NewRequester(1)).SetArchived(context.Background(), 1, true)
+
+		if common.ToAppError(err).StatusCode != http.StatusNotFound {
+			t.Errorf("err = %v, want 404", err)
+		}
+	})
+}
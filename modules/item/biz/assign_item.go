@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type AssignItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error
+}
+
+type assignItemBiz struct {
+	store     AssignItemStorage
+	userStore UserStorage
+	requester common.Requester
+}
+
+func NewAssignItemBiz(
+	store AssignItemStorage,
+	userStore UserStorage,
+	requester common.Requester,
+) *assignItemBiz {
+	return &assignItemBiz{store: store, userStore: userStore, requester: requester}
+}
+
+// AssignItem chuyển item của requester sang cho user userId, sau đó item chỉ còn trong list của user mới.
+// Giao cho chính mình thì không làm gì
+func (biz *assignItemBiz) AssignItem(ctx context.Context, id, userId int) error {
+	cond := map[string]interface{}{"id": id, "user_id": biz.requester.GetUserId()}
+
+	data, err := biz.store.GetItem(ctx, cond)
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
+		return common.ErrEntityDeleted(model.EntityName, model.ErrItemDeleted)
+	}
+
+	if userId == data.UserId {
+		return nil
+	}
+
+	users, err := biz.userStore.GetUsers(ctx, []int{userId})
+
+	if err != nil {
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if len(users) == 0 {
+		return common.ErrInvalidRequest(model.ErrAssigneeNotFound)
+	}
+
+	nextVersion := data.Version + 1
+	updatedBy := biz.requester.GetUserId()
+	cond["version"] = data.Version
+
+	dataUpdate := &model.TodoItemUpdate{UserId: &userId, Version: &nextVersion, UpdatedBy: &updatedBy}
+
+	if err := biz.store.UpdateItem(ctx, cond, dataUpdate); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityConflict(model.EntityName, model.ErrVersionConflict)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockAssignItemStorage chỉ thấy item khi user_id trong cond trùng owner, giống query của storage thật
+type mockAssignItemStorage struct {
+	*mockUpdateItemStorage
+}
+
+func (s mockAssignItemStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error) {
+	item, err := s.mockUpdateItemStorage.GetItem(ctx, cond)
+
+	if err != nil || item.UserId != cond["user_id"] {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
+
+func TestAssignItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		requester  int
+		itemStatus model.ItemStatus
+		assignee   int
+		wantStatus int
+		wantKey    string
+		wantWrites int
+	}{
+		{"owner assigns to another user", 1, model.ItemStatusDoing, 2, 0, "", 1},
+		{"assign to self is a no-op", 1, model.ItemStatusDoing, 1, 0, "", 0},
+		{"not the owner", 3, model.ItemStatusDoing, 2, http.StatusNotFound, "ErrItemNotFound", 0},
+		{"assignee does not exist", 1, model.ItemStatusDoing, 99, http.StatusBadRequest, "ErrInvalidRequest", 0},
+		{"deleted item", 1, model.ItemStatusDeleted, 2, http.StatusBadRequest, "ErrItemDeleted", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, tt.itemStatus)
+			item.Version = 4
+			store := mockAssignItemStorage{&mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}}}
+			users := &mockUserStorage{missing: map[int]bool{99: true}}
+
+			err := NewAssignItemBiz(store, users, common.NewRequester(tt.requester)).AssignItem(context.Background(), 1, tt.assignee)
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantStatus != 0 {
+				appErr := common.ToAppError(err)
+
+				if appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey {
+					t.Fatalf("error = %d %s, want %d %s", appErr.StatusCode, appErr.Key, tt.wantStatus, tt.wantKey)
+				}
+			}
+
+			if len(store.writes) != tt.wantWrites {
+				t.Fatalf("writes = %d, want %d", len(store.writes), tt.wantWrites)
+			}
+
+			if tt.wantWrites == 0 {
+				return
+			}
+
+			write, cond := store.writes[0], store.conds[0]
+
+			if *write.UserId != tt.assignee || *write.UpdatedBy != tt.requester || *write.Version != 5 {
+				t.Errorf("write = user %d updated by %d version %d, want %d %d 5", *write.UserId, *write.UpdatedBy, *write.Version, tt.assignee, tt.requester)
+			}
+
+			if cond["user_id"] != tt.requester || cond["version"] != 4 {
+				t.Errorf("cond = %v, want the requester's item at version 4", cond)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package biz
+
+import (
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// checkItemOwner trả lỗi nếu item (đã đọc theo id) không thuộc requester.
+// hideForbidden = true trả 404 như item không tồn tại để không lộ item của user khác, false thì trả 403
+func checkItemOwner(item *model.TodoItem, requester common.Requester, hideForbidden bool) error {
+	if item.UserId == requester.GetUserId() {
+		return nil
+	}
+
+	if hideForbidden {
+		return common.ErrEntityNotFound(model.EntityName, model.ErrNotItemOwner)
+	}
+
+	return common.ErrForbidden(model.EntityName, model.ErrNotItemOwner)
+}
@@ -0,0 +1,85 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockDeleteItemStorage đọc item qua mockUpdateItemStorage và đếm số lần xoá
+type mockDeleteItemStorage struct {
+	*mockUpdateItemStorage
+	deleted int
+}
+
+func (s *mockDeleteItemStorage) DeleteItem(ctx context.Context, cond map[string]interface{}, updatedBy int) error {
+	s.deleted++
+
+	return nil
+}
+
+func TestHideForbiddenAsNotFound(t *testing.T) {
+	title := "buy bread"
+
+	// item 1 của requester, item 2 của user khác
+	items := map[int]model.TodoItem{
+		1: newTestItem(1, 1, model.ItemStatusDoing),
+		2: newTestItem(2, 2, model.ItemStatusDoing),
+	}
+
+	actions := map[string]func(hideForbidden bool, id int) (int, error){
+		"get": func(hideForbidden bool, id int) (int, error) {
+			business := NewGetItemBiz(&mockGetItemStorage{items: items}, mockEnrichStorage{}, mockEnrichStorage{}, hideForbidden, common.NewRequester(1))
+			_, err := business.GetItemById(context.Background(), id)
+			return 0, err
+		},
+		"update": func(hideForbidden bool, id int) (int, error) {
+			store := &mockUpdateItemStorage{items: items}
+			err := NewUpdateItemBiz(store, hideForbidden, model.LengthLimits{}, nil, common.NewRequester(1)).
+				UpdateItemById(context.Background(), id, &model.TodoItemUpdate{Title: &title})
+			return len(store.writes), err
+		},
+		"delete": func(hideForbidden bool, id int) (int, error) {
+			store := &mockDeleteItemStorage{mockUpdateItemStorage: &mockUpdateItemStorage{items: items}}
+			err := NewDeleteItemBiz(store, hideForbidden, common.NewRequester(1)).DeleteItemById(context.Background(), id)
+			return store.deleted, err
+		},
+	}
+
+	tests := []struct {
+		name          string
+		hideForbidden bool
+		id            int
+		wantStatus    int
+		wantKey       string
+	}{
+		{"own item", true, 1, 0, ""},
+		{"other user's item hidden", true, 2, http.StatusNotFound, "ErrItemNotFound"},
+		{"other user's item forbidden", false, 2, http.StatusForbidden, "ErrNoPermissionItem"},
+		{"missing item with flag off", false, 3, http.StatusNotFound, "ErrItemNotFound"},
+	}
+
+	for action, run := range actions {
+		for _, tt := range tests {
+			t.Run(action+"/"+tt.name, func(t *testing.T) {
+				writes, err := run(tt.hideForbidden, tt.id)
+
+				if tt.wantStatus == 0 {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+
+					return
+				}
+
+				appErr := common.ToAppError(err)
+
+				if appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey || writes != 0 {
+					t.Errorf("error = %d %s, writes = %d, want %d %s and no write", appErr.StatusCode, appErr.Key, writes, tt.wantStatus, tt.wantKey)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type CloneItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+}
+
+// ItemCreator tạo item mới, bản sao đi qua đúng các bước validate và hạn mức như tạo item bình thường
+type ItemCreator interface {
+	CreateNewItem(ctx context.Context, idempotencyKey string, data *model.TodoItemCreation) error
+}
+
+type cloneItemBiz struct {
+	store     CloneItemStorage
+	creator   ItemCreator
+	requester common.Requester
+}
+
+func NewCloneItemBiz(store CloneItemStorage, creator ItemCreator, requester common.Requester) *cloneItemBiz {
+	return &cloneItemBiz{store: store, creator: creator, requester: requester}
+}
+
+// CloneItem tạo bản sao của item id thuộc requester và trả về id của bản sao, item gốc giữ nguyên
+func (biz *cloneItemBiz) CloneItem(ctx context.Context, id int, titleSuffix string) (int, error) {
+	data, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id, "user_id": biz.requester.GetUserId()})
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return 0, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return 0, common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
+		return 0, common.ErrEntityNotFound(model.EntityName, model.ErrItemDeleted)
+	}
+
+	clone := model.Clone(data, titleSuffix)
+
+	if err := biz.creator.CreateNewItem(ctx, "", clone); err != nil {
+		return 0, err
+	}
+
+	return clone.Id, nil
+}
@@ -0,0 +1,78 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockItemCreator ghi lại item được tạo và gán id 100, err khác nil thì tạo lỗi
+type mockItemCreator struct {
+	created []*model.TodoItemCreation
+	err     error
+}
+
+func (c *mockItemCreator) CreateNewItem(ctx context.Context, idempotencyKey string, data *model.TodoItemCreation) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	data.Id = 100
+	c.created = append(c.created, data)
+
+	return nil
+}
+
+func TestCloneItem(t *testing.T) {
+	quotaErr := common.ErrQuotaExceeded(model.ErrDailyQuotaExceeded)
+
+	tests := []struct {
+		name       string
+		requester  int
+		status     model.ItemStatus
+		createErr  error
+		wantStatus int
+		wantKey    string
+	}{
+		{"owner clones", 1, model.ItemStatusDone, nil, 0, ""},
+		{"not the owner", 2, model.ItemStatusDoing, nil, http.StatusNotFound, "ErrItemNotFound"},
+		{"deleted item", 1, model.ItemStatusDeleted, nil, http.StatusNotFound, "ErrItemNotFound"},
+		{"create error is returned as is", 1, model.ItemStatusDoing, quotaErr, http.StatusTooManyRequests, quotaErr.Key},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, tt.status)
+			item.Tags = model.ItemTags{"home"}
+			store := mockAssignItemStorage{&mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}}}
+			creator := &mockItemCreator{err: tt.createErr}
+
+			id, err := NewCloneItemBiz(store, creator, common.NewRequester(tt.requester)).CloneItem(context.Background(), 1, model.DefaultCloneTitleSuffix)
+
+			if tt.wantStatus != 0 {
+				var appErr *common.AppError
+
+				if !errors.As(err, &appErr) || appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey {
+					t.Fatalf("err = %v, want %d %s", err, tt.wantStatus, tt.wantKey)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if id != 100 || len(creator.created) != 1 {
+				t.Fatalf("id = %d, created = %d, want the new item's id", id, len(creator.created))
+			}
+
+			if clone := creator.created[0]; clone.Title != "buy milk (copy)" || *clone.Status != model.ItemStatusDoing || clone.Tags[0] != "home" {
+				t.Errorf("clone = %+v", clone)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type CreateItemsStorage interface {
+	CreateItem(ctx context.Context, data *model.TodoItemCreation) error
+	CreateItems(ctx context.Context, data []*model.TodoItemCreation) error
+}
+
+type createItemsBiz struct {
+	store         CreateItemsStorage
+	dailyQuota    int
+	defaultStatus model.ItemStatus
+	lengthLimits  model.LengthLimits
+	sanitizer     *common.HTMLSanitizer
+	requester     common.Requester
+}
+
+func NewCreateItemsBiz(
+	store CreateItemsStorage,
+	dailyQuota int,
+	defaultStatus model.ItemStatus,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	requester common.Requester,
+) *createItemsBiz {
+	return &createItemsBiz{
+		store:         store,
+		dailyQuota:    dailyQuota,
+		defaultStatus: defaultStatus,
+		lengthLimits:  lengthLimits,
+		sanitizer:     sanitizer,
+		requester:     requester,
+	}
+}
+
+// CreateItems chỉ insert khi toàn bộ item đều hợp lệ, lỗi sẽ chỉ ra index của item sai.
+// dailyQuota > 0 thì cả batch phải nằm trong hạn mức còn lại của ngày, vượt quá trả về 429
+func (biz *createItemsBiz) CreateItems(ctx context.Context, data []*model.TodoItemCreation) error {
+	if len(data) == 0 {
+		return common.ErrInvalidRequest(model.ErrItemsIsEmpty)
+	}
+
+	for i := range data {
+		if data[i] == nil {
+			return common.ErrInvalidRequest(fmt.Errorf("item at index %d: %w", i, model.ErrItemIsNull))
+		}
+
+		data[i].ApplyDefaultStatus(biz.defaultStatus)
+		data[i].Sanitize(biz.sanitizer.Sanitize)
+
+		if err := data[i].Validate(biz.lengthLimits); err != nil {
+			return common.ErrInvalidRequest(itemAtIndexError(i, err))
+		}
+
+		data[i].UserId = biz.requester.GetUserId()
+		data[i].DailyQuota = biz.dailyQuota
+	}
+
+	if err := biz.store.CreateItems(ctx, data); err != nil {
+		return errCannotCreateItem(err)
+	}
+
+	return nil
+}
+
+// itemAtIndexError thêm index vào lỗi validate, field trong details đổi thành "[i].field" để client biết item nào sai
+func itemAtIndexError(i int, err error) error {
+	var validationErr *common.ValidationError
+
+	if errors.As(err, &validationErr) {
+		err = validationErr.WithPrefix(fmt.Sprintf("[%d].", i))
+	}
+
+	return fmt.Errorf("item at index %d: %w", i, err)
+}
+
+// CreateItemsBestEffort insert từng item hợp lệ trong transaction riêng, item lỗi (validate hoặc DB)
+// không làm ảnh hưởng các item khác. Kết quả có đúng một phần tử cho mỗi item, theo thứ tự của data
+func (biz *createItemsBiz) CreateItemsBestEffort(ctx context.Context, data []*model.TodoItemCreation) ([]model.BatchItemResult, error) {
+	if len(data) == 0 {
+		return nil, common.ErrInvalidRequest(model.ErrItemsIsEmpty)
+	}
+
+	results := make([]model.BatchItemResult, len(data))
+
+	for i := range data {
+		results[i].Index = i
+
+		if data[i] == nil {
+			results[i].Error = model.ErrItemIsNull.Error()
+			continue
+		}
+
+		data[i].ApplyDefaultStatus(biz.defaultStatus)
+		data[i].Sanitize(biz.sanitizer.Sanitize)
+
+		if err := data[i].Validate(biz.lengthLimits); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		data[i].UserId = biz.requester.GetUserId()
+		data[i].DailyQuota = biz.dailyQuota
+
+		// Không trả lỗi DB gốc ra ngoài, chỉ báo item này không tạo được
+		if err := biz.store.CreateItem(ctx, data[i]); err != nil {
+			results[i].Error = errCannotCreateItem(err).Message
+			continue
+		}
+
+		uid := common.NewUID(uint32(data[i].Id), common.DbTypeItem, 1)
+		results[i].Id = &uid
+	}
+
+	return results, nil
+}
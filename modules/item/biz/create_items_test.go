@@ -0,0 +1,221 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockCreateItemsStorage đếm số lần gọi, lastBatch là batch cuối cùng được insert (id đánh từ 1)
+type mockCreateItemsStorage struct {
+	batches   int
+	singles   int
+	lastBatch []*model.TodoItemCreation
+}
+
+func (s *mockCreateItemsStorage) CreateItem(ctx context.Context, data *model.TodoItemCreation) error {
+	s.singles++
+	data.Id = s.singles
+
+	return nil
+}
+
+func (s *mockCreateItemsStorage) CreateItems(ctx context.Context, data []*model.TodoItemCreation) error {
+	s.batches++
+	s.lastBatch = data
+
+	for i := range data {
+		data[i].Id = i + 1
+	}
+
+	return nil
+}
+
+func TestCreateItemsNullElement(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []*model.TodoItemCreation
+		wantErr     error
+		wantBatches int
+	}{
+		{"only null", []*model.TodoItemCreation{nil}, model.ErrItemIsNull, 0},
+		{"null after valid", []*model.TodoItemCreation{{Title: "a"}, nil}, model.ErrItemIsNull, 0},
+		{"all valid", []*model.TodoItemCreation{{Title: "a"}, {Title: "b"}}, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateItemsStorage{}
+			business := NewCreateItemsBiz(store, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+			err := business.CreateItems(context.Background(), tt.data)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && common.ToAppError(err).StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", common.ToAppError(err).StatusCode)
+			}
+
+			if store.batches != tt.wantBatches {
+				t.Errorf("CreateItems called %d times, want %d", store.batches, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func TestCreateItemsBestEffortNullElement(t *testing.T) {
+	store := &mockCreateItemsStorage{}
+	business := NewCreateItemsBiz(store, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+	results, err := business.CreateItemsBestEffort(context.Background(), []*model.TodoItemCreation{nil, {Title: "a"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results[0].Error != model.ErrItemIsNull.Error() || results[0].Id != nil {
+		t.Errorf("null item result = %+v", results[0])
+	}
+
+	if results[1].Error != "" || results[1].Id == nil {
+		t.Errorf("valid item result = %+v", results[1])
+	}
+}
+
+func TestCreateItemsBestEffort(t *testing.T) {
+	tests := []struct {
+		name       string
+		titles     []string
+		wantFailed []bool
+	}{
+		{"all valid", []string{"a", "b", "c"}, []bool{false, false, false}},
+		{"blank title in the middle", []string{"a", "  ", "c"}, []bool{false, true, false}},
+		{"all invalid", []string{"", " "}, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateItemsStorage{}
+			business := NewCreateItemsBiz(store, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+			data := make([]*model.TodoItemCreation, len(tt.titles))
+
+			for i, title := range tt.titles {
+				data[i] = &model.TodoItemCreation{Title: title}
+			}
+
+			results, err := business.CreateItemsBestEffort(context.Background(), data)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(results) != len(tt.titles) {
+				t.Fatalf("results = %d, want one per item", len(results))
+			}
+
+			created := 0
+
+			for i, result := range results {
+				if result.Index != i || tt.wantFailed[i] != (result.Id == nil) || tt.wantFailed[i] != (result.Error != "") {
+					t.Errorf("result %d = %+v, want failed = %v", i, result, tt.wantFailed[i])
+				}
+
+				if !tt.wantFailed[i] {
+					created++
+				}
+			}
+
+			if store.singles != created || store.batches != 0 {
+				t.Errorf("inserted %d items in %d batches, want %d one by one", store.singles, store.batches, created)
+			}
+		})
+	}
+}
+
+// mockQuotaStorage giống storage thật: item nào vượt DailyQuota thì trả model.ErrDailyQuotaExceeded
+type mockQuotaStorage struct {
+	created int
+}
+
+func (s *mockQuotaStorage) CreateItem(ctx context.Context, data *model.TodoItemCreation) error {
+	return s.CreateItems(ctx, []*model.TodoItemCreation{data})
+}
+
+func (s *mockQuotaStorage) CreateItems(ctx context.Context, data []*model.TodoItemCreation) error {
+	for _, item := range data {
+		if item.DailyQuota <= 0 || s.created+len(data) > item.DailyQuota {
+			return common.ErrDB(model.ErrDailyQuotaExceeded)
+		}
+	}
+
+	s.created += len(data)
+
+	return nil
+}
+
+func TestCreatePathsApplyDailyQuota(t *testing.T) {
+	ctx := context.Background()
+	requester := common.NewRequester(1)
+
+	items := func(n int) []*model.TodoItemCreation {
+		data := make([]*model.TodoItemCreation, n)
+
+		for i := range data {
+			data[i] = &model.TodoItemCreation{Title: "a"}
+		}
+
+		return data
+	}
+
+	tests := []struct {
+		name   string
+		create func(store *mockQuotaStorage) error
+	}{
+		{"batch", func(store *mockQuotaStorage) error {
+			return NewCreateItemsBiz(store, 2, model.ItemStatusDoing, model.LengthLimits{}, nil, requester).CreateItems(ctx, items(3))
+		}},
+		{"import", func(store *mockQuotaStorage) error {
+			_, err := NewImportItemsBiz(store, 2, model.ItemStatusDoing, model.LengthLimits{}, nil, requester).ImportItems(ctx, items(3))
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.create(&mockQuotaStorage{})
+
+			if err == nil {
+				t.Fatal("expected quota error")
+			}
+
+			if status := common.ToAppError(err).StatusCode; status != http.StatusTooManyRequests {
+				t.Fatalf("status = %d, want 429 (%v)", status, err)
+			}
+		})
+	}
+}
+
+func TestCreateItemsBestEffortDailyQuota(t *testing.T) {
+	business := NewCreateItemsBiz(&mockQuotaStorage{}, 2, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+	results, err := business.CreateItemsBestEffort(context.Background(), []*model.TodoItemCreation{
+		{Title: "a"}, {Title: "b"}, {Title: "c"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantQuotaErr := common.ErrQuotaExceeded(model.ErrDailyQuotaExceeded).Message
+
+	for i, result := range results {
+		if overQuota := i >= 2; overQuota != (result.Id == nil) || overQuota && result.Error != wantQuotaErr {
+			t.Errorf("result %d = %+v", i, result)
+		}
+	}
+}
@@ -2,32 +2,135 @@ package biz
 
 import (
 	"context"
+	"errors"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
-	"strings"
+	"time"
 )
 
 type CreateItemStorage interface {
 	CreateItem(ctx context.Context, data *model.TodoItemCreation) error
+	FindIdempotencyKey(ctx context.Context, userId int, key string) (*model.IdempotencyKey, error)
+	CreateItemWithIdempotencyKey(ctx context.Context, data *model.TodoItemCreation, key *model.IdempotencyKey) error
+	HasActiveItemWithTitle(ctx context.Context, userId int, title string) (bool, error)
 }
 
 type createItemBiz struct {
-	store CreateItemStorage
+	store          CreateItemStorage
+	idempotencyTTL time.Duration
+	dailyQuota     int
+	defaultStatus  model.ItemStatus
+	lengthLimits   model.LengthLimits
+	sanitizer      *common.HTMLSanitizer
+	requester      common.Requester
 }
 
-func NewCreateItemBiz(store CreateItemStorage) *createItemBiz {
-	return &createItemBiz{store: store}
+func NewCreateItemBiz(
+	store CreateItemStorage,
+	idempotencyTTL time.Duration,
+	dailyQuota int,
+	defaultStatus model.ItemStatus,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	requester common.Requester,
+) *createItemBiz {
+	return &createItemBiz{
+		store:          store,
+		idempotencyTTL: idempotencyTTL,
+		dailyQuota:     dailyQuota,
+		defaultStatus:  defaultStatus,
+		lengthLimits:   lengthLimits,
+		sanitizer:      sanitizer,
+		requester:      requester,
+	}
 }
 
-func (biz *createItemBiz) CreateNewItem(ctx context.Context, data *model.TodoItemCreation) error {
-	title := strings.TrimSpace(data.Title)
+// CreateNewItem với idempotencyKey khác rỗng: gửi lại cùng key trong idempotencyTTL
+// thì không tạo item mới mà gán data.Id là id của item đã tạo lần trước.
+// dailyQuota > 0 thì requester chỉ được tạo tối đa dailyQuota item mỗi ngày (UTC), vượt quá trả về 429
+func (biz *createItemBiz) CreateNewItem(ctx context.Context, idempotencyKey string, data *model.TodoItemCreation) error {
+	data.ApplyDefaultStatus(biz.defaultStatus)
+	data.Sanitize(biz.sanitizer.Sanitize)
+
+	if err := data.Validate(biz.lengthLimits); err != nil {
+		return common.ErrInvalidRequest(err)
+	}
 
-	if title == "" {
-		return model.ErrTitleIsBlank
+	if err := model.ValidateIdempotencyKey(idempotencyKey); err != nil {
+		return common.ErrInvalidRequest(err)
 	}
 
-	if err := biz.store.CreateItem(ctx, data); err != nil {
-		return err
+	data.UserId = biz.requester.GetUserId()
+	data.DailyQuota = biz.dailyQuota
+	now := time.Now().UTC()
+
+	// Retry cùng key thì trả lại item cũ, kiểm tra trước duplicate title vì item đó chắc chắn trùng title
+	if idempotencyKey != "" {
+		record, err := biz.store.FindIdempotencyKey(ctx, data.UserId, idempotencyKey)
+
+		if err != nil && err != common.RecordNotFound {
+			return common.ErrCannotCreateEntity(model.EntityName, err)
+		}
+
+		if record != nil && record.ExpiresAt.After(now) {
+			data.Id = record.ItemId
+			return nil
+		}
+	}
+
+	if !data.AllowDuplicate {
+		existed, err := biz.store.HasActiveItemWithTitle(ctx, data.UserId, data.Title)
+
+		if err != nil {
+			return common.ErrCannotCreateEntity(model.EntityName, err)
+		}
+
+		if existed {
+			return common.ErrEntityExisted(model.EntityName, model.ErrTitleDuplicated)
+		}
+	}
+
+	if idempotencyKey == "" {
+		if err := biz.store.CreateItem(ctx, data); err != nil {
+			return errCannotCreateItem(err)
+		}
+
+		return nil
+	}
+
+	key := model.IdempotencyKey{
+		UserId:    data.UserId,
+		Key:       idempotencyKey,
+		ExpiresAt: now.Add(biz.idempotencyTTL),
+	}
+
+	if err := biz.store.CreateItemWithIdempotencyKey(ctx, data, &key); err != nil {
+		// Request khác cùng key vừa commit trước: trả lại id item của request đó thay vì 409
+		if common.IsDuplicateKeyError(err) {
+			record, findErr := biz.store.FindIdempotencyKey(ctx, data.UserId, idempotencyKey)
+
+			if findErr == nil && record.ExpiresAt.After(now) {
+				data.Id = record.ItemId
+				return nil
+			}
+		}
+
+		return errCannotCreateItem(err)
 	}
 
 	return nil
 }
+
+// errCannotCreateItem trả 409 khi insert vi phạm unique index, 429 khi vượt hạn mức trong ngày,
+// các lỗi khác giữ nguyên như ErrCannotCreateEntity
+func errCannotCreateItem(err error) *common.AppError {
+	if errors.Is(err, model.ErrDailyQuotaExceeded) {
+		return common.ErrQuotaExceeded(model.ErrDailyQuotaExceeded)
+	}
+
+	if common.IsDuplicateKeyError(err) {
+		return common.ErrEntityExisted(model.EntityName, err)
+	}
+
+	return common.ErrCannotCreateEntity(model.EntityName, err)
+}
@@ -0,0 +1,241 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// mockCreateStorage ghi lại các lần gọi, err là lỗi trả về cho CreateItem, duplicate là kết quả của HasActiveItemWithTitle
+type mockCreateStorage struct {
+	err       error
+	duplicate bool
+	created   []*model.TodoItemCreation
+}
+
+func (s *mockCreateStorage) CreateItem(ctx context.Context, data *model.TodoItemCreation) error {
+	s.created = append(s.created, data)
+
+	if s.err != nil {
+		return s.err
+	}
+
+	data.Id = len(s.created)
+
+	return nil
+}
+
+func (s *mockCreateStorage) FindIdempotencyKey(ctx context.Context, userId int, key string) (*model.IdempotencyKey, error) {
+	return nil, common.RecordNotFound
+}
+
+func (s *mockCreateStorage) CreateItemWithIdempotencyKey(ctx context.Context, data *model.TodoItemCreation, key *model.IdempotencyKey) error {
+	return s.CreateItem(ctx, data)
+}
+
+func (s *mockCreateStorage) HasActiveItemWithTitle(ctx context.Context, userId int, title string) (bool, error) {
+	return s.duplicate, nil
+}
+
+func TestCreateNewItemStorageError(t *testing.T) {
+	storeErr := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		storeErr   error
+		wantCalls  int
+		wantStatus int
+	}{
+		{"success", nil, 1, 0},
+		{"storage error", storeErr, 1, 400},
+		{"db error keeps status", common.ErrDB(storeErr), 1, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateStorage{err: tt.storeErr}
+			business := NewCreateItemBiz(store, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+			err := business.CreateNewItem(context.Background(), "", &model.TodoItemCreation{Title: "buy milk"})
+
+			if len(store.created) != tt.wantCalls {
+				t.Fatalf("CreateItem called %d times, want %d", len(store.created), tt.wantCalls)
+			}
+
+			if tt.storeErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, storeErr) {
+				t.Fatalf("error %v does not wrap the storage error", err)
+			}
+
+			if appErr := common.ToAppError(err); appErr.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", appErr.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCreateNewItemDuplicateKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		storeErr   error
+		wantStatus int
+		wantKey    string
+	}{
+		{"duplicate key", common.ErrDB(gorm.ErrDuplicatedKey), http.StatusConflict, "ErrItemExisted"},
+		{"generic db error", common.ErrDB(errors.New("disk I/O error")), http.StatusInternalServerError, "ErrCannotCreateItem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := NewCreateItemBiz(&mockCreateStorage{err: tt.storeErr}, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+			err := business.CreateNewItem(context.Background(), "", &model.TodoItemCreation{Title: "buy milk"})
+
+			if appErr := common.ToAppError(err); appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey {
+				t.Errorf("error = %d %s, want %d %s", appErr.StatusCode, appErr.Key, tt.wantStatus, tt.wantKey)
+			}
+		})
+	}
+}
+
+// mockRacingKeyStorage giả lập một request khác cùng Idempotency-Key commit ngay trước khi request này insert key
+type mockRacingKeyStorage struct {
+	mockCreateStorage
+	winner *model.IdempotencyKey
+	stored *model.IdempotencyKey
+}
+
+func (s *mockRacingKeyStorage) FindIdempotencyKey(ctx context.Context, userId int, key string) (*model.IdempotencyKey, error) {
+	if s.stored == nil {
+		return nil, common.RecordNotFound
+	}
+
+	return s.stored, nil
+}
+
+func (s *mockRacingKeyStorage) CreateItemWithIdempotencyKey(ctx context.Context, data *model.TodoItemCreation, key *model.IdempotencyKey) error {
+	s.stored = s.winner
+	return common.ErrDB(gorm.ErrDuplicatedKey)
+}
+
+func TestCreateNewItemConcurrentIdempotencyKey(t *testing.T) {
+	future := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		winner     *model.IdempotencyKey
+		wantId     int
+		wantStatus int
+	}{
+		{"replays the item of the other request", &model.IdempotencyKey{Key: "k", ItemId: 42, ExpiresAt: future}, 42, 0},
+		{"duplicate key without stored record", nil, 0, 409},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockRacingKeyStorage{winner: tt.winner}
+			business := NewCreateItemBiz(store, time.Hour, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+			data := model.TodoItemCreation{Title: "buy milk"}
+
+			err := business.CreateNewItem(context.Background(), "k", &data)
+
+			if tt.wantStatus != 0 {
+				if appErr := common.ToAppError(err); err == nil || appErr.StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if data.Id != tt.wantId {
+				t.Errorf("id = %d, want %d", data.Id, tt.wantId)
+			}
+		})
+	}
+}
+
+func TestCreateNewItemValidatesBeforeStorage(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		wantErr   error
+		wantCalls int
+	}{
+		{"empty title", "", model.ErrTitleIsBlank, 0},
+		{"whitespace-only title", "   ", model.ErrTitleIsBlank, 0},
+		{"valid title", "buy milk", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateStorage{}
+			business := NewCreateItemBiz(store, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+			err := business.CreateNewItem(context.Background(), "", &model.TodoItemCreation{Title: tt.title})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			// Lỗi validation trả 422 kèm lỗi của từng field
+			if err != nil && common.ToAppError(err).StatusCode != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want 422", common.ToAppError(err).StatusCode)
+			}
+
+			if len(store.created) != tt.wantCalls {
+				t.Errorf("CreateItem called %d times, want %d", len(store.created), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCreateNewItemDuplicateTitle(t *testing.T) {
+	tests := []struct {
+		name           string
+		duplicate      bool
+		allowDuplicate bool
+		wantStatus     int
+		wantCreated    int
+	}{
+		{"new title", false, false, 0, 1},
+		{"duplicate rejected", true, false, http.StatusConflict, 0},
+		{"duplicate allowed by flag", true, true, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateStorage{duplicate: tt.duplicate}
+			business := NewCreateItemBiz(store, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(1))
+
+			err := business.CreateNewItem(context.Background(), "", &model.TodoItemCreation{Title: "buy milk", AllowDuplicate: tt.allowDuplicate})
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantStatus != 0 && common.ToAppError(err).StatusCode != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if len(store.created) != tt.wantCreated {
+				t.Errorf("created %d items, want %d", len(store.created), tt.wantCreated)
+			}
+		})
+	}
+}
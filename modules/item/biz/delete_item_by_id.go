@@ -2,20 +2,27 @@ package biz
 
 import (
 	"context"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
 )
 
 type DeleteItemStorage interface {
 	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
-	DeleteItem(ctx context.Context, cond map[string]interface{}) error
+	DeleteItem(ctx context.Context, cond map[string]interface{}, updatedBy int) error
 }
 
 type deleteItemBiz struct {
-	store DeleteItemStorage
+	store         DeleteItemStorage
+	hideForbidden bool
+	requester     common.Requester
 }
 
-func NewDeleteItemBiz(store DeleteItemStorage) *deleteItemBiz {
-	return &deleteItemBiz{store: store}
+func NewDeleteItemBiz(
+	store DeleteItemStorage,
+	hideForbidden bool,
+	requester common.Requester,
+) *deleteItemBiz {
+	return &deleteItemBiz{store: store, hideForbidden: hideForbidden, requester: requester}
 }
 
 func (biz *deleteItemBiz) DeleteItemById(ctx context.Context, id int) error {
@@ -23,15 +30,25 @@ func (biz *deleteItemBiz) DeleteItemById(ctx context.Context, id int) error {
 	data, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id})
 
 	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	if err := checkItemOwner(data, biz.requester, biz.hideForbidden); err != nil {
 		return err
 	}
 
 	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
-		return model.ErrItemDeleted
+		return common.ErrEntityDeleted(model.EntityName, model.ErrItemDeleted)
 	}
 
-	if err := biz.store.DeleteItem(ctx, map[string]interface{}{"id": id}); err != nil {
-		return err
+	userId := biz.requester.GetUserId()
+
+	if err := biz.store.DeleteItem(ctx, map[string]interface{}{"id": id, "user_id": userId}, userId); err != nil {
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
 	}
 
 	return nil
@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type DeleteItemsStorage interface {
+	DeleteItems(ctx context.Context, cond map[string]interface{}, ids []int, updatedBy int) (int64, error)
+}
+
+type deleteItemsBiz struct {
+	store     DeleteItemsStorage
+	requester common.Requester
+}
+
+func NewDeleteItemsBiz(store DeleteItemsStorage, requester common.Requester) *deleteItemsBiz {
+	return &deleteItemsBiz{store: store, requester: requester}
+}
+
+// DeleteItems trả về số item thực sự bị xoá, id không thuộc requester, không tồn tại
+// hoặc đã xoá từ trước sẽ được bỏ qua
+func (biz *deleteItemsBiz) DeleteItems(ctx context.Context, data *model.TodoItemsDelete) (int64, error) {
+	if err := data.Validate(); err != nil {
+		return 0, common.ErrInvalidRequest(err)
+	}
+
+	userId := biz.requester.GetUserId()
+	cond := map[string]interface{}{"user_id": userId}
+
+	deleted, err := biz.store.DeleteItems(ctx, cond, data.Ids, userId)
+
+	if err != nil {
+		return 0, common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	return deleted, nil
+}
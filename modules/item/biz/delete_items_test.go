@@ -0,0 +1,60 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+type mockDeleteItemsStorage struct {
+	calls int
+	cond  map[string]interface{}
+}
+
+func (s *mockDeleteItemsStorage) DeleteItems(ctx context.Context, cond map[string]interface{}, ids []int, updatedBy int) (int64, error) {
+	s.calls++
+	s.cond = cond
+
+	return int64(len(ids)), nil
+}
+
+func TestDeleteItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       common.LocalIds
+		wantErr   error
+		wantCalls int
+	}{
+		{"empty ids", common.LocalIds{}, model.ErrIdsIsEmpty, 0},
+		{"nil ids", nil, model.ErrIdsIsEmpty, 0},
+		{"scoped to requester", common.LocalIds{1, 2}, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockDeleteItemsStorage{}
+			business := NewDeleteItemsBiz(store, common.NewRequester(7))
+
+			_, err := business.DeleteItems(context.Background(), &model.TodoItemsDelete{Ids: tt.ids})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && common.ToAppError(err).StatusCode != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", common.ToAppError(err).StatusCode)
+			}
+
+			if store.calls != tt.wantCalls {
+				t.Errorf("storage called %d times, want %d", store.calls, tt.wantCalls)
+			}
+
+			if store.calls > 0 && store.cond["user_id"] != 7 {
+				t.Errorf("cond = %v, want user_id 7", store.cond)
+			}
+		})
+	}
+}
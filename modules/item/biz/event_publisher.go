@@ -0,0 +1,8 @@
+package biz
+
+import "social-todo-list/common"
+
+// EventPublisher nhận event từ job relay outbox sau khi thay đổi đã commit, ví dụ common.EventBus để đẩy qua websocket
+type EventPublisher interface {
+	Publish(userId int, event common.Event)
+}
@@ -0,0 +1,73 @@
+package biz
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+var exportItemsHeader = []string{"id", "title", "description", "status", "created_at", "updated_at"}
+
+type ExportItemsStorage interface {
+	IterateItems(ctx context.Context, cond map[string]interface{}, fn func(item *model.TodoItem) error) error
+}
+
+type exportItemsBiz struct {
+	store     ExportItemsStorage
+	requester common.Requester
+}
+
+func NewExportItemsBiz(store ExportItemsStorage, requester common.Requester) *exportItemsBiz {
+	return &exportItemsBiz{store: store, requester: requester}
+}
+
+// ExportItems ghi các item chưa xoá của requester ra w dạng CSV, từng dòng một
+func (biz *exportItemsBiz) ExportItems(ctx context.Context, w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(exportItemsHeader); err != nil {
+		return common.ErrInternal(err)
+	}
+
+	cond := map[string]interface{}{"user_id": biz.requester.GetUserId()}
+
+	if err := biz.store.IterateItems(ctx, cond, func(item *model.TodoItem) error {
+		item.Mask()
+
+		status := ""
+
+		if item.Status != nil {
+			status = item.Status.String()
+		}
+
+		return writer.Write([]string{
+			item.FakeId.String(),
+			item.Title,
+			item.Description,
+			status,
+			formatExportTime(item.CreatedAt),
+			formatExportTime(item.UpdatedAt),
+		})
+	}); err != nil {
+		return common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return common.ErrInternal(err)
+	}
+
+	return nil
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
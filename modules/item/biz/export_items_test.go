@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+// mockIterateStorage gọi fn lần lượt với từng item trong items, err khác nil thì trả lỗi sau khi duyệt xong
+type mockIterateStorage struct {
+	items []model.TodoItem
+	err   error
+}
+
+func (s *mockIterateStorage) IterateItems(ctx context.Context, cond map[string]interface{}, fn func(item *model.TodoItem) error) error {
+	for i := range s.items {
+		if err := fn(&s.items[i]); err != nil {
+			return err
+		}
+	}
+
+	return s.err
+}
+
+func TestExportItems(t *testing.T) {
+	item := newTestItem(1, 1, model.ItemStatusDone)
+	item.Title = "milk, eggs"
+	item.Description = "line 1\nline 2"
+	fakeId := common.NewUID(1, common.DbTypeItem, 1).String()
+
+	tests := []struct {
+		name       string
+		store      *mockIterateStorage
+		wantLines  []string
+		wantStatus int
+	}{
+		{"no items", &mockIterateStorage{}, []string{"id,title,description,status,created_at,updated_at"}, 0},
+		{
+			"quoted fields",
+			&mockIterateStorage{items: []model.TodoItem{item}},
+			[]string{
+				"id,title,description,status,created_at,updated_at",
+				fakeId + `,"milk, eggs","line 1`,
+				`line 2",Done,,`,
+			},
+			0,
+		},
+		{"storage error", &mockIterateStorage{err: errors.New("connection reset")}, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := NewExportItemsBiz(tt.store, common.NewRequester(1)).ExportItems(context.Background(), &buf)
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := strings.TrimSuffix(buf.String(), "\n"); got != strings.Join(tt.wantLines, "\n") {
+				t.Errorf("csv =\n%s\nwant\n%s", got, strings.Join(tt.wantLines, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// Số item lặp lại tối đa được xử lý trong một lần quét, còn lại để lần sau
+const recurBatchSize = 100
+
+type GenerateRecurringItemsStorage interface {
+	ListItemsToRecur(ctx context.Context, limit int) ([]model.TodoItem, error)
+	CreateNextOccurrence(ctx context.Context, itemId int, next *model.TodoItemCreation) (bool, error)
+}
+
+type generateRecurringItemsBiz struct {
+	store GenerateRecurringItemsStorage
+}
+
+// NewGenerateRecurringItemsBiz dùng cho job chạy nền nên không có requester
+func NewGenerateRecurringItemsBiz(store GenerateRecurringItemsStorage) *generateRecurringItemsBiz {
+	return &generateRecurringItemsBiz{store: store}
+}
+
+// GenerateRecurringItems sinh lần lặp kế tiếp cho mỗi item lặp lại đã Done, item đã Done được giữ nguyên.
+// Trả về số item mới, item lỗi không chặn các item khác
+func (biz *generateRecurringItemsBiz) GenerateRecurringItems(ctx context.Context) (int, error) {
+	items, err := biz.store.ListItemsToRecur(ctx, recurBatchSize)
+
+	if err != nil {
+		return 0, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	created := 0
+	var errs []error
+
+	for i := range items {
+		next := model.NextOccurrence(&items[i])
+
+		ok, err := biz.store.CreateNextOccurrence(ctx, items[i].Id, next)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recur item %d: %w", items[i].Id, err))
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		created++
+	}
+
+	return created, errors.Join(errs...)
+}
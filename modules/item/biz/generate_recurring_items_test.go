@@ -0,0 +1,74 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// mockRecurStorage giống storage: item đã có lần lặp kế tiếp thì không được liệt kê và không sinh thêm
+type mockRecurStorage struct {
+	items   []model.TodoItem
+	next    map[int]*model.TodoItemCreation
+	created []*model.TodoItemCreation
+}
+
+func (s *mockRecurStorage) ListItemsToRecur(ctx context.Context, limit int) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+
+	for _, item := range s.items {
+		if s.next[item.Id] == nil {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *mockRecurStorage) CreateNextOccurrence(ctx context.Context, itemId int, next *model.TodoItemCreation) (bool, error) {
+	if s.next[itemId] != nil {
+		return false, nil
+	}
+
+	s.next[itemId] = next
+	s.created = append(s.created, next)
+
+	return true, nil
+}
+
+func TestGenerateRecurringItems(t *testing.T) {
+	dueDate := time.Date(2024, 5, 10, 17, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		rule        model.RecurrenceRule
+		wantDueDate time.Time
+	}{
+		{"daily", model.RecurrenceDaily, dueDate.AddDate(0, 0, 1)},
+		{"weekly", model.RecurrenceWeekly, dueDate.AddDate(0, 0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, model.ItemStatusDone)
+			item.DueDate = &dueDate
+			item.RecurrenceRule = tt.rule
+
+			store := &mockRecurStorage{items: []model.TodoItem{item}, next: map[int]*model.TodoItemCreation{}}
+			business := NewGenerateRecurringItemsBiz(store)
+
+			for run, want := range []int{1, 0} {
+				created, err := business.GenerateRecurringItems(context.Background())
+
+				if err != nil || created != want {
+					t.Fatalf("run %d: created = %d, err = %v, want %d", run+1, created, err, want)
+				}
+			}
+
+			if len(store.created) != 1 || !store.created[0].DueDate.Equal(tt.wantDueDate) {
+				t.Errorf("created = %+v, want one item due %v", store.created, tt.wantDueDate)
+			}
+		})
+	}
+}
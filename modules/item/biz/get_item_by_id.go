@@ -2,28 +2,65 @@ package biz
 
 import (
 	"context"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
 )
 
 type GetItemStorage interface {
-	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	GetItemColumns(ctx context.Context, cond map[string]interface{}, columns []string) (*model.TodoItem, error)
 }
 
 type getItemBiz struct {
-	store GetItemStorage
+	store         GetItemStorage
+	likeStore     ItemLikeStorage
+	subtaskStore  SubtaskStorage
+	hideForbidden bool
+	requester     common.Requester
 }
 
-func NewGetItemBiz(store GetItemStorage) *getItemBiz {
-	return &getItemBiz{store: store}
+func NewGetItemBiz(
+	store GetItemStorage,
+	likeStore ItemLikeStorage,
+	subtaskStore SubtaskStorage,
+	hideForbidden bool,
+	requester common.Requester,
+) *getItemBiz {
+	return &getItemBiz{
+		store:         store,
+		likeStore:     likeStore,
+		subtaskStore:  subtaskStore,
+		hideForbidden: hideForbidden,
+		requester:     requester,
+	}
 }
 
-func (biz *getItemBiz) GetItemById(ctx context.Context, id int) (*model.TodoItem, error) {
-
-	data, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id})
+// GetItemById chỉ đọc các cột của fields (đã qua model.ParseFields), không truyền fields thì lấy hết
+func (biz *getItemBiz) GetItemById(ctx context.Context, id int, fields ...string) (*model.TodoItem, error) {
+	data, err := biz.store.GetItemColumns(ctx, map[string]interface{}{"id": id}, model.FieldColumns(fields))
 
 	if err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return nil, common.ErrCannotGetEntity(model.EntityName, err)
+	}
+
+	if err := checkItemOwner(data, biz.requester, biz.hideForbidden); err != nil {
 		return nil, err
 	}
 
+	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
+		return nil, common.ErrEntityNotFound(model.EntityName, model.ErrItemDeleted)
+	}
+
+	if err := fillLikes(ctx, biz.likeStore, biz.requester.GetUserId(), data); err != nil {
+		return nil, common.ErrCannotGetEntity(model.EntityName, err)
+	}
+
+	if err := fillProgress(ctx, biz.subtaskStore, data); err != nil {
+		return nil, common.ErrCannotGetEntity(model.EntityName, err)
+	}
+
 	return data, nil
 }
@@ -0,0 +1,81 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockGetItemStorage trả về bản sao của items[id], không có thì common.RecordNotFound
+type mockGetItemStorage struct {
+	items map[int]model.TodoItem
+	err   error
+}
+
+func (s *mockGetItemStorage) GetItemColumns(ctx context.Context, cond map[string]interface{}, columns []string) (*model.TodoItem, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	item, ok := s.items[cond["id"].(int)]
+
+	if !ok {
+		return nil, common.RecordNotFound
+	}
+
+	return &item, nil
+}
+
+func newTestItem(id, userId int, status model.ItemStatus) model.TodoItem {
+	item := model.TodoItem{Title: "buy milk", UserId: userId, Status: &status}
+	item.Id = id
+
+	return item
+}
+
+func TestGetItemById(t *testing.T) {
+	store := &mockGetItemStorage{items: map[int]model.TodoItem{
+		1: newTestItem(1, 1, model.ItemStatusDoing),
+	}}
+
+	tests := []struct {
+		name       string
+		store      *mockGetItemStorage
+		id         int
+		wantStatus int
+		wantKey    string
+	}{
+		{"found", store, 1, 0, ""},
+		{"not found", store, 2, http.StatusNotFound, "ErrItemNotFound"},
+		{"storage error", &mockGetItemStorage{err: errors.New("connection refused")}, 1, http.StatusBadRequest, "ErrCannotGetItem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			business := NewGetItemBiz(tt.store, mockEnrichStorage{}, mockEnrichStorage{}, false, common.NewRequester(1))
+
+			data, err := business.GetItemById(context.Background(), tt.id)
+
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if data.Id != tt.id {
+					t.Errorf("id = %d, want %d", data.Id, tt.id)
+				}
+
+				return
+			}
+
+			appErr := common.ToAppError(err)
+
+			if appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey {
+				t.Errorf("error = %d %s, want %d %s", appErr.StatusCode, appErr.Key, tt.wantStatus, tt.wantKey)
+			}
+		})
+	}
+}
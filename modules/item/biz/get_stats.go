@@ -0,0 +1,56 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// Số ngày (tính cả hôm nay) của completed_last_7_days
+const statsCompletedDays = 7
+
+type StatsStorage interface {
+	CountItemsByStatus(ctx context.Context, cond map[string]interface{}) (map[string]int64, error)
+	CountItemsCompletedSince(ctx context.Context, cond map[string]interface{}, since time.Time) (int64, error)
+}
+
+type statsBiz struct {
+	store     StatsStorage
+	requester common.Requester
+}
+
+func NewStatsBiz(store StatsStorage, requester common.Requester) *statsBiz {
+	return &statsBiz{store: store, requester: requester}
+}
+
+// GetStats đếm completed_last_7_days từ 0h của 6 ngày trước tới giờ, ngày được tính theo loc
+func (biz *statsBiz) GetStats(ctx context.Context, loc *time.Location) (*model.ItemStats, error) {
+	cond := map[string]interface{}{"user_id": biz.requester.GetUserId()}
+
+	counts, err := biz.store.CountItemsByStatus(ctx, cond)
+
+	if err != nil {
+		return nil, common.ErrCannotGetEntity(model.EntityName, err)
+	}
+
+	doing, done, deleted := model.ItemStatusDoing, model.ItemStatusDone, model.ItemStatusDeleted
+
+	stats := model.ItemStats{
+		Doing:   counts[doing.String()],
+		Done:    counts[done.String()],
+		Deleted: counts[deleted.String()],
+	}
+
+	for _, count := range counts {
+		stats.Total += count
+	}
+
+	since := common.StartOfDay(time.Now(), loc).In(loc).AddDate(0, 0, 1-statsCompletedDays).UTC()
+
+	if stats.CompletedLastWeek, err = biz.store.CountItemsCompletedSince(ctx, cond, since); err != nil {
+		return nil, common.ErrCannotGetEntity(model.EntityName, err)
+	}
+
+	return &stats, nil
+}
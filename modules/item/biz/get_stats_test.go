@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"testing"
+	"time"
+)
+
+// mockStatsStorage trả về counts và completed, ghi lại since được truyền vào
+type mockStatsStorage struct {
+	counts    map[string]int64
+	completed int64
+	since     time.Time
+}
+
+func (s *mockStatsStorage) CountItemsByStatus(ctx context.Context, cond map[string]interface{}) (map[string]int64, error) {
+	return s.counts, nil
+}
+
+func (s *mockStatsStorage) CountItemsCompletedSince(ctx context.Context, cond map[string]interface{}, since time.Time) (int64, error) {
+	s.since = since
+	return s.completed, nil
+}
+
+func TestGetStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		store     *mockStatsStorage
+		wantDoing int64
+		wantDone  int64
+		wantTotal int64
+	}{
+		{"no items", &mockStatsStorage{counts: map[string]int64{}}, 0, 0, 0},
+		{"mixed", &mockStatsStorage{counts: map[string]int64{"Doing": 2, "Done": 3, "Deleted": 1}, completed: 1}, 2, 3, 6},
+		{"items without status count toward total", &mockStatsStorage{counts: map[string]int64{"Doing": 1, "": 2}}, 1, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := NewStatsBiz(tt.store, common.NewRequester(1)).GetStats(context.Background(), time.UTC)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if stats.Doing != tt.wantDoing || stats.Done != tt.wantDone || stats.Total != tt.wantTotal {
+				t.Errorf("stats = %+v, want doing %d, done %d, total %d", stats, tt.wantDoing, tt.wantDone, tt.wantTotal)
+			}
+
+			if stats.CompletedLastWeek != tt.store.completed {
+				t.Errorf("completed_last_7_days = %d, want %d", stats.CompletedLastWeek, tt.store.completed)
+			}
+
+			// 0h của 6 ngày trước, tính cả hôm nay là 7 ngày
+			wantSince := common.StartOfDay(time.Now(), time.UTC).AddDate(0, 0, -6)
+
+			if !tt.store.since.Equal(wantSince) {
+				t.Errorf("since = %v, want %v", tt.store.since, wantSince)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type importItemsBiz struct {
+	store         CreateItemsStorage
+	dailyQuota    int
+	defaultStatus model.ItemStatus
+	lengthLimits  model.LengthLimits
+	sanitizer     *common.HTMLSanitizer
+	requester     common.Requester
+}
+
+func NewImportItemsBiz(
+	store CreateItemsStorage,
+	dailyQuota int,
+	defaultStatus model.ItemStatus,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	requester common.Requester,
+) *importItemsBiz {
+	return &importItemsBiz{
+		store:         store,
+		dailyQuota:    dailyQuota,
+		defaultStatus: defaultStatus,
+		lengthLimits:  lengthLimits,
+		sanitizer:     sanitizer,
+		requester:     requester,
+	}
+}
+
+// ImportItems khác CreateItems ở chỗ item không hợp lệ chỉ bị bỏ qua và ghi vào report,
+// các item hợp lệ vẫn được insert trong một transaction
+func (biz *importItemsBiz) ImportItems(ctx context.Context, data []*model.TodoItemCreation) (*model.ImportItemsReport, error) {
+	if len(data) == 0 {
+		return nil, common.ErrInvalidRequest(model.ErrItemsIsEmpty)
+	}
+
+	report := model.ImportItemsReport{
+		Ids:    []common.UID{},
+		Errors: []model.ImportItemError{},
+	}
+
+	valid := make([]*model.TodoItemCreation, 0, len(data))
+
+	for i := range data {
+		if data[i] == nil {
+			report.Errors = append(report.Errors, model.ImportItemError{Index: i, Error: model.ErrTitleIsBlank.Error()})
+			continue
+		}
+
+		data[i].ApplyDefaultStatus(biz.defaultStatus)
+		data[i].Sanitize(biz.sanitizer.Sanitize)
+
+		if err := data[i].Validate(biz.lengthLimits); err != nil {
+			report.Errors = append(report.Errors, model.ImportItemError{Index: i, Error: err.Error()})
+			continue
+		}
+
+		data[i].UserId = biz.requester.GetUserId()
+		data[i].DailyQuota = biz.dailyQuota
+		valid = append(valid, data[i])
+	}
+
+	if len(valid) == 0 {
+		return &report, nil
+	}
+
+	if err := biz.store.CreateItems(ctx, valid); err != nil {
+		return nil, errCannotCreateItem(err)
+	}
+
+	for _, item := range valid {
+		report.Ids = append(report.Ids, common.NewUID(uint32(item.Id), common.DbTypeItem, 1))
+	}
+
+	report.Imported = len(valid)
+
+	return &report, nil
+}
@@ -0,0 +1,56 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestImportItems(t *testing.T) {
+	const requesterId = 1
+
+	tests := []struct {
+		name         string
+		data         []*model.TodoItemCreation
+		wantImported int
+		wantErrIdx   []int
+	}{
+		{"all valid", []*model.TodoItemCreation{{Title: "a"}, {Title: "b"}}, 2, nil},
+		{"mixed", []*model.TodoItemCreation{{Title: "a"}, {Title: " "}, nil, {Title: "d", UserId: 99}}, 2, []int{1, 2}},
+		{"all invalid", []*model.TodoItemCreation{{Title: ""}}, 0, []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateItemsStorage{}
+			business := NewImportItemsBiz(store, 0, model.ItemStatusDoing, model.LengthLimits{}, nil, common.NewRequester(requesterId))
+
+			report, err := business.ImportItems(context.Background(), tt.data)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if report.Imported != tt.wantImported || len(report.Ids) != tt.wantImported || len(store.lastBatch) != tt.wantImported {
+				t.Errorf("imported = %d, ids = %d, inserted = %d, want %d", report.Imported, len(report.Ids), len(store.lastBatch), tt.wantImported)
+			}
+
+			if len(report.Errors) != len(tt.wantErrIdx) {
+				t.Fatalf("errors = %+v, want indexes %v", report.Errors, tt.wantErrIdx)
+			}
+
+			for i, idx := range tt.wantErrIdx {
+				if report.Errors[i].Index != idx || report.Errors[i].Error == "" {
+					t.Errorf("errors[%d] = %+v, want index %d", i, report.Errors[i], idx)
+				}
+			}
+
+			for _, item := range store.lastBatch {
+				if item.UserId != requesterId {
+					t.Errorf("item %q user_id = %d, want the requester %d", item.Title, item.UserId, requesterId)
+				}
+			}
+		})
+	}
+}
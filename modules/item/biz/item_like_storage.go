@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+)
+
+// ItemLikeStorage lấy thông tin like của item, được implement bởi storage của module userlikeitem
+type ItemLikeStorage interface {
+	GetItemLikes(ctx context.Context, ids []int) (map[int]int, error)
+	GetLikedItemIds(ctx context.Context, userId int, ids []int) (map[int]bool, error)
+}
+
+// fillLikes gán LikedCount và HasLiked (theo userId) cho các item
+func fillLikes(ctx context.Context, store ItemLikeStorage, userId int, items ...*model.TodoItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(items))
+
+	for i := range items {
+		ids[i] = items[i].Id
+	}
+
+	likedCount, err := store.GetItemLikes(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	likedIds, err := store.GetLikedItemIds(ctx, userId, ids)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		item.LikedCount = likedCount[item.Id]
+		item.HasLiked = likedIds[item.Id]
+	}
+
+	return nil
+}
@@ -16,24 +16,73 @@ type ListItemStorage interface {
 }
 
 type listItemBiz struct {
-	store ListItemStorage
+	store        ListItemStorage
+	likeStore    ItemLikeStorage
+	userStore    UserStorage
+	subtaskStore SubtaskStorage
+	requester    common.Requester
 }
 
-func NewListItemBiz(store ListItemStorage) *listItemBiz {
-	return &listItemBiz{store: store}
+func NewListItemBiz(
+	store ListItemStorage,
+	likeStore ItemLikeStorage,
+	userStore UserStorage,
+	subtaskStore SubtaskStorage,
+	requester common.Requester,
+) *listItemBiz {
+	return &listItemBiz{
+		store:        store,
+		likeStore:    likeStore,
+		userStore:    userStore,
+		subtaskStore: subtaskStore,
+		requester:    requester,
+	}
 }
 
+// ListItem trả về item của requester theo filter, paging.Total được storage điền
 func (biz *listItemBiz) ListItem(
 	ctx context.Context,
 	filter *model.Filter,
 	paging *common.Paging,
-) ([]model.TodoItem, error) {
+) (*common.ListResult[model.TodoItem], error) {
+	if filter == nil {
+		filter = &model.Filter{}
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	if paging.CursorMode && !filter.SupportsCursor() {
+		return nil, common.ErrInvalidRequest(model.ErrCursorWithSort)
+	}
+
+	// Chỉ list item của chính requester
+	filter.UserId = biz.requester.GetUserId()
 
 	data, err := biz.store.ListItem(ctx, filter, paging)
 
 	if err != nil {
-		return nil, err
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	items := make([]*model.TodoItem, len(data))
+
+	for i := range data {
+		items[i] = &data[i]
+	}
+
+	if err := fillLikes(ctx, biz.likeStore, biz.requester.GetUserId(), items...); err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	if err := fillUsers(ctx, biz.userStore, items...); err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	if err := fillProgress(ctx, biz.subtaskStore, items...); err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
 	}
 
-	return data, nil
+	return common.NewListResult(data, paging, filter), nil
 }
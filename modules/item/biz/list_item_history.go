@@ -0,0 +1,48 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type ListItemHistoryStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	ListItemAuditLogs(
+		ctx context.Context,
+		cond map[string]interface{},
+		paging *common.Paging,
+	) ([]model.ItemAuditLog, error)
+}
+
+type listItemHistoryBiz struct {
+	store     ListItemHistoryStorage
+	requester common.Requester
+}
+
+func NewListItemHistoryBiz(store ListItemHistoryStorage, requester common.Requester) *listItemHistoryBiz {
+	return &listItemHistoryBiz{store: store, requester: requester}
+}
+
+// ListItemHistory chỉ cho chủ item xem, item đã xoá mềm vẫn xem được lịch sử
+func (biz *listItemHistoryBiz) ListItemHistory(
+	ctx context.Context,
+	id int,
+	paging *common.Paging,
+) ([]model.ItemAuditLog, error) {
+	if _, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id, "user_id": biz.requester.GetUserId()}); err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	data, err := biz.store.ListItemAuditLogs(ctx, map[string]interface{}{"item_id": id}, paging)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,299 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	usermodel "social-todo-list/modules/user/model"
+	"testing"
+)
+
+// mockListStorage trả về items và điền paging.Total như storage thật
+type mockListStorage struct {
+	items  []model.TodoItem
+	calls  int
+	filter *model.Filter
+}
+
+func (s *mockListStorage) ListItem(
+	ctx context.Context,
+	filter *model.Filter,
+	paging *common.Paging,
+	moreKeys ...string,
+) ([]model.TodoItem, error) {
+	s.calls++
+	s.filter = filter
+	paging.Total = int64(len(s.items))
+
+	return s.items, nil
+}
+
+type mockEnrichStorage struct{}
+
+func (mockEnrichStorage) GetItemLikes(ctx context.Context, ids []int) (map[int]int, error) {
+	return map[int]int{}, nil
+}
+
+func (mockEnrichStorage) GetLikedItemIds(ctx context.Context, userId int, ids []int) (map[int]bool, error) {
+	return map[int]bool{}, nil
+}
+
+func (mockEnrichStorage) GetUsers(ctx context.Context, ids []int) ([]usermodel.UserInfo, error) {
+	return nil, nil
+}
+
+func (mockEnrichStorage) CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error) {
+	return map[int]int{}, map[int]int{}, nil
+}
+
+func newTestListItemBiz(store ListItemStorage, userId int) *listItemBiz {
+	return NewListItemBiz(store, mockEnrichStorage{}, mockEnrichStorage{}, mockEnrichStorage{}, common.NewRequester(userId))
+}
+
+func TestListItemCursorWithSort(t *testing.T) {
+	cursor := common.NewUID(10, common.DbTypeItem, 1).String()
+
+	tests := []struct {
+		name    string
+		filter  *model.Filter
+		wantErr error
+	}{
+		{"default order", &model.Filter{}, nil},
+		{"nil filter", nil, nil},
+		{"id desc", &model.Filter{Sort: "id", Order: "desc"}, nil},
+		{"id asc", &model.Filter{Sort: "id", Order: "asc"}, model.ErrCursorWithSort},
+		{"other column", &model.Filter{Sort: "title"}, model.ErrCursorWithSort},
+		{"smart", &model.Filter{Sort: model.SortSmart}, model.ErrCursorWithSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockListStorage{}
+			paging := common.Paging{FakeCursor: cursor}
+			_ = paging.Process()
+
+			_, err := newTestListItemBiz(store, 1).ListItem(context.Background(), tt.filter, &paging)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && store.calls != 0 {
+				t.Error("storage queried for a rejected request")
+			}
+		})
+	}
+}
+
+// mockUserStorage ghi lại id của từng lần GetUsers, user nằm trong missing coi như không tồn tại
+type mockUserStorage struct {
+	calls   [][]int
+	missing map[int]bool
+}
+
+func (s *mockUserStorage) GetUsers(ctx context.Context, ids []int) ([]usermodel.UserInfo, error) {
+	s.calls = append(s.calls, ids)
+
+	users := make([]usermodel.UserInfo, 0, len(ids))
+
+	for _, id := range ids {
+		if !s.missing[id] {
+			users = append(users, usermodel.UserInfo{Id: id})
+		}
+	}
+
+	return users, nil
+}
+
+func TestListItemUsers(t *testing.T) {
+	const requesterId, otherId, editorId = 1, 2, 3
+
+	newItem := func(id, ownerId int, updatedBy *int) model.TodoItem {
+		item := model.TodoItem{UserId: ownerId, UpdatedBy: updatedBy}
+		item.Id = id
+
+		return item
+	}
+
+	self, editor := requesterId, editorId
+
+	tests := []struct {
+		name      string
+		items     []model.TodoItem
+		missing   map[int]bool
+		wantCalls [][]int
+	}{
+		{"no items", nil, nil, nil},
+		{
+			"two distinct users resolved in one owner query",
+			[]model.TodoItem{newItem(1, requesterId, nil), newItem(2, otherId, nil), newItem(3, requesterId, nil)},
+			nil,
+			[][]int{{requesterId, otherId}},
+		},
+		{
+			"owners and updated_by in the same query",
+			[]model.TodoItem{newItem(1, requesterId, &self), newItem(2, otherId, &editor), newItem(3, otherId, &editor)},
+			nil,
+			[][]int{{requesterId, otherId, editorId}},
+		},
+		{
+			"missing owner renders nil",
+			[]model.TodoItem{newItem(1, requesterId, nil), newItem(2, otherId, nil)},
+			map[int]bool{otherId: true},
+			[][]int{{requesterId, otherId}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStore := &mockUserStorage{missing: tt.missing}
+			business := NewListItemBiz(
+				&mockListStorage{items: tt.items},
+				mockEnrichStorage{},
+				userStore,
+				mockEnrichStorage{},
+				common.NewRequester(requesterId),
+			)
+
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := business.ListItem(context.Background(), nil, &paging)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprint(userStore.calls) != fmt.Sprint(tt.wantCalls) {
+				t.Fatalf("GetUsers calls = %v, want %v", userStore.calls, tt.wantCalls)
+			}
+
+			for _, item := range result.Items {
+				if tt.missing[item.UserId] != (item.Owner == nil) {
+					t.Errorf("item %d: owner = %+v, owner %d missing = %v", item.Id, item.Owner, item.UserId, tt.missing[item.UserId])
+				}
+
+				if item.Owner != nil && item.Owner.Id != item.UserId {
+					t.Errorf("item %d: owner id = %d, want %d", item.Id, item.Owner.Id, item.UserId)
+				}
+
+				if (item.UpdatedBy == nil) != (item.UpdatedByUser == nil) {
+					t.Errorf("item %d: updated_by = %v, updated_by_user = %v", item.Id, item.UpdatedBy, item.UpdatedByUser)
+				}
+
+				if item.UpdatedByUser != nil && item.UpdatedByUser.Id != *item.UpdatedBy {
+					t.Errorf("item %d: updated_by_user id = %d, want %d", item.Id, item.UpdatedByUser.Id, *item.UpdatedBy)
+				}
+			}
+		})
+	}
+}
+
+func TestListItemResult(t *testing.T) {
+	const requesterId = 1
+
+	newItem := func(id int) model.TodoItem {
+		item := model.TodoItem{UserId: requesterId, Title: fmt.Sprintf("item %d", id)}
+		item.Id = id
+
+		return item
+	}
+
+	tests := []struct {
+		name   string
+		items  []model.TodoItem
+		filter *model.Filter
+	}{
+		{"empty from nil slice", nil, nil},
+		{"empty from empty slice", []model.TodoItem{}, &model.Filter{Search: "milk"}},
+		{"items", []model.TodoItem{newItem(1), newItem(2), newItem(3)}, nil},
+		{"echoed filter", []model.TodoItem{newItem(1)}, &model.Filter{Status: []string{"Doing"}, Search: "milk", Tag: "home"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := newTestListItemBiz(&mockListStorage{items: tt.items}, requesterId).
+				ListItem(context.Background(), tt.filter, &paging)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result == nil || result.Items == nil {
+				t.Fatalf("result = %+v, want non-nil result with non-nil items", result)
+			}
+
+			if len(result.Items) != len(tt.items) {
+				t.Fatalf("len(items) = %d, want %d", len(result.Items), len(tt.items))
+			}
+
+			for i := range tt.items {
+				if result.Items[i].Id != tt.items[i].Id {
+					t.Errorf("items[%d].id = %d, want %d", i, result.Items[i].Id, tt.items[i].Id)
+				}
+			}
+
+			if result.Paging != &paging || result.Paging.Total != int64(len(tt.items)) {
+				t.Errorf("paging = %+v, want the request paging with total %d", result.Paging, len(tt.items))
+			}
+
+			filter, ok := result.Filter.(*model.Filter)
+
+			if !ok || filter == nil {
+				t.Fatalf("filter = %#v, want *model.Filter", result.Filter)
+			}
+
+			if tt.filter != nil && filter != tt.filter {
+				t.Errorf("filter = %+v, want the request filter %+v", filter, tt.filter)
+			}
+
+			if filter.UserId != requesterId {
+				t.Errorf("filter.user_id = %d, want %d", filter.UserId, requesterId)
+			}
+		})
+	}
+}
+
+func TestListItemRejectsBadSort(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     *model.Filter
+		wantStatus int
+	}{
+		{"allowed column", &model.Filter{Sort: "created_at", Order: "asc"}, 0},
+		{"unknown column", &model.Filter{Sort: "password"}, http.StatusBadRequest},
+		{"unknown order", &model.Filter{Sort: "title", Order: "up"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockListStorage{}
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			_, err := newTestListItemBiz(store, 1).ListItem(context.Background(), tt.filter, &paging)
+
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if appErr := common.ToAppError(err); err == nil || appErr.StatusCode != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if store.calls != 0 {
+				t.Error("storage queried for a rejected sort")
+			}
+		})
+	}
+}
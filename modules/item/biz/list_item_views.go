@@ -0,0 +1,59 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+type ListItemViewsStorage interface {
+	ListItemsDue(ctx context.Context, userId int, from *time.Time, to time.Time, paging *common.Paging) ([]model.TodoItem, error)
+}
+
+type listItemViewsBiz struct {
+	store     ListItemViewsStorage
+	requester common.Requester
+}
+
+func NewListItemViewsBiz(store ListItemViewsStorage, requester common.Requester) *listItemViewsBiz {
+	return &listItemViewsBiz{store: store, requester: requester}
+}
+
+// ListToday trả về item chưa Done đến hạn hôm nay hoặc đã quá hạn, "hôm nay" tính theo loc
+func (biz *listItemViewsBiz) ListToday(ctx context.Context, loc *time.Location, paging *common.Paging) ([]model.TodoItem, error) {
+	start := common.StartOfDay(time.Now(), loc)
+	end := start.In(loc).AddDate(0, 0, 1).UTC()
+
+	result, err := biz.store.ListItemsDue(ctx, biz.requester.GetUserId(), nil, end, paging)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	return result, nil
+}
+
+// ListUpcoming trả về item chưa Done đến hạn trong days ngày tới, không tính hôm nay (đã có ở view today).
+// days lớn hơn model.MaxUpcomingDays thì lấy model.MaxUpcomingDays
+func (biz *listItemViewsBiz) ListUpcoming(ctx context.Context, loc *time.Location, days int, paging *common.Paging) ([]model.TodoItem, error) {
+	if days <= 0 {
+		return nil, common.ErrInvalidRequest(model.ErrInvalidUpcomingDays)
+	}
+
+	if days > model.MaxUpcomingDays {
+		days = model.MaxUpcomingDays
+	}
+
+	today := common.StartOfDay(time.Now(), loc).In(loc)
+	from := today.AddDate(0, 0, 1).UTC()
+	to := today.AddDate(0, 0, days+1).UTC()
+
+	result, err := biz.store.ListItemsDue(ctx, biz.requester.GetUserId(), &from, to, paging)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	return result, nil
+}
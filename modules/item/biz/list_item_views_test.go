@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// mockListItemViewsStorage ghi lại khoảng due_date của lần gọi cuối
+type mockListItemViewsStorage struct {
+	calls int
+	from  *time.Time
+	to    time.Time
+}
+
+func (s *mockListItemViewsStorage) ListItemsDue(ctx context.Context, userId int, from *time.Time, to time.Time, paging *common.Paging) ([]model.TodoItem, error) {
+	s.calls++
+	s.from, s.to = from, to
+
+	return nil, nil
+}
+
+func TestListItemViews(t *testing.T) {
+	ho, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		loc        *time.Location
+		upcoming   bool
+		days       int
+		wantStatus int
+		wantFrom   int
+		wantTo     int
+	}{
+		{"today", time.UTC, false, 0, 0, 0, 1},
+		{"today in Ho Chi Minh", ho, false, 0, 0, 0, 1},
+		{"upcoming 7 days", time.UTC, true, 7, 0, 1, 8},
+		{"upcoming is capped", ho, true, 1000, 0, 1, model.MaxUpcomingDays + 1},
+		{"zero days", time.UTC, true, 0, http.StatusBadRequest, 0, 0},
+		{"negative days", time.UTC, true, -1, http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockListItemViewsStorage{}
+			business := NewListItemViewsBiz(store, common.NewRequester(1))
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			if !tt.upcoming {
+				_, err = business.ListToday(context.Background(), tt.loc, &paging)
+			} else {
+				_, err = business.ListUpcoming(context.Background(), tt.loc, tt.days, &paging)
+			}
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus || store.calls != 0 {
+					t.Fatalf("err = %v, calls = %d, want %d without a query", err, store.calls, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			today := common.StartOfDay(time.Now(), tt.loc).In(tt.loc)
+
+			if want := today.AddDate(0, 0, tt.wantTo); !store.to.Equal(want) {
+				t.Errorf("to = %v, want %v", store.to, want)
+			}
+
+			if !tt.upcoming {
+				if store.from != nil {
+					t.Errorf("from = %v, want no lower bound for overdue items", store.from)
+				}
+
+				return
+			}
+
+			if want := today.AddDate(0, 0, tt.wantFrom); store.from == nil || !store.from.Equal(want) {
+				t.Errorf("from = %v, want %v", store.from, want)
+			}
+		})
+	}
+}
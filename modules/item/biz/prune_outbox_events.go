@@ -0,0 +1,25 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+type PruneOutboxEventsStorage interface {
+	DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+type pruneOutboxEventsBiz struct {
+	store     PruneOutboxEventsStorage
+	retention time.Duration
+}
+
+// NewPruneOutboxEventsBiz dùng cho job chạy nền nên không có requester
+func NewPruneOutboxEventsBiz(store PruneOutboxEventsStorage, retention time.Duration) *pruneOutboxEventsBiz {
+	return &pruneOutboxEventsBiz{store: store, retention: retention}
+}
+
+// PruneOutboxEvents xoá các event đã gửi lâu hơn retention, event chưa gửi được giữ lại, trả về số event đã xoá
+func (biz *pruneOutboxEventsBiz) PruneOutboxEvents(ctx context.Context) (int64, error) {
+	return biz.store.DeletePublishedOutboxEvents(ctx, time.Now().UTC().Add(-biz.retention))
+}
@@ -0,0 +1,35 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+type PurgeDeletedItemsStorage interface {
+	HardDeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type purgeDeletedItemsBiz struct {
+	store     PurgeDeletedItemsStorage
+	retention time.Duration
+}
+
+// NewPurgeDeletedItemsBiz dùng cho job chạy nền nên không có requester, chạy trên item của mọi user
+func NewPurgeDeletedItemsBiz(store PurgeDeletedItemsStorage, retention time.Duration) *purgeDeletedItemsBiz {
+	return &purgeDeletedItemsBiz{store: store, retention: retention}
+}
+
+// PurgeDeletedItems xoá hẳn các item đã nằm trong thùng rác lâu hơn retention, trả về số item đã xoá
+func (biz *purgeDeletedItemsBiz) PurgeDeletedItems(ctx context.Context) (int64, error) {
+	cutoff := time.Now().UTC().Add(-biz.retention)
+
+	purged, err := biz.store.HardDeleteOlderThan(ctx, cutoff)
+
+	if err != nil {
+		return 0, common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	return purged, nil
+}
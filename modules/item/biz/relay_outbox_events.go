@@ -0,0 +1,142 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"sync"
+	"time"
+)
+
+const (
+	// Số event tối đa được gửi trong một lần chạy, còn lại để lần sau
+	outboxRelayBatchSize = 100
+	// Gửi lỗi thì chờ outboxRetryBaseDelay rồi gấp đôi sau mỗi lần lỗi, tối đa outboxRetryMaxDelay
+	outboxRetryBaseDelay = 10 * time.Second
+	outboxRetryMaxDelay  = time.Hour
+	// Event được claim giữ khoá trong outboxClaimLease, event chưa gửi xong khi hết lease bị huỷ và hẹn gửi lại
+	outboxClaimLease = 5 * time.Minute
+	// Số worker gửi event song song, event của một user luôn vào cùng một worker để giữ thứ tự
+	outboxRelayWorkers = 8
+)
+
+type RelayOutboxEventsStorage interface {
+	ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int, at time.Time) error
+	MarkOutboxEventFailed(ctx context.Context, id int, reason string, nextAttemptAt time.Time) error
+}
+
+type relayOutboxEventsBiz struct {
+	store      RelayOutboxEventsStorage
+	publisher  EventPublisher
+	dispatcher common.EventDispatcher
+}
+
+// NewRelayOutboxEventsBiz dùng cho job chạy nền nên không có requester.
+// publisher (websocket) nhận event một lần khi event được claim lần đầu, dispatcher (webhook) được gửi lại khi lỗi
+func NewRelayOutboxEventsBiz(
+	store RelayOutboxEventsStorage,
+	publisher EventPublisher,
+	dispatcher common.EventDispatcher,
+) *relayOutboxEventsBiz {
+	return &relayOutboxEventsBiz{store: store, publisher: publisher, dispatcher: dispatcher}
+}
+
+// RelayOutboxEvents claim rồi gửi các event chưa gửi, trả về số event đã gửi.
+// Websocket nhận event theo đúng thứ tự ghi, còn dispatcher chạy trên outboxRelayWorkers worker chia theo user
+// nên một endpoint chậm chỉ làm chậm event của các user cùng worker, event của mỗi user vẫn theo thứ tự.
+// Event chỉ được đánh dấu đã gửi sau khi gửi thành công nên có thể bị gửi lại (at-least-once),
+// gửi lỗi thì hẹn gửi lại sau và các event khác vẫn được gửi tiếp
+func (biz *relayOutboxEventsBiz) RelayOutboxEvents(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	events, err := biz.store.ClaimOutboxEvents(ctx, now, outboxClaimLease, outboxRelayBatchSize)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Websocket chỉ là thông báo tức thời, không gửi lại khi webhook lỗi
+	for _, event := range events {
+		if event.Attempts == 0 {
+			biz.publisher.Publish(event.UserId, event.Event())
+		}
+	}
+
+	partitions := make([][]model.OutboxEvent, outboxRelayWorkers)
+
+	for _, event := range events {
+		worker := event.UserId % outboxRelayWorkers
+		partitions[worker] = append(partitions[worker], event)
+	}
+
+	// Hết lease thì event có thể đã bị instance khác claim, dừng gửi để không gửi trùng
+	dispatchCtx, cancel := context.WithDeadline(ctx, now.Add(outboxClaimLease))
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		published int
+		errs      []error
+	)
+
+	for _, partition := range partitions {
+		if len(partition) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(partition []model.OutboxEvent) {
+			defer wg.Done()
+
+			for _, event := range partition {
+				err := biz.relay(ctx, dispatchCtx, event, now)
+
+				mu.Lock()
+
+				if err != nil {
+					errs = append(errs, err)
+				} else {
+					published++
+				}
+
+				mu.Unlock()
+			}
+		}(partition)
+	}
+
+	wg.Wait()
+
+	return published, errors.Join(errs...)
+}
+
+// relay gửi một event qua dispatcher rồi đánh dấu đã gửi hoặc hẹn gửi lại.
+// Đánh dấu dùng ctx chứ không phải dispatchCtx để vẫn ghi được khi dispatchCtx đã hết hạn
+func (biz *relayOutboxEventsBiz) relay(ctx, dispatchCtx context.Context, event model.OutboxEvent, now time.Time) error {
+	if err := biz.dispatcher.Dispatch(dispatchCtx, event.UserId, event.Event()); err != nil {
+		dispatchErr := fmt.Errorf("dispatch outbox event %d: %w", event.Id, err)
+
+		if err := biz.store.MarkOutboxEventFailed(ctx, event.Id, err.Error(), now.Add(outboxRetryDelay(event.Attempts))); err != nil {
+			return errors.Join(dispatchErr, err)
+		}
+
+		return dispatchErr
+	}
+
+	return biz.store.MarkOutboxEventPublished(ctx, event.Id, time.Now().UTC())
+}
+
+// outboxRetryDelay là thời gian chờ trước lần gửi lại sau attempts lần đã lỗi
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay
+
+	for i := 0; i < attempts && delay < outboxRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, outboxRetryMaxDelay)
+}
@@ -0,0 +1,270 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mockPublisher struct {
+	events []common.Event
+}
+
+func (p *mockPublisher) Publish(userId int, event common.Event) {
+	p.events = append(p.events, event)
+}
+
+// mockOutboxStorage trả về events khi claim và ghi lại event nào được đánh dấu đã gửi hoặc lỗi,
+// relay gọi từ nhiều worker nên có mu
+type mockOutboxStorage struct {
+	mu        sync.Mutex
+	events    []model.OutboxEvent
+	published []int
+	failed    map[int]time.Time
+}
+
+func (s *mockOutboxStorage) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEvent, error) {
+	return s.events, nil
+}
+
+func (s *mockOutboxStorage) MarkOutboxEventPublished(ctx context.Context, id int, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *mockOutboxStorage) MarkOutboxEventFailed(ctx context.Context, id int, reason string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed[id] = nextAttemptAt
+	return nil
+}
+
+// mockDispatcher lỗi với các item trong failItems
+type mockDispatcher struct {
+	failItems map[int]bool
+}
+
+func (d *mockDispatcher) Dispatch(ctx context.Context, userId int, event common.Event) error {
+	id := event.Data.(map[string]interface{})["id"].(common.UID)
+
+	if d.failItems[int(id.GetLocalID())] {
+		return errors.New("endpoint unavailable")
+	}
+
+	return nil
+}
+
+func TestRelayOutboxEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []model.OutboxEvent
+		failItems     map[int]bool
+		wantPublished []int
+		wantFailed    []int
+		wantLive      int
+	}{
+		{
+			name:          "first attempt goes to websocket and dispatcher",
+			events:        []model.OutboxEvent{{Id: 1, ItemId: 10}, {Id: 2, ItemId: 20}},
+			wantPublished: []int{1, 2},
+			wantLive:      2,
+		},
+		{
+			name:          "retries are not pushed to websocket again",
+			events:        []model.OutboxEvent{{Id: 1, ItemId: 10, Attempts: 2}},
+			wantPublished: []int{1},
+			wantLive:      0,
+		},
+		{
+			name:          "failed dispatch is rescheduled, others still sent",
+			events:        []model.OutboxEvent{{Id: 1, ItemId: 10}, {Id: 2, ItemId: 20}},
+			failItems:     map[int]bool{10: true},
+			wantPublished: []int{2},
+			wantFailed:    []int{1},
+			wantLive:      2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockOutboxStorage{events: tt.events, failed: map[int]time.Time{}}
+			publisher := &mockPublisher{}
+			business := NewRelayOutboxEventsBiz(store, publisher, &mockDispatcher{failItems: tt.failItems})
+
+			published, err := business.RelayOutboxEvents(context.Background())
+
+			if (err != nil) != (len(tt.wantFailed) > 0) {
+				t.Fatalf("err = %v, want failures %v", err, tt.wantFailed)
+			}
+
+			if published != len(tt.wantPublished) || len(store.published) != len(tt.wantPublished) {
+				t.Fatalf("published %d (%v), want %v", published, store.published, tt.wantPublished)
+			}
+
+			for i, id := range tt.wantPublished {
+				if store.published[i] != id {
+					t.Fatalf("published %v, want %v", store.published, tt.wantPublished)
+				}
+			}
+
+			for _, id := range tt.wantFailed {
+				next, ok := store.failed[id]
+
+				if !ok {
+					t.Fatalf("event %d not marked failed", id)
+				}
+
+				if delay := time.Until(next); delay <= 0 || delay > outboxRetryBaseDelay {
+					t.Errorf("event %d retried in %v, want within %v", id, delay, outboxRetryBaseDelay)
+				}
+			}
+
+			if len(publisher.events) != tt.wantLive {
+				t.Errorf("websocket got %d events, want %d", len(publisher.events), tt.wantLive)
+			}
+		})
+	}
+}
+
+func TestOutboxRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, outboxRetryBaseDelay},
+		{1, 2 * outboxRetryBaseDelay},
+		{3, 8 * outboxRetryBaseDelay},
+		{100, outboxRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := outboxRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("outboxRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// blockingDispatcher chặn event của blockedUser tới khi release bị đóng, event của user khác được ghi theo thứ tự gửi
+type blockingDispatcher struct {
+	mu          sync.Mutex
+	blockedUser int
+	release     chan struct{}
+	dispatched  map[int][]int
+	delivered   chan int
+	deadlines   bool
+}
+
+func (d *blockingDispatcher) Dispatch(ctx context.Context, userId int, event common.Event) error {
+	if _, ok := ctx.Deadline(); !ok {
+		d.mu.Lock()
+		d.deadlines = false
+		d.mu.Unlock()
+	}
+
+	if userId == d.blockedUser {
+		select {
+		case <-d.release:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	id := event.Data.(map[string]interface{})["id"].(common.UID)
+
+	d.mu.Lock()
+	d.dispatched[userId] = append(d.dispatched[userId], int(id.GetLocalID()))
+	d.mu.Unlock()
+
+	d.delivered <- userId
+
+	return nil
+}
+
+func TestRelayOutboxEventsWorkers(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []model.OutboxEvent
+		want   map[int][]int
+	}{
+		{
+			name: "slow user does not block others and order per user is kept",
+			events: []model.OutboxEvent{
+				{Id: 1, UserId: 1, ItemId: 10},
+				{Id: 2, UserId: 2, ItemId: 20},
+				{Id: 3, UserId: 1, ItemId: 11},
+				{Id: 4, UserId: 2, ItemId: 21},
+				{Id: 5, UserId: 2, ItemId: 22},
+			},
+			want: map[int][]int{1: {10, 11}, 2: {20, 21, 22}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockOutboxStorage{events: tt.events, failed: map[int]time.Time{}}
+			dispatcher := &blockingDispatcher{
+				blockedUser: 1,
+				release:     make(chan struct{}),
+				dispatched:  map[int][]int{},
+				delivered:   make(chan int, len(tt.events)),
+				deadlines:   true,
+			}
+			business := NewRelayOutboxEventsBiz(store, &mockPublisher{}, dispatcher)
+
+			done := make(chan error, 1)
+
+			go func() {
+				_, err := business.RelayOutboxEvents(context.Background())
+				done <- err
+			}()
+
+			// Các event của user 2 phải gửi xong khi user 1 vẫn đang bị chặn
+			for i := 0; i < len(tt.want[2]); i++ {
+				select {
+				case userId := <-dispatcher.delivered:
+					if userId != 2 {
+						t.Fatalf("user %d delivered while blocked", userId)
+					}
+				case <-time.After(time.Second):
+					t.Fatal("events of other users waited for the slow endpoint")
+				}
+			}
+
+			close(dispatcher.release)
+
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+
+			for userId, want := range tt.want {
+				got := dispatcher.dispatched[userId]
+
+				if len(got) != len(want) {
+					t.Fatalf("user %d dispatched %v, want %v", userId, got, want)
+				}
+
+				for i := range want {
+					if got[i] != want[i] {
+						t.Fatalf("user %d dispatched %v, want %v", userId, got, want)
+					}
+				}
+			}
+
+			if len(store.published) != len(tt.events) {
+				t.Errorf("published %v, want all %d events", store.published, len(tt.events))
+			}
+
+			if !dispatcher.deadlines {
+				t.Error("dispatch context has no deadline, a slow endpoint could outlive the claim lease")
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// Số item tối đa được nhắc trong một lần quét, còn lại để lần sau
+const remindBatchSize = 100
+
+type RemindDueItemsStorage interface {
+	ListItemsToRemind(ctx context.Context, from, to time.Time, limit int) ([]model.TodoItem, error)
+	ClaimReminder(ctx context.Context, id int, at time.Time) (bool, error)
+	ReleaseReminder(ctx context.Context, id int) error
+}
+
+type remindDueItemsBiz struct {
+	store    RemindDueItemsStorage
+	notifier common.Notifier
+	window   time.Duration
+}
+
+// NewRemindDueItemsBiz dùng cho job chạy nền nên không có requester, nhắc item sắp đến hạn trong window
+func NewRemindDueItemsBiz(store RemindDueItemsStorage, notifier common.Notifier, window time.Duration) *remindDueItemsBiz {
+	return &remindDueItemsBiz{store: store, notifier: notifier, window: window}
+}
+
+// RemindDueItems gửi một thông báo cho mỗi item sắp đến hạn, trả về số thông báo đã gửi.
+// Gửi lỗi thì item được bỏ đánh dấu để lần quét sau gửi lại, các item khác vẫn được gửi tiếp
+func (biz *remindDueItemsBiz) RemindDueItems(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	items, err := biz.store.ListItemsToRemind(ctx, now, now.Add(biz.window), remindBatchSize)
+
+	if err != nil {
+		return 0, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	sent := 0
+	var errs []error
+
+	for _, item := range items {
+		claimed, err := biz.store.ClaimReminder(ctx, item.Id, now)
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !claimed {
+			continue
+		}
+
+		msg := fmt.Sprintf("%q is due at %s", item.Title, item.DueDate.UTC().Format(time.RFC3339))
+
+		if err := biz.notifier.Notify(ctx, item.UserId, msg); err != nil {
+			errs = append(errs, fmt.Errorf("notify item %d: %w", item.Id, err))
+
+			if err := biz.store.ReleaseReminder(ctx, item.Id); err != nil {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
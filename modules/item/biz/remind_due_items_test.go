@@ -0,0 +1,101 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// mockRemindStorage trả về các item chưa được đánh dấu đã nhắc, giống điều kiện reminded_at IS NULL của storage
+type mockRemindStorage struct {
+	items    []model.TodoItem
+	reminded map[int]bool
+}
+
+func (s *mockRemindStorage) ListItemsToRemind(ctx context.Context, from, to time.Time, limit int) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+
+	for _, item := range s.items {
+		if !s.reminded[item.Id] {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *mockRemindStorage) ClaimReminder(ctx context.Context, id int, at time.Time) (bool, error) {
+	if s.reminded[id] {
+		return false, nil
+	}
+
+	s.reminded[id] = true
+
+	return true, nil
+}
+
+func (s *mockRemindStorage) ReleaseReminder(ctx context.Context, id int) error {
+	delete(s.reminded, id)
+
+	return nil
+}
+
+// fakeNotifier ghi lại user được thông báo, err khác nil thì lần gửi đó lỗi
+type fakeNotifier struct {
+	sent []int
+	err  error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, userId int, msg string) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	n.sent = append(n.sent, userId)
+
+	return nil
+}
+
+func TestRemindDueItems(t *testing.T) {
+	dueDate := time.Now().Add(time.Hour)
+	notifyErr := errors.New("smtp unavailable")
+
+	tests := []struct {
+		name      string
+		firstErr  error
+		wantFirst int
+		wantTotal int
+	}{
+		{"notified once across two scans", nil, 1, 1},
+		{"failed notification retried on the next scan", notifyErr, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 7, model.ItemStatusDoing)
+			item.DueDate = &dueDate
+
+			store := &mockRemindStorage{items: []model.TodoItem{item}, reminded: map[int]bool{}}
+			notifier := &fakeNotifier{err: tt.firstErr}
+			business := NewRemindDueItemsBiz(store, notifier, 24*time.Hour)
+
+			sent, err := business.RemindDueItems(context.Background())
+
+			if !errors.Is(err, tt.firstErr) || sent != tt.wantFirst {
+				t.Fatalf("first scan = %d, %v, want %d, %v", sent, err, tt.wantFirst, tt.firstErr)
+			}
+
+			notifier.err = nil
+
+			if _, err := business.RemindDueItems(context.Background()); err != nil {
+				t.Fatalf("second scan: %v", err)
+			}
+
+			if len(notifier.sent) != tt.wantTotal || notifier.sent[0] != 7 {
+				t.Errorf("notifications = %v, want %d to user 7", notifier.sent, tt.wantTotal)
+			}
+		})
+	}
+}
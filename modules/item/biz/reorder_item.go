@@ -0,0 +1,55 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type ReorderItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	MoveItemAfter(ctx context.Context, userId, id int, afterId *int) error
+}
+
+type reorderItemBiz struct {
+	store     ReorderItemStorage
+	requester common.Requester
+}
+
+func NewReorderItemBiz(store ReorderItemStorage, requester common.Requester) *reorderItemBiz {
+	return &reorderItemBiz{store: store, requester: requester}
+}
+
+// ReorderItem chuyển item tới ngay sau item afterId trong danh sách của requester, afterId nil là lên đầu
+func (biz *reorderItemBiz) ReorderItem(ctx context.Context, id int, afterId *int) error {
+	if afterId != nil && *afterId == id {
+		return common.ErrInvalidRequest(model.ErrMoveAfterItself)
+	}
+
+	userId := biz.requester.GetUserId()
+
+	data, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id, "user_id": userId})
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
+		return common.ErrEntityDeleted(model.EntityName, model.ErrItemDeleted)
+	}
+
+	// Item đích không tồn tại, đã xoá hoặc của user khác đều trả về not found
+	if err := biz.store.MoveItemAfter(ctx, userId, id, afterId); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package biz
+
+import (
+	"context"
+	"database/sql"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+type RestoreItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
+	UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error
+}
+
+type restoreItemBiz struct {
+	store     RestoreItemStorage
+	requester common.Requester
+}
+
+func NewRestoreItemBiz(store RestoreItemStorage, requester common.Requester) *restoreItemBiz {
+	return &restoreItemBiz{store: store, requester: requester}
+}
+
+// RestoreItemById chỉ khôi phục item đang bị xoá mềm, item còn sống sẽ trả về not found
+func (biz *restoreItemBiz) RestoreItemById(ctx context.Context, id int) error {
+	deletedStatus := model.ItemStatusDeleted
+	cond := map[string]interface{}{
+		"id":      id,
+		"user_id": biz.requester.GetUserId(),
+		"status":  deletedStatus,
+	}
+
+	data, err := biz.store.GetItem(ctx, cond)
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	doingStatus := model.ItemStatusDoing
+	nextVersion := data.Version + 1
+	updatedBy := biz.requester.GetUserId()
+	cond["version"] = data.Version
+
+	// Item bị xoá lúc đang Done vẫn còn completed_at, khôi phục về Doing thì phải xoá đi
+	dataUpdate := &model.TodoItemUpdate{
+		Status:      &doingStatus,
+		Version:     &nextVersion,
+		UpdatedBy:   &updatedBy,
+		CompletedAt: &sql.NullTime{},
+	}
+
+	if err := biz.store.UpdateItem(ctx, cond, dataUpdate); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityConflict(model.EntityName, model.ErrVersionConflict)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestRestoreItemById(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         int
+		wantStatus int
+	}{
+		{"deleted item", 1, 0},
+		{"not found", 2, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: newTestItem(1, 1, model.ItemStatusDeleted)}}
+
+			err := NewRestoreItemBiz(store, common.NewRequester(1)).RestoreItemById(context.Background(), tt.id)
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			write := store.writes[0]
+
+			if write.Status == nil || *write.Status != model.ItemStatusDoing {
+				t.Errorf("status = %v, want Doing", write.Status)
+			}
+
+			if write.CompletedAt == nil || write.CompletedAt.Valid {
+				t.Errorf("completed_at = %+v, want cleared", write.CompletedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+)
+
+// SubtaskStorage đếm subtask của item, được implement bởi storage của module subtask
+type SubtaskStorage interface {
+	CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error)
+}
+
+// fillProgress gán Progress = số subtask đã xong / tổng số subtask, item không có subtask thì để nil
+func fillProgress(ctx context.Context, store SubtaskStorage, items ...*model.TodoItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(items))
+
+	for i := range items {
+		ids[i] = items[i].Id
+	}
+
+	total, done, err := store.CountSubtasks(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if total[item.Id] > 0 {
+			progress := float64(done[item.Id]) / float64(total[item.Id])
+			item.Progress = &progress
+		}
+	}
+
+	return nil
+}
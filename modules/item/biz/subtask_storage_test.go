@@ -0,0 +1,54 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockCountSubtasksStorage trả về số subtask đếm sẵn theo item_id
+type mockCountSubtasksStorage struct {
+	total map[int]int
+	done  map[int]int
+}
+
+func (s mockCountSubtasksStorage) CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error) {
+	return s.total, s.done, nil
+}
+
+func TestFillProgress(t *testing.T) {
+	store := mockCountSubtasksStorage{
+		total: map[int]int{1: 4, 2: 2, 3: 3},
+		done:  map[int]int{1: 1, 2: 2},
+	}
+
+	tests := []struct {
+		name string
+		id   int
+		want *float64
+	}{
+		{"partly done", 1, progressOf(0.25)},
+		{"all done", 2, progressOf(1)},
+		{"none done", 3, progressOf(0)},
+		{"no subtasks", 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(tt.id, 1, model.ItemStatusDoing)
+
+			if err := fillProgress(context.Background(), store, &item); err != nil {
+				t.Fatal(err)
+			}
+
+			switch {
+			case tt.want == nil && item.Progress != nil:
+				t.Errorf("progress = %v, want nil", *item.Progress)
+			case tt.want != nil && (item.Progress == nil || *item.Progress != *tt.want):
+				t.Errorf("progress = %v, want %v", item.Progress, *tt.want)
+			}
+		})
+	}
+}
+
+func progressOf(v float64) *float64 { return &v }
@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"unicode/utf8"
+)
+
+type SuggestTitlesStorage interface {
+	SuggestTitles(ctx context.Context, userId int, prefix string, limit int) ([]model.TodoItemSuggestion, error)
+}
+
+type suggestTitlesBiz struct {
+	store     SuggestTitlesStorage
+	limit     int
+	requester common.Requester
+}
+
+func NewSuggestTitlesBiz(store SuggestTitlesStorage, limit int, requester common.Requester) *suggestTitlesBiz {
+	return &suggestTitlesBiz{store: store, limit: limit, requester: requester}
+}
+
+// SuggestTitles gợi ý tối đa limit title của requester bắt đầu bằng q, q quá ngắn thì trả danh sách rỗng
+func (biz *suggestTitlesBiz) SuggestTitles(ctx context.Context, q string) ([]model.TodoItemSuggestion, error) {
+	q = strings.TrimSpace(q)
+
+	if utf8.RuneCountInString(q) < model.MinSuggestQueryLength || biz.limit <= 0 {
+		return []model.TodoItemSuggestion{}, nil
+	}
+
+	result, err := biz.store.SuggestTitles(ctx, biz.requester.GetUserId(), q, biz.limit)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	for i := range result {
+		result[i].Mask()
+	}
+
+	return result, nil
+}
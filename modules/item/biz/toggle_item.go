@@ -0,0 +1,50 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// ToggleItemById đổi item Doing <-> Done (completed_at được set/xoá như update thường) và trả về status mới.
+// Item đã xoá trả về 409, data.Done khác nil mà item đã đúng status đó thì không làm gì
+func (biz *updateItemBiz) ToggleItemById(ctx context.Context, id int, data *model.TodoItemToggle) (model.ItemStatus, error) {
+	item, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id})
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return 0, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return 0, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if err := checkItemOwner(item, biz.requester, biz.hideForbidden); err != nil {
+		return 0, err
+	}
+
+	current := model.ItemStatusDoing
+
+	if item.Status != nil {
+		current = *item.Status
+	}
+
+	next := data.NextStatus(current)
+
+	if current == model.ItemStatusDeleted {
+		return 0, common.ErrInvalidStateTransition(model.EntityName, current.String(), next.String(), model.ErrInvalidStatusTransition)
+	}
+
+	if next == current {
+		return current, nil
+	}
+
+	// Gửi kèm version vừa đọc để hai lần toggle chen nhau thì lần sau bị 409 thay vì đảo ngược lần trước
+	version := item.Version
+
+	if err := biz.updateItem(ctx, id, &model.TodoItemUpdate{Status: &next, Version: &version}, biz.store.UpdateItem); err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
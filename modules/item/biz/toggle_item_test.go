@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestToggleItemById(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name       string
+		current    model.ItemStatus
+		requester  int
+		done       *bool
+		want       model.ItemStatus
+		wantStatus int
+		wantWrites int
+	}{
+		{"doing to done", model.ItemStatusDoing, 1, nil, model.ItemStatusDone, 0, 1},
+		{"done to doing", model.ItemStatusDone, 1, nil, model.ItemStatusDoing, 0, 1},
+		{"explicit done on doing", model.ItemStatusDoing, 1, &yes, model.ItemStatusDone, 0, 1},
+		{"explicit done on done is a no-op", model.ItemStatusDone, 1, &yes, model.ItemStatusDone, 0, 0},
+		{"explicit undone on doing is a no-op", model.ItemStatusDoing, 1, &no, model.ItemStatusDoing, 0, 0},
+		{"deleted item", model.ItemStatusDeleted, 1, nil, 0, http.StatusConflict, 0},
+		{"not the owner", model.ItemStatusDoing, 2, nil, 0, http.StatusNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, tt.current)
+			item.Version = 2
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}}
+			business := NewUpdateItemBiz(store, true, model.LengthLimits{}, nil, common.NewRequester(tt.requester))
+
+			got, err := business.ToggleItemById(context.Background(), 1, &model.TodoItemToggle{Done: tt.done})
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+			} else if err != nil || got != tt.want {
+				t.Fatalf("status, err = %v, %v, want %v", got, err, tt.want)
+			}
+
+			if len(store.writes) != tt.wantWrites {
+				t.Fatalf("writes = %d, want %d", len(store.writes), tt.wantWrites)
+			}
+
+			if tt.wantWrites == 0 {
+				return
+			}
+
+			write := store.writes[0]
+
+			if *write.Status != tt.want || store.conds[0]["version"] != 2 {
+				t.Errorf("write status = %v where version = %v, want %v at version 2", *write.Status, store.conds[0]["version"], tt.want)
+			}
+
+			if write.CompletedAt == nil || write.CompletedAt.Valid != (tt.want == model.ItemStatusDone) {
+				t.Errorf("completed_at = %+v, want set only when done", write.CompletedAt)
+			}
+		})
+	}
+}
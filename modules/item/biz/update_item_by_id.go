@@ -2,36 +2,158 @@ package biz
 
 import (
 	"context"
+	"database/sql"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
+	"time"
 )
 
 type UpdateItemStorage interface {
 	GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error)
 	UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error
+	ReplaceItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error
 }
 
 type updateItemBiz struct {
-	store UpdateItemStorage
+	store         UpdateItemStorage
+	hideForbidden bool
+	lengthLimits  model.LengthLimits
+	sanitizer     *common.HTMLSanitizer
+	requester     common.Requester
 }
 
-func NewUpdateItemBiz(store UpdateItemStorage) *updateItemBiz {
-	return &updateItemBiz{store: store}
+func NewUpdateItemBiz(
+	store UpdateItemStorage,
+	hideForbidden bool,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	requester common.Requester,
+) *updateItemBiz {
+	return &updateItemBiz{
+		store:         store,
+		hideForbidden: hideForbidden,
+		lengthLimits:  lengthLimits,
+		sanitizer:     sanitizer,
+		requester:     requester,
+	}
 }
 
 func (biz *updateItemBiz) UpdateItemById(ctx context.Context, id int, dataUpdate *model.TodoItemUpdate) error {
+	dataUpdate.Sanitize(biz.sanitizer.Sanitize)
+
+	if err := dataUpdate.Validate(biz.lengthLimits); err != nil {
+		return common.ErrInvalidRequest(err)
+	}
+
+	return biz.updateItem(ctx, id, dataUpdate, biz.store.UpdateItem)
+}
+
+// ReplaceItemById ghi đè toàn bộ field của item bằng data (PUT), field không gửi về mặc định
+func (biz *updateItemBiz) ReplaceItemById(ctx context.Context, id int, data *model.TodoItemReplace) error {
+	data.Sanitize(biz.sanitizer.Sanitize)
 
+	if err := data.Validate(biz.lengthLimits); err != nil {
+		return common.ErrInvalidRequest(err)
+	}
+
+	return biz.updateItem(ctx, id, data.ToUpdate(), biz.store.ReplaceItem)
+}
+
+func (biz *updateItemBiz) updateItem(
+	ctx context.Context,
+	id int,
+	dataUpdate *model.TodoItemUpdate,
+	write func(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error,
+) error {
 	data, err := biz.store.GetItem(ctx, map[string]interface{}{"id": id})
 
 	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if err := checkItemOwner(data, biz.requester, biz.hideForbidden); err != nil {
+		return err
+	}
+
+	if err := checkStatusTransition(data, dataUpdate); err != nil {
 		return err
 	}
 
 	if data.Status != nil && *data.Status == model.ItemStatusDeleted {
-		return model.ErrItemDeleted
+		return common.ErrEntityDeleted(model.EntityName, model.ErrItemDeleted)
 	}
 
-	if err := biz.store.UpdateItem(ctx, map[string]interface{}{"id": id}, dataUpdate); err != nil {
-		return err
+	// Không gửi version thì so với version vừa đọc, vẫn chặn được update chen vào giữa
+	version := data.Version
+
+	if dataUpdate.Version != nil {
+		version = *dataUpdate.Version
+	}
+
+	if version != data.Version {
+		return common.ErrEntityConflict(model.EntityName, model.ErrVersionConflict)
+	}
+
+	nextVersion := version + 1
+	dataUpdate.Version = &nextVersion
+
+	updatedBy := biz.requester.GetUserId()
+	dataUpdate.UpdatedBy = &updatedBy
+
+	setCompletedAt(data, dataUpdate)
+
+	cond := map[string]interface{}{"id": id, "user_id": biz.requester.GetUserId(), "version": version}
+
+	if err := write(ctx, cond, dataUpdate); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityConflict(model.EntityName, model.ErrVersionConflict)
+		}
+
+		return common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
+
+// setCompletedAt set completed_at khi item chuyển sang Done và xoá nó khi item rời khỏi Done,
+// các update không đổi status thì giữ nguyên
+func setCompletedAt(current *model.TodoItem, dataUpdate *model.TodoItemUpdate) {
+	if dataUpdate.Status == nil {
+		return
+	}
+
+	wasDone := current.Status != nil && *current.Status == model.ItemStatusDone
+	isDone := *dataUpdate.Status == model.ItemStatusDone
+
+	switch {
+	case isDone && !wasDone:
+		dataUpdate.CompletedAt = &sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	case !isDone && wasDone:
+		dataUpdate.CompletedAt = &sql.NullTime{}
+	}
+}
+
+// checkStatusTransition trả 409 nếu update đổi status theo hướng không cho phép (ví dụ Deleted -> Done).
+// Item cũ chưa có status được coi là Doing
+func checkStatusTransition(current *model.TodoItem, dataUpdate *model.TodoItemUpdate) error {
+	if dataUpdate.Status == nil {
+		return nil
+	}
+
+	from := model.ItemStatusDoing
+
+	if current.Status != nil {
+		from = *current.Status
+	}
+
+	to := *dataUpdate.Status
+
+	if !from.CanTransitionTo(to) {
+		return common.ErrInvalidStateTransition(model.EntityName, from.String(), to.String(), model.ErrInvalidStatusTransition)
 	}
 
 	return nil
@@ -0,0 +1,248 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// mockUpdateItemStorage đọc item từ items và ghi lại các lần ghi (update lẫn replace), writeErr khác nil thì ghi lỗi
+type mockUpdateItemStorage struct {
+	items    map[int]model.TodoItem
+	writes   []*model.TodoItemUpdate
+	conds    []map[string]interface{}
+	writeErr error
+}
+
+func (s *mockUpdateItemStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error) {
+	item, ok := s.items[cond["id"].(int)]
+
+	if !ok {
+		return nil, common.RecordNotFound
+	}
+
+	return &item, nil
+}
+
+func (s *mockUpdateItemStorage) UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	s.writes = append(s.writes, dataUpdate)
+	s.conds = append(s.conds, cond)
+
+	return s.writeErr
+}
+
+func (s *mockUpdateItemStorage) ReplaceItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	return s.UpdateItem(ctx, cond, dataUpdate)
+}
+
+func newTestUpdateItemBiz(store UpdateItemStorage, userId int) *updateItemBiz {
+	return NewUpdateItemBiz(store, false, model.LengthLimits{}, nil, common.NewRequester(userId))
+}
+
+func TestUpdateItemById(t *testing.T) {
+	done := model.ItemStatusDone
+
+	tests := []struct {
+		name       string
+		id         int
+		wantStatus int
+		wantWrites int
+	}{
+		{"found", 1, 0, 1},
+		{"not found", 2, http.StatusNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: newTestItem(1, 1, model.ItemStatusDoing)}}
+
+			err := newTestUpdateItemBiz(store, 1).UpdateItemById(context.Background(), tt.id, &model.TodoItemUpdate{Status: &done})
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantStatus != 0 && common.ToAppError(err).StatusCode != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if len(store.writes) != tt.wantWrites {
+				t.Fatalf("writes = %d, want %d", len(store.writes), tt.wantWrites)
+			}
+
+			if tt.wantWrites > 0 && (store.writes[0].Title != nil || store.writes[0].Description != nil) {
+				t.Errorf("update touches fields that were not sent: %+v", store.writes[0])
+			}
+		})
+	}
+}
+
+func TestUpdateItemByIdCompletedAt(t *testing.T) {
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	title := "buy bread"
+
+	tests := []struct {
+		name       string
+		current    model.ItemStatus
+		update     model.TodoItemUpdate
+		wantSet    bool
+		wantClear  bool
+		wantAbsent bool
+	}{
+		{"set on completion", model.ItemStatusDoing, model.TodoItemUpdate{Status: &done}, true, false, false},
+		{"cleared on reopen", model.ItemStatusDone, model.TodoItemUpdate{Status: &doing}, false, true, false},
+		{"untouched on unrelated field", model.ItemStatusDone, model.TodoItemUpdate{Title: &title}, false, false, true},
+		{"untouched when staying done", model.ItemStatusDone, model.TodoItemUpdate{Status: &done}, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: newTestItem(1, 1, tt.current)}}
+			update := tt.update
+
+			if err := newTestUpdateItemBiz(store, 1).UpdateItemById(context.Background(), 1, &update); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			completedAt := store.writes[0].CompletedAt
+
+			switch {
+			case tt.wantAbsent && completedAt != nil:
+				t.Errorf("completed_at = %+v, want untouched", completedAt)
+			case tt.wantSet && (completedAt == nil || !completedAt.Valid || completedAt.Time.IsZero()):
+				t.Errorf("completed_at = %+v, want set", completedAt)
+			case tt.wantClear && (completedAt == nil || completedAt.Valid):
+				t.Errorf("completed_at = %+v, want cleared", completedAt)
+			}
+		})
+	}
+}
+
+func TestUpdateItemByIdVersion(t *testing.T) {
+	title := "buy bread"
+	current, stale := 3, 2
+
+	tests := []struct {
+		name       string
+		version    *int
+		writeErr   error
+		wantStatus int
+		wantWrites int
+	}{
+		{"current version", &current, nil, 0, 1},
+		{"version omitted", nil, nil, 0, 1},
+		{"stale version", &stale, nil, http.StatusConflict, 0},
+		{"modified between read and write", &current, common.RecordNotFound, http.StatusConflict, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, model.ItemStatusDoing)
+			item.Version = current
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}, writeErr: tt.writeErr}
+
+			err := newTestUpdateItemBiz(store, 1).UpdateItemById(context.Background(), 1, &model.TodoItemUpdate{Title: &title, Version: tt.version})
+
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantStatus != 0 && common.ToAppError(err).StatusCode != tt.wantStatus {
+				t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+			}
+
+			if len(store.writes) != tt.wantWrites {
+				t.Fatalf("writes = %d, want %d", len(store.writes), tt.wantWrites)
+			}
+
+			if tt.wantWrites == 0 {
+				return
+			}
+
+			if store.conds[0]["version"] != current || *store.writes[0].Version != current+1 {
+				t.Errorf("write where version = %v set version = %d, want %d and %d", store.conds[0]["version"], *store.writes[0].Version, current, current+1)
+			}
+		})
+	}
+}
+
+func TestUpdateItemByIdStatusTransition(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       *model.ItemStatus
+		to         model.ItemStatus
+		wantStatus int
+		wantKey    string
+	}{
+		{"doing to done", statusOf(model.ItemStatusDoing), model.ItemStatusDone, 0, ""},
+		{"done to doing", statusOf(model.ItemStatusDone), model.ItemStatusDoing, 0, ""},
+		{"doing to deleted", statusOf(model.ItemStatusDoing), model.ItemStatusDeleted, 0, ""},
+		{"legacy without status to done", nil, model.ItemStatusDone, 0, ""},
+		{"deleted to done", statusOf(model.ItemStatusDeleted), model.ItemStatusDone, http.StatusConflict, "ErrInvalidItemStatusTransition"},
+		{"deleted to doing", statusOf(model.ItemStatusDeleted), model.ItemStatusDoing, http.StatusConflict, "ErrInvalidItemStatusTransition"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := newTestItem(1, 1, model.ItemStatusDoing)
+			item.Status = tt.from
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: item}}
+			to := tt.to
+
+			err := newTestUpdateItemBiz(store, 1).UpdateItemById(context.Background(), 1, &model.TodoItemUpdate{Status: &to})
+
+			if tt.wantStatus == 0 {
+				if err != nil || len(store.writes) != 1 {
+					t.Fatalf("err = %v, writes = %d, want one write", err, len(store.writes))
+				}
+
+				return
+			}
+
+			appErr := common.ToAppError(err)
+
+			if appErr.StatusCode != tt.wantStatus || appErr.Key != tt.wantKey || len(store.writes) != 0 {
+				t.Errorf("error = %d %s, writes = %d, want %d %s and no write", appErr.StatusCode, appErr.Key, len(store.writes), tt.wantStatus, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestUpdateItemByIdLengthLimits(t *testing.T) {
+	limits := model.LengthLimits{Title: 5, Description: 10}
+
+	tests := []struct {
+		name       string
+		title      string
+		wantStatus int
+	}{
+		{"at the limit", "ănmọc", 0},
+		{"one rune over", "ănmọcx", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockUpdateItemStorage{items: map[int]model.TodoItem{1: newTestItem(1, 1, model.ItemStatusDoing)}}
+			business := NewUpdateItemBiz(store, false, limits, nil, common.NewRequester(1))
+			title := tt.title
+
+			err := business.UpdateItemById(context.Background(), 1, &model.TodoItemUpdate{Title: &title})
+
+			if tt.wantStatus == 0 {
+				if err != nil || len(store.writes) != 1 {
+					t.Fatalf("err = %v, writes = %d, want one write", err, len(store.writes))
+				}
+
+				return
+			}
+
+			if common.ToAppError(err).StatusCode != tt.wantStatus || len(store.writes) != 0 {
+				t.Errorf("err = %v, writes = %d, want %d and no write", err, len(store.writes), tt.wantStatus)
+			}
+		})
+	}
+}
+
+func statusOf(status model.ItemStatus) *model.ItemStatus { return &status }
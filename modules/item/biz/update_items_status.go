@@ -0,0 +1,54 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+type UpdateItemsStatusStorage interface {
+	UpdateItemsStatus(
+		ctx context.Context,
+		cond map[string]interface{},
+		ids []int,
+		status model.ItemStatus,
+		completedAt *time.Time,
+		updatedBy int,
+	) (int64, error)
+}
+
+type updateItemsStatusBiz struct {
+	store     UpdateItemsStatusStorage
+	requester common.Requester
+}
+
+func NewUpdateItemsStatusBiz(store UpdateItemsStatusStorage, requester common.Requester) *updateItemsStatusBiz {
+	return &updateItemsStatusBiz{store: store, requester: requester}
+}
+
+// UpdateItemsStatus trả về số item thực sự được cập nhật, id không thuộc requester hoặc
+// không tồn tại sẽ được bỏ qua
+func (biz *updateItemsStatusBiz) UpdateItemsStatus(ctx context.Context, data *model.TodoItemsStatusUpdate) (int64, error) {
+	if err := data.Validate(); err != nil {
+		return 0, common.ErrInvalidRequest(err)
+	}
+
+	var completedAt *time.Time
+
+	if *data.Status == model.ItemStatusDone {
+		now := time.Now().UTC()
+		completedAt = &now
+	}
+
+	userId := biz.requester.GetUserId()
+	cond := map[string]interface{}{"user_id": userId}
+
+	updated, err := biz.store.UpdateItemsStatus(ctx, cond, data.Ids, *data.Status, completedAt, userId)
+
+	if err != nil {
+		return 0, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,59 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	usermodel "social-todo-list/modules/user/model"
+)
+
+// UserStorage lấy thông tin user (owner, người sửa item, người được giao item), được implement bởi storage của module user
+type UserStorage interface {
+	GetUsers(ctx context.Context, ids []int) ([]usermodel.UserInfo, error)
+}
+
+// fillUsers gán Owner và UpdatedByUser cho các item bằng một query, không tìm thấy user thì để nil
+func fillUsers(ctx context.Context, store UserStorage, items ...*model.TodoItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(items))
+	seen := make(map[int]bool, len(items))
+
+	addId := func(id int) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, item := range items {
+		addId(item.UserId)
+
+		if item.UpdatedBy != nil {
+			addId(*item.UpdatedBy)
+		}
+	}
+
+	users, err := store.GetUsers(ctx, ids)
+
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[int]*usermodel.UserInfo, len(users))
+
+	for i := range users {
+		byId[users[i].Id] = &users[i]
+	}
+
+	for _, item := range items {
+		item.Owner = byId[item.UserId]
+
+		if item.UpdatedBy != nil {
+			item.UpdatedByUser = byId[*item.UpdatedBy]
+		}
+	}
+
+	return nil
+}
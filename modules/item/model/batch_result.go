@@ -0,0 +1,11 @@
+package model
+
+import "social-todo-list/common"
+
+// BatchItemResult là kết quả của từng item trong batch best effort (cùng thứ tự với input):
+// tạo được thì có Id, không thì có Error
+type BatchItemResult struct {
+	Index int         `json:"index"`
+	Id    *common.UID `json:"id,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
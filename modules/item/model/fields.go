@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidField = errors.New("invalid field")
+)
+
+// Các field client được chọn qua ?fields=, key là tên trong JSON và value là cột dưới DB
+var selectableFields = map[string]string{
+	"id":              "id",
+	"user_id":         "user_id",
+	"title":           "title",
+	"description":     "description",
+	"status":          "status",
+	"completed_at":    "completed_at",
+	"tags":            "tags",
+	"due_date":        "due_date",
+	"priority":        "priority",
+	"image":           "image",
+	"version":         "version",
+	"position":        "position",
+	"archived":        "archived",
+	"recurrence_rule": "recurrence_rule",
+	"created_at":      "created_at",
+	"updated_at":      "updated_at",
+}
+
+// Biz luôn cần các cột này (mask id, kiểm tra quyền, status đã xoá, ETag) nên luôn được SELECT
+var requiredColumns = []string{"id", "user_id", "status", "version", "updated_at"}
+
+// ParseFields tách danh sách field phân cách bởi dấu phẩy, rỗng thì trả về nil (lấy đủ field)
+func ParseFields(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+
+		if _, ok := selectableFields[name]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidField, name)
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			fields = append(fields, name)
+		}
+	}
+
+	return fields, nil
+}
+
+// FieldColumns trả về các cột cần SELECT cho fields, fields rỗng thì trả về nil (SELECT *)
+func FieldColumns(fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	columns := append([]string{}, requiredColumns...)
+
+	for _, name := range fields {
+		column := selectableFields[name]
+		found := false
+
+		for _, c := range columns {
+			if c == column {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
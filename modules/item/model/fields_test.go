@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      string
+		want        []string
+		wantErr     error
+		wantColumns []string
+	}{
+		{"empty", "", nil, nil, nil},
+		{"subset", "id,title", []string{"id", "title"}, nil, []string{"id", "user_id", "status", "version", "updated_at", "title"}},
+		{"spaces and duplicates", " title , title,status", []string{"title", "status"}, nil, []string{"id", "user_id", "status", "version", "updated_at", "title"}},
+		{"unknown field", "id,password", nil, ErrInvalidField, nil},
+		{"trailing comma", "id,", nil, ErrInvalidField, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFields(tt.fields)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+
+			if columns := FieldColumns(got); !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("columns = %v, want %v", columns, tt.wantColumns)
+			}
+		})
+	}
+}
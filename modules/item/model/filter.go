@@ -1,5 +1,195 @@
 package model
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSortColumn = errors.New("invalid sort column")
+	ErrInvalidSortOrder  = errors.New("invalid sort order")
+	ErrInvalidFilter     = errors.New("invalid filter")
+	ErrCursorWithSort    = errors.New("cursor paging only supports the default order (id desc)")
+)
+
+// SortSmart sort theo hạn: quá hạn trước, rồi due_date tăng dần (không có hạn xếp sau),
+// priority giảm dần, created_at tăng dần. Sort này không dùng Order
+const SortSmart = "smart"
+
+// Chỉ cho phép sort theo các cột này để tránh SQL injection qua mệnh đề ORDER BY
+var allowedSortColumns = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+	"status":     true,
+	"priority":   true,
+	"position":   true,
+}
+
 type Filter struct {
-	Status string `json:"status" form:"status"`
+	UserId   int      `json:"-" xml:"-" form:"-"`
+	Status   []string `json:"status,omitempty" xml:"status>value,omitempty" form:"status"`
+	Priority []string `json:"priority,omitempty" xml:"priority>value,omitempty" form:"priority"`
+	Search   string   `json:"search,omitempty" xml:"search,omitempty" form:"search"`
+	Tag      string   `json:"tag,omitempty" xml:"tag,omitempty" form:"tag"`
+	// Overdue chỉ lấy item đã quá hạn mà chưa Done
+	Overdue bool `json:"overdue,omitempty" xml:"overdue,omitempty" form:"overdue"`
+	// DueToday chỉ lấy item có due_date trong ngày hôm nay theo Timezone
+	DueToday bool `json:"due_today,omitempty" xml:"due_today,omitempty" form:"due_today"`
+	// DueBefore/DueAfter lấy item có due_date < DueBefore và >= DueAfter, item không có hạn bị loại
+	DueBefore *time.Time `json:"due_before,omitempty" xml:"due_before,omitempty" form:"due_before"`
+	DueAfter  *time.Time `json:"due_after,omitempty" xml:"due_after,omitempty" form:"due_after"`
+	// Timezone là tên IANA dùng để tính "hôm nay" cho các filter theo ngày, rỗng là UTC
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty" form:"timezone"`
+	// Mặc định không lấy item đã archive, IncludeArchived lấy cả hai, OnlyArchived chỉ lấy item đã archive
+	IncludeArchived bool   `json:"include_archived,omitempty" xml:"include_archived,omitempty" form:"include_archived"`
+	OnlyArchived    bool   `json:"only_archived,omitempty" xml:"only_archived,omitempty" form:"only_archived"`
+	Sort            string `json:"sort,omitempty" xml:"sort,omitempty" form:"sort"`
+	Order           string `json:"order,omitempty" xml:"order,omitempty" form:"order"`
+	// Fields là danh sách field cần lấy, phân cách bởi dấu phẩy, rỗng là lấy hết
+	Fields string `json:"-" xml:"-" form:"fields"`
+}
+
+// ApplyJSON đọc filter dạng JSON (query ?filter=) đè lên các field đã bind từ query param riêng lẻ,
+// key của JSON giống key của filter trong response. Key lạ hoặc JSON sai trả về ErrInvalidFilter
+func (f *Filter) ApplyJSON(raw string) error {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(f); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidFilter, err)
+	}
+
+	// Chỉ nhận đúng một object
+	if decoder.More() {
+		return fmt.Errorf("%w: unexpected data after the filter object", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+func (f *Filter) Validate() error {
+	if f.Sort != "" && f.Sort != SortSmart && !allowedSortColumns[f.Sort] {
+		return fmt.Errorf("%w: %q", ErrInvalidSortColumn, f.Sort)
+	}
+
+	if order := strings.ToLower(f.Order); order != "" && order != "asc" && order != "desc" {
+		return fmt.Errorf("%w: %q, must be asc or desc", ErrInvalidSortOrder, f.Order)
+	}
+
+	if _, err := f.Statuses(); err != nil {
+		return err
+	}
+
+	if _, err := f.Priorities(); err != nil {
+		return err
+	}
+
+	if _, err := ParseFields(f.Fields); err != nil {
+		return err
+	}
+
+	if _, err := common.LoadTimezone(f.Timezone); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FieldList trả về các field đã chọn qua ?fields=, nil nếu không chọn
+func (f *Filter) FieldList() []string {
+	if f == nil {
+		return nil
+	}
+
+	fields, _ := ParseFields(f.Fields)
+
+	return fields
+}
+
+// Location là timezone của filter, timezone không hợp lệ (chưa Validate) thì dùng UTC
+func (f *Filter) Location() *time.Location {
+	if f == nil {
+		return time.UTC
+	}
+
+	loc, err := common.LoadTimezone(f.Timezone)
+
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Statuses chuyển tên status trong filter thành giá trị lưu dưới DB
+func (f *Filter) Statuses() ([]ItemStatus, error) {
+	result := make([]ItemStatus, 0, len(f.Status))
+
+	for _, name := range f.Status {
+		s, err := parseStr2ItemStatus(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, s)
+	}
+
+	return result, nil
+}
+
+// Priorities chuyển tên priority trong filter thành giá trị lưu dưới DB
+func (f *Filter) Priorities() ([]ItemPriority, error) {
+	result := make([]ItemPriority, 0, len(f.Priority))
+
+	for _, name := range f.Priority {
+		p, err := ParseItemPriority(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, p)
+	}
+
+	return result, nil
+}
+
+// SupportsCursor cho biết thứ tự của filter có phân trang bằng cursor được không,
+// cursor là id nên chỉ dùng được với thứ tự mặc định id desc
+func (f *Filter) SupportsCursor() bool {
+	return f == nil || f.OrderBy() == "id desc" && f.Sort != SortSmart
+}
+
+// OrderBy trả về mệnh đề ORDER BY, mặc định là id desc.
+// Khi sort theo cột khác id thì thêm id desc để thứ tự giữa các trang luôn ổn định.
+func (f *Filter) OrderBy() string {
+	column, order := "id", "desc"
+
+	if f == nil {
+		return column + " " + order
+	}
+
+	if f.Sort != "" && f.Sort != SortSmart {
+		column = f.Sort
+	}
+
+	if f.Order != "" {
+		order = strings.ToLower(f.Order)
+	} else if column == "position" {
+		// Thứ tự tự sắp xếp đọc từ trên xuống nên mặc định là asc
+		order = "asc"
+	}
+
+	if column != "id" {
+		return fmt.Sprintf("%s %s, id desc", column, order)
+	}
+
+	return column + " " + order
 }
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"social-todo-list/common"
+	"testing"
+	"time"
+)
+
+func TestFilterSort(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      Filter
+		wantErr     error
+		wantOrderBy string
+	}{
+		{"default", Filter{}, nil, "id desc"},
+		{"id asc", Filter{Sort: "id", Order: "asc"}, nil, "id asc"},
+		{"created_at desc", Filter{Sort: "created_at", Order: "desc"}, nil, "created_at desc, id desc"},
+		{"title asc", Filter{Sort: "title", Order: "ASC"}, nil, "title asc, id desc"},
+		{"status without order", Filter{Sort: "status"}, nil, "status desc, id desc"},
+		{"position defaults to asc", Filter{Sort: "position"}, nil, "position asc, id desc"},
+		{"unknown column", Filter{Sort: "password"}, ErrInvalidSortColumn, ""},
+		{"injection", Filter{Sort: "id; DROP TABLE todo_items"}, ErrInvalidSortColumn, ""},
+		{"unknown order", Filter{Sort: "id", Order: "sideways"}, ErrInvalidSortOrder, ""},
+		{"smart", Filter{Sort: SortSmart}, nil, "id desc"},
+		{"valid timezone", Filter{Timezone: "America/Los_Angeles"}, nil, "id desc"},
+		{"invalid timezone", Filter{Timezone: "Mars/Olympus"}, common.ErrInvalidTimezone, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && tt.filter.OrderBy() != tt.wantOrderBy {
+				t.Errorf("OrderBy = %q, want %q", tt.filter.OrderBy(), tt.wantOrderBy)
+			}
+		})
+	}
+}
+
+func TestFilterApplyJSON(t *testing.T) {
+	dueBefore := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr error
+		want    Filter
+	}{
+		{
+			"combined conditions",
+			`{"status":["Doing"],"priority":["High"],"due_before":"2024-12-01T00:00:00Z"}`,
+			nil,
+			Filter{UserId: 1, Status: []string{"Doing"}, Priority: []string{"High"}, Tag: "home", DueBefore: &dueBefore},
+		},
+		{"json overrides the query param", `{"tag":"work"}`, nil, Filter{UserId: 1, Tag: "work"}},
+		{"empty object keeps the query params", `{}`, nil, Filter{UserId: 1, Tag: "home"}},
+		{"unknown key", `{"owner":1}`, ErrInvalidFilter, Filter{}},
+		{"user_id cannot be set", `{"UserId":2}`, ErrInvalidFilter, Filter{}},
+		{"malformed json", `{"status":["Doing"`, ErrInvalidFilter, Filter{}},
+		{"wrong type", `{"status":"Doing"}`, ErrInvalidFilter, Filter{}},
+		{"invalid date", `{"due_before":"tomorrow"}`, ErrInvalidFilter, Filter{}},
+		{"trailing data", `{"tag":"a"} {"tag":"b"}`, ErrInvalidFilter, Filter{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := Filter{UserId: 1, Tag: "home"}
+			err := filter.ApplyJSON(tt.raw)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && !reflect.DeepEqual(filter, tt.want) {
+				t.Errorf("filter = %+v, want %+v", filter, tt.want)
+			}
+		})
+	}
+}
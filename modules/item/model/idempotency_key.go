@@ -0,0 +1,34 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	idempotencyKeyMaxLen = 255
+)
+
+var (
+	ErrIdempotencyKeyTooLong = errors.New("idempotency key is too long")
+)
+
+// IdempotencyKey lưu key client gửi lên khi tạo item và id của item đã tạo, key tính riêng theo user
+type IdempotencyKey struct {
+	UserId    int        `gorm:"column:user_id;primaryKey;autoIncrement:false;"`
+	Key       string     `gorm:"column:idempotency_key;size:255;primaryKey;"`
+	ItemId    int        `gorm:"column:item_id;"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;index;"`
+	CreatedAt *time.Time `gorm:"column:created_at;"`
+}
+
+func (IdempotencyKey) TableName() string { return "item_idempotency_keys" }
+
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > idempotencyKeyMaxLen {
+		return ErrIdempotencyKeyTooLong
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package model
+
+import "social-todo-list/common"
+
+type ImportItemError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// ImportItemsReport là kết quả import: id các item đã tạo và lỗi của từng item bị bỏ qua (theo index)
+type ImportItemsReport struct {
+	Imported int               `json:"imported"`
+	Ids      []common.UID      `json:"ids"`
+	Errors   []ImportItemError `json:"errors"`
+}
@@ -1,37 +1,293 @@
 package model
 
 import (
+	"database/sql"
 	"errors"
+	"gorm.io/gorm"
 	"social-todo-list/common"
+	usermodel "social-todo-list/modules/user/model"
+	"strings"
+	"time"
+)
+
+const (
+	EntityName = "Item"
 )
 
 var (
-	ErrTitleIsBlank = errors.New("title cannot be blank")
-	ErrItemDeleted  = errors.New("item is deleted")
+	ErrTitleIsBlank            = errors.New("title cannot be blank")
+	ErrItemDeleted             = errors.New("item is deleted")
+	ErrInvalidStatus           = errors.New("invalid status")
+	ErrItemsIsEmpty            = errors.New("items cannot be empty")
+	ErrItemIsNull              = errors.New("item cannot be null")
+	ErrIdsIsEmpty              = errors.New("ids cannot be empty")
+	ErrStatusIsBlank           = errors.New("status cannot be blank")
+	ErrDueDateInPast           = errors.New("due date must be in the future")
+	ErrVersionConflict         = errors.New("item version does not match")
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrDailyQuotaExceeded      = errors.New("daily item creation quota exceeded")
+	ErrTitleDuplicated         = errors.New("an item with the same title already exists")
+	ErrNotItemOwner            = errors.New("item belongs to another user")
 )
 
 type TodoItem struct {
 	common.SQLModel
-	Title       string      `json:"title" gorm:"column:title;"`
-	Description string      `json:"description" gorm:"column:description;"`
-	Status      *ItemStatus `json:"status" gorm:"column:status;"`
+	UserId      int           `json:"-" xml:"-" gorm:"column:user_id;index;"`
+	FakeUserId  *common.UID   `json:"user_id" xml:"user_id" gorm:"-"`
+	Title       string        `json:"title" xml:"title" gorm:"column:title;size:255;index;"`
+	Description string        `json:"description" xml:"description" gorm:"column:description;type:text;"`
+	Status      *ItemStatus   `json:"status" xml:"status" gorm:"column:status;index;"`
+	CompletedAt *time.Time    `json:"completed_at,omitempty" xml:"completed_at,omitempty" gorm:"column:completed_at;"`
+	Tags        ItemTags      `json:"tags" xml:"tags>tag,omitempty" gorm:"column:tags;"`
+	DueDate     *time.Time    `json:"due_date,omitempty" xml:"due_date,omitempty" gorm:"column:due_date;index;"`
+	Priority    *ItemPriority `json:"priority" xml:"priority" gorm:"column:priority;index;"`
+	Image       *common.Image `json:"image,omitempty" xml:"image,omitempty" gorm:"column:image;"`
+	// Version tăng mỗi lần item bị sửa, client gửi lại khi update để không ghi đè thay đổi của người khác
+	Version    int                 `json:"version" xml:"version" gorm:"column:version;not null;default:1;"`
+	LikedCount int                 `json:"liked_count" xml:"liked_count" gorm:"-"`
+	HasLiked   bool                `json:"has_liked" xml:"has_liked" gorm:"-"`
+	Owner      *usermodel.UserInfo `json:"owner,omitempty" xml:"owner,omitempty" gorm:"-"`
+	// UpdatedBy là user sửa item gần nhất (lúc tạo là người tạo), item tạo trước khi có cột này thì để trống
+	UpdatedBy     *int                `json:"-" xml:"-" gorm:"column:updated_by;"`
+	FakeUpdatedBy *common.UID         `json:"updated_by,omitempty" xml:"updated_by,omitempty" gorm:"-"`
+	UpdatedByUser *usermodel.UserInfo `json:"updated_by_user,omitempty" xml:"updated_by_user,omitempty" gorm:"-"`
+	// Position là thứ tự do user tự sắp xếp (sort=position), item mới luôn ở cuối
+	Position float64 `json:"position" xml:"position" gorm:"column:position;not null;default:0;index;"`
+	// RemindedAt là lúc đã gửi nhắc nhở sắp đến hạn, mỗi item chỉ được nhắc một lần
+	RemindedAt *time.Time `json:"-" xml:"-" gorm:"column:reminded_at;"`
+	// Archived ẩn item khỏi list mặc định mà không xoá, không liên quan tới status
+	Archived bool `json:"archived" xml:"archived" gorm:"column:archived;not null;default:false;index;"`
+	// RecurrenceRule khác rỗng thì khi item Done, job sẽ sinh item cho lần lặp kế tiếp
+	RecurrenceRule RecurrenceRule `json:"recurrence_rule,omitempty" xml:"recurrence_rule,omitempty" gorm:"column:recurrence_rule;size:20;not null;default:'';"`
+	// NextOccurrenceId là item đã được sinh cho lần lặp kế tiếp, khác NULL thì job không sinh thêm
+	NextOccurrenceId *int `json:"-" xml:"-" gorm:"column:next_occurrence_id;"`
+	// Progress là tỉ lệ subtask đã xong (0..1), không có subtask thì bỏ trống
+	Progress *float64 `json:"progress,omitempty" xml:"progress,omitempty" gorm:"-"`
 }
 
 func (TodoItem) TableName() string { return "todo_items" }
 
+// Mask đổi id của item và các user id sang fake id, id thật không được trả cho client
+func (i *TodoItem) Mask() {
+	i.SQLModel.Mask(common.DbTypeItem)
+
+	userId := common.NewUID(uint32(i.UserId), common.DbTypeUser, 1)
+	i.FakeUserId = &userId
+
+	if i.UpdatedBy != nil {
+		updatedBy := common.NewUID(uint32(*i.UpdatedBy), common.DbTypeUser, 1)
+		i.FakeUpdatedBy = &updatedBy
+	}
+
+	if i.Owner != nil {
+		i.Owner.Mask()
+	}
+
+	if i.UpdatedByUser != nil {
+		i.UpdatedByUser.Mask()
+	}
+}
+
+// ETag đổi mỗi khi item được update (updated_at, version), số like hoặc progress thay đổi
+func (i *TodoItem) ETag() string {
+	var updatedAt int64
+
+	if i.UpdatedAt != nil {
+		updatedAt = i.UpdatedAt.UnixNano()
+	}
+
+	var progress float64 = -1
+
+	if i.Progress != nil {
+		progress = *i.Progress
+	}
+
+	return common.NewETag(i.Id, updatedAt, i.Version, i.LikedCount, i.HasLiked, progress)
+}
+
 type TodoItemCreation struct {
-	Id          int         `json:"-" gorm:"column:id;"`
-	Title       string      `json:"title" gorm:"column:title;"`
-	Description string      `json:"description" gorm:"column:description;"`
-	Status      *ItemStatus `json:"status" gorm:"column:status;"`
+	Id          int           `json:"-" gorm:"column:id;"`
+	UserId      int           `json:"-" gorm:"column:user_id;"`
+	UpdatedBy   *int          `json:"-" gorm:"column:updated_by;"`
+	Title       string        `json:"title" gorm:"column:title;"`
+	Description string        `json:"description" gorm:"column:description;"`
+	Status      *ItemStatus   `json:"status" gorm:"column:status;"`
+	Tags        ItemTags      `json:"tags" gorm:"column:tags;"`
+	DueDate     *time.Time    `json:"due_date" gorm:"column:due_date;"`
+	Priority    *ItemPriority `json:"priority" gorm:"column:priority;"`
+	Image       *common.Image `json:"image" gorm:"column:image;"`
+	// RecurrenceRule là DAILY, WEEKLY hoặc MONTHLY, để trống là không lặp
+	RecurrenceRule RecurrenceRule `json:"recurrence_rule" gorm:"column:recurrence_rule;"`
+	// Position do storage gán lúc insert để item mới nằm cuối danh sách
+	Position  float64    `json:"-" gorm:"column:position;"`
+	CreatedAt *time.Time `json:"-" gorm:"column:created_at;"`
+	UpdatedAt *time.Time `json:"-" gorm:"column:updated_at;"`
+	// CompletedAt được set lúc insert nếu item tạo ra đã ở trạng thái Done
+	CompletedAt *time.Time `json:"-" gorm:"column:completed_at;"`
+	// AllowDuplicate cho phép tạo item trùng title với item đang có, lấy từ query ?allow_duplicate=true
+	AllowDuplicate bool `json:"-" gorm:"-"`
+	// DailyQuota > 0 là số item tối đa user được tạo mỗi ngày, storage kiểm tra trong transaction insert
+	DailyQuota int `json:"-" gorm:"-"`
 }
 
 func (TodoItemCreation) TableName() string { return TodoItem{}.TableName() }
 
+// Validate kiểm tra hết các field rồi mới trả lỗi, lỗi là *common.ValidationError chứa lỗi của từng field
+func (i *TodoItemCreation) Validate(limits LengthLimits) error {
+	validationErr := common.NewValidationError()
+	i.Title = strings.TrimSpace(i.Title)
+
+	if i.Title == "" {
+		validationErr.Add("title", ErrTitleIsBlank)
+	}
+
+	limits.validate(validationErr, &i.Title, &i.Description)
+
+	if i.Status != nil && (!i.Status.IsValid() || *i.Status == ItemStatusDeleted) {
+		validationErr.Add("status", ErrInvalidStatus)
+	}
+
+	if i.Priority == nil {
+		priority := ItemPriorityMedium
+		i.Priority = &priority
+	} else if !i.Priority.IsValid() {
+		validationErr.Add("priority", ErrInvalidPriority)
+	}
+
+	i.Tags = i.Tags.Normalize()
+
+	if !i.RecurrenceRule.IsValid() {
+		validationErr.Add("recurrence_rule", ErrInvalidRecurrenceRule)
+	}
+
+	if i.DueDate != nil {
+		if !i.DueDate.After(time.Now()) {
+			validationErr.Add("due_date", ErrDueDateInPast)
+		} else {
+			dueDate := i.DueDate.UTC()
+			i.DueDate = &dueDate
+		}
+	}
+
+	return validationErr.Err()
+}
+
+// ApplyDefaultStatus gán status khi client không gửi status, gọi trước Validate
+func (i *TodoItemCreation) ApplyDefaultStatus(status ItemStatus) {
+	if i.Status == nil {
+		i.Status = &status
+	}
+}
+
+// Sanitize làm sạch title/description bằng sanitize, gọi trước Validate
+func (i *TodoItemCreation) Sanitize(sanitize func(string) string) {
+	i.Title = sanitize(i.Title)
+	i.Description = sanitize(i.Description)
+}
+
+func (i *TodoItemCreation) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	i.CreatedAt = &now
+	i.UpdatedAt = &now
+
+	if i.Status != nil && *i.Status == ItemStatusDone && i.CompletedAt == nil {
+		i.CompletedAt = &now
+	}
+
+	// Item mới thì người sửa gần nhất chính là người tạo
+	if i.UpdatedBy == nil {
+		updatedBy := i.UserId
+		i.UpdatedBy = &updatedBy
+	}
+
+	return nil
+}
+
 type TodoItemUpdate struct {
-	Title       *string `json:"title" gorm:"column:title;"`
-	Description *string `json:"description" gorm:"column:description;"`
-	Status      *string `json:"status" gorm:"column:status;"`
+	Title       *string       `json:"title" gorm:"column:title;"`
+	Description *string       `json:"description" gorm:"column:description;"`
+	Status      *ItemStatus   `json:"status" gorm:"column:status;"`
+	Priority    *ItemPriority `json:"priority" gorm:"column:priority;"`
+	Image       *common.Image `json:"image" gorm:"column:image;"`
+	// Gửi "" để item thôi lặp lại
+	RecurrenceRule *RecurrenceRule `json:"recurrence_rule" gorm:"column:recurrence_rule;"`
+	// Tags và DueDate chỉ đổi qua PUT, DueDate dùng NullTime giống CompletedAt để có thể xoá due date
+	Tags    *ItemTags     `json:"-" gorm:"column:tags;"`
+	DueDate *sql.NullTime `json:"-" gorm:"column:due_date;"`
+	// Archived chỉ đổi qua API archive/unarchive
+	Archived *bool `json:"-" gorm:"column:archived;"`
+	// UserId chỉ đổi qua API assign
+	UserId *int `json:"-" gorm:"column:user_id;"`
+	// UpdatedBy do biz set bằng requester
+	UpdatedBy *int `json:"-" gorm:"column:updated_by;"`
+	// Client gửi version đang có (không bắt buộc), biz đổi thành version mới trước khi ghi xuống DB
+	Version   *int       `json:"version" gorm:"column:version;"`
+	UpdatedAt *time.Time `json:"-" gorm:"column:updated_at;"`
+	// Biz tự set theo status. Dùng NullTime để có thể set completed_at về NULL:
+	// con trỏ khác nil nên GORM không bỏ qua, còn Valid = false thì ghi NULL
+	CompletedAt *sql.NullTime `json:"-" gorm:"column:completed_at;"`
 }
 
 func (TodoItemUpdate) TableName() string { return TodoItem{}.TableName() }
+
+// Sanitize làm sạch title/description (nếu có gửi lên) bằng sanitize
+func (i *TodoItemUpdate) Sanitize(sanitize func(string) string) {
+	if i.Title != nil {
+		title := sanitize(*i.Title)
+		i.Title = &title
+	}
+
+	if i.Description != nil {
+		description := sanitize(*i.Description)
+		i.Description = &description
+	}
+}
+
+// Validate chỉ kiểm tra độ dài của title/description nếu có gửi lên
+func (i *TodoItemUpdate) Validate(limits LengthLimits) error {
+	validationErr := common.NewValidationError()
+	limits.validate(validationErr, i.Title, i.Description)
+
+	return validationErr.Err()
+}
+
+func (i *TodoItemUpdate) BeforeUpdate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	i.UpdatedAt = &now
+
+	return nil
+}
+
+type TodoItemsStatusUpdate struct {
+	Ids    common.LocalIds `json:"ids"`
+	Status *ItemStatus     `json:"status"`
+}
+
+func (u *TodoItemsStatusUpdate) Validate() error {
+	if len(u.Ids) == 0 {
+		return ErrIdsIsEmpty
+	}
+
+	if u.Status == nil {
+		return ErrStatusIsBlank
+	}
+
+	// Xoá item phải đi qua API delete
+	if !u.Status.IsValid() || *u.Status == ItemStatusDeleted {
+		return ErrInvalidStatus
+	}
+
+	return nil
+}
+
+type TodoItemsDelete struct {
+	Ids common.LocalIds `json:"ids"`
+}
+
+func (d *TodoItemsDelete) Validate() error {
+	if len(d.Ids) == 0 {
+		return ErrIdsIsEmpty
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+var (
+	ErrAssigneeIsMissing = errors.New("user_id is required")
+	ErrAssigneeNotFound  = errors.New("assignee user does not exist")
+)
+
+// TodoItemAssign chuyển item cho user UserId (fake id hoặc id số)
+type TodoItemAssign struct {
+	UserId string `json:"user_id"`
+}
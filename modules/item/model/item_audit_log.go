@@ -0,0 +1,202 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"social-todo-list/common"
+	"time"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// ItemAuditLog ghi lại mỗi lần item bị tạo/sửa/xoá, được ghi cùng transaction với thay đổi
+type ItemAuditLog struct {
+	Id         int          `json:"-" gorm:"column:id;"`
+	ItemId     int          `json:"-" gorm:"column:item_id;index;"`
+	UserId     int          `json:"-" gorm:"column:user_id;"`
+	FakeUserId *common.UID  `json:"user_id" gorm:"-"`
+	Action     string       `json:"action" gorm:"column:action;size:20;"`
+	Changes    AuditChanges `json:"changes" gorm:"column:changes;"`
+	CreatedAt  *time.Time   `json:"created_at" gorm:"column:created_at;"`
+}
+
+func (ItemAuditLog) TableName() string { return "item_audit_log" }
+
+// Mask đổi user id của dòng log và của thay đổi owner (assign) sang fake id
+func (l *ItemAuditLog) Mask() {
+	uid := common.NewUID(uint32(l.UserId), common.DbTypeUser, 1)
+	l.FakeUserId = &uid
+
+	if change, ok := l.Changes["user_id"]; ok {
+		l.Changes["user_id"] = AuditChange{Old: maskUserId(change.Old), New: maskUserId(change.New)}
+	}
+}
+
+// maskUserId nhận user id đọc từ JSON trong DB (float64) hoặc còn trong bộ nhớ (int)
+func maskUserId(v interface{}) interface{} {
+	var id uint32
+
+	switch n := v.(type) {
+	case int:
+		id = uint32(n)
+	case float64:
+		id = uint32(n)
+	default:
+		return v
+	}
+
+	return common.NewUID(id, common.DbTypeUser, 1)
+}
+
+type AuditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditChanges là các field bị đổi (tên field trong JSON -> giá trị cũ/mới), lưu xuống DB dạng JSON
+type AuditChanges map[string]AuditChange
+
+func (AuditChanges) GormDataType() string {
+	return "text"
+}
+
+func (c *AuditChanges) Scan(value interface{}) error {
+	var bytes []byte
+
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes), nil
+}
+
+// CreationChanges là giá trị ban đầu của item mới tạo, bỏ qua các field để trống
+func CreationChanges(data *TodoItemCreation) AuditChanges {
+	changes := AuditChanges{"title": {New: data.Title}}
+
+	if data.Description != "" {
+		changes["description"] = AuditChange{New: data.Description}
+	}
+
+	if data.Status != nil {
+		changes["status"] = AuditChange{New: data.Status}
+	}
+
+	if data.Priority != nil {
+		changes["priority"] = AuditChange{New: data.Priority}
+	}
+
+	if len(data.Tags) > 0 {
+		changes["tags"] = AuditChange{New: data.Tags}
+	}
+
+	if data.DueDate != nil {
+		changes["due_date"] = AuditChange{New: data.DueDate}
+	}
+
+	if data.Image != nil {
+		changes["image"] = AuditChange{New: data.Image}
+	}
+
+	if data.RecurrenceRule != RecurrenceNone {
+		changes["recurrence_rule"] = AuditChange{New: data.RecurrenceRule}
+	}
+
+	return changes
+}
+
+// UpdateChanges so sánh item hiện tại với dữ liệu update, chỉ giữ các field thực sự đổi giá trị
+func UpdateChanges(current *TodoItem, data *TodoItemUpdate) AuditChanges {
+	changes := AuditChanges{}
+
+	if data.Title != nil && *data.Title != current.Title {
+		changes["title"] = AuditChange{Old: current.Title, New: *data.Title}
+	}
+
+	if data.Description != nil && *data.Description != current.Description {
+		changes["description"] = AuditChange{Old: current.Description, New: *data.Description}
+	}
+
+	if data.Status != nil && (current.Status == nil || *current.Status != *data.Status) {
+		changes["status"] = AuditChange{Old: current.Status, New: data.Status}
+	}
+
+	if data.Priority != nil && (current.Priority == nil || *current.Priority != *data.Priority) {
+		changes["priority"] = AuditChange{Old: current.Priority, New: data.Priority}
+	}
+
+	if data.Image != nil && (current.Image == nil || *current.Image != *data.Image) {
+		changes["image"] = AuditChange{Old: current.Image, New: data.Image}
+	}
+
+	if data.Archived != nil && *data.Archived != current.Archived {
+		changes["archived"] = AuditChange{Old: current.Archived, New: *data.Archived}
+	}
+
+	if data.UserId != nil && *data.UserId != current.UserId {
+		changes["user_id"] = AuditChange{Old: current.UserId, New: *data.UserId}
+	}
+
+	if data.RecurrenceRule != nil && *data.RecurrenceRule != current.RecurrenceRule {
+		changes["recurrence_rule"] = AuditChange{Old: current.RecurrenceRule, New: *data.RecurrenceRule}
+	}
+
+	if data.Tags != nil && !reflect.DeepEqual(data.Tags.Normalize(), current.Tags.Normalize()) {
+		changes["tags"] = AuditChange{Old: current.Tags, New: *data.Tags}
+	}
+
+	if data.DueDate != nil {
+		var dueDate *time.Time
+
+		if data.DueDate.Valid {
+			dueDate = &data.DueDate.Time
+		}
+
+		if (dueDate == nil) != (current.DueDate == nil) || dueDate != nil && !dueDate.Equal(*current.DueDate) {
+			changes["due_date"] = AuditChange{Old: current.DueDate, New: dueDate}
+		}
+	}
+
+	if data.CompletedAt != nil {
+		var completedAt *time.Time
+
+		if data.CompletedAt.Valid {
+			completedAt = &data.CompletedAt.Time
+		}
+
+		if (completedAt == nil) != (current.CompletedAt == nil) {
+			changes["completed_at"] = AuditChange{Old: current.CompletedAt, New: completedAt}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// DefaultCloneTitleSuffix được thêm vào title của bản sao khi client không gửi title_suffix
+const DefaultCloneTitleSuffix = " (copy)"
+
+// Clone là item mới chép từ item: title thêm suffix, status về Doing, chưa hoàn thành.
+// Comment, like và subtask không được chép, due date đã qua cũng bỏ vì item mới không được quá hạn ngay
+func Clone(item *TodoItem, titleSuffix string) *TodoItemCreation {
+	status := ItemStatusDoing
+
+	data := &TodoItemCreation{
+		UserId:         item.UserId,
+		Title:          item.Title + titleSuffix,
+		Description:    item.Description,
+		Status:         &status,
+		Tags:           append(ItemTags{}, item.Tags...),
+		Priority:       item.Priority,
+		Image:          item.Image,
+		RecurrenceRule: item.RecurrenceRule,
+		// Bản sao không có suffix thì trùng title với item gốc
+		AllowDuplicate: true,
+	}
+
+	if item.DueDate != nil && item.DueDate.After(time.Now()) {
+		dueDate := *item.DueDate
+		data.DueDate = &dueDate
+	}
+
+	return data
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClone(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		status      ItemStatus
+		dueDate     *time.Time
+		suffix      string
+		wantTitle   string
+		wantDueDate *time.Time
+	}{
+		{"done item with suffix", ItemStatusDone, &future, DefaultCloneTitleSuffix, "buy milk (copy)", &future},
+		{"empty suffix keeps the title", ItemStatusDoing, nil, "", "buy milk", nil},
+		{"past due date is dropped", ItemStatusDoing, &past, DefaultCloneTitleSuffix, "buy milk (copy)", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			completedAt := time.Now()
+			item := &TodoItem{
+				UserId:      1,
+				Title:       "buy milk",
+				Description: "2 bottles",
+				Status:      &status,
+				CompletedAt: &completedAt,
+				Tags:        ItemTags{"home", "shopping"},
+				DueDate:     tt.dueDate,
+			}
+			item.Id = 7
+
+			clone := Clone(item, tt.suffix)
+
+			if clone.Id != 0 || clone.Title != tt.wantTitle || clone.Description != item.Description || clone.UserId != item.UserId {
+				t.Errorf("clone = %+v, want a new %q for the same owner", clone, tt.wantTitle)
+			}
+
+			if clone.Status == nil || *clone.Status != ItemStatusDoing || clone.CompletedAt != nil {
+				t.Errorf("status = %v completed_at = %v, want Doing and not completed", clone.Status, clone.CompletedAt)
+			}
+
+			if !reflect.DeepEqual(clone.Tags, item.Tags) {
+				t.Errorf("tags = %v, want %v", clone.Tags, item.Tags)
+			}
+
+			if (clone.DueDate == nil) != (tt.wantDueDate == nil) || clone.DueDate != nil && !clone.DueDate.Equal(*tt.wantDueDate) {
+				t.Errorf("due date = %v, want %v", clone.DueDate, tt.wantDueDate)
+			}
+
+			clone.Tags[0] = "changed"
+
+			if item.Tags[0] != "home" || *item.Status != tt.status {
+				t.Errorf("original changed: tags %v status %v", item.Tags, *item.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import "social-todo-list/common"
+
+const (
+	EventItemCreated = "item.created"
+	EventItemUpdated = "item.updated"
+	EventItemDeleted = "item.deleted"
+)
+
+// NewItemEvent chỉ gửi id (đã mask) của item, client tự gọi API lấy chi tiết nếu cần
+func NewItemEvent(eventType string, itemId int) common.Event {
+	return common.Event{
+		Type: eventType,
+		Data: map[string]interface{}{"id": common.NewUID(uint32(itemId), common.DbTypeItem, 1)},
+	}
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"social-todo-list/common"
+	"unicode/utf8"
+)
+
+var (
+	ErrTitleTooLong       = errors.New("title is too long")
+	ErrDescriptionTooLong = errors.New("description is too long")
+)
+
+// LengthLimits là số ký tự tối đa của title/description, giá trị <= 0 là không giới hạn.
+// Đếm theo rune để tiếng Việt, emoji... không bị tính nhiều hơn số ký tự thật
+type LengthLimits struct {
+	Title       int
+	Description int
+}
+
+// validate thêm lỗi của title/description vượt giới hạn vào validationErr, nil là field không gửi lên
+func (l LengthLimits) validate(validationErr *common.ValidationError, title, description *string) {
+	if title != nil && l.Title > 0 && utf8.RuneCountInString(*title) > l.Title {
+		validationErr.Add("title", fmt.Errorf("%w, maximum is %d characters", ErrTitleTooLong, l.Title))
+	}
+
+	if description != nil && l.Description > 0 && utf8.RuneCountInString(*description) > l.Description {
+		validationErr.Add("description", fmt.Errorf("%w, maximum is %d characters", ErrDescriptionTooLong, l.Description))
+	}
+}
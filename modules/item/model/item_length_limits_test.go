@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"social-todo-list/common"
+	"strings"
+	"testing"
+)
+
+func TestLengthLimits(t *testing.T) {
+	limits := LengthLimits{Title: 10, Description: 20}
+	doing := ItemStatusDoing
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		limits      LengthLimits
+		wantErrs    map[string]error
+	}{
+		{"title at the limit", strings.Repeat("a", 10), "", limits, nil},
+		{"title one rune over", strings.Repeat("a", 11), "", limits, map[string]error{"title": ErrTitleTooLong}},
+		{"multibyte title counted by rune", strings.Repeat("ắ", 10), "", limits, nil},
+		{"multibyte title one rune over", strings.Repeat("🙂", 11), "", limits, map[string]error{"title": ErrTitleTooLong}},
+		{"description at the limit", "a", strings.Repeat("ờ", 20), limits, nil},
+		{"description one rune over", "a", strings.Repeat("ờ", 21), limits, map[string]error{"description": ErrDescriptionTooLong}},
+		{"both over", strings.Repeat("a", 11), strings.Repeat("a", 21), limits, map[string]error{"title": ErrTitleTooLong, "description": ErrDescriptionTooLong}},
+		{"zero is unlimited", strings.Repeat("a", 1000), strings.Repeat("a", 100000), LengthLimits{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, description := tt.title, tt.description
+			creation := &TodoItemCreation{Title: title, Description: description}
+			update := &TodoItemUpdate{Title: &title, Description: &description}
+			replace := &TodoItemReplace{Title: &title, Description: &description, Status: &doing}
+
+			for kind, validate := range map[string]func(LengthLimits) error{
+				"create":  creation.Validate,
+				"update":  update.Validate,
+				"replace": replace.Validate,
+			} {
+				err := validate(tt.limits)
+
+				if tt.wantErrs == nil {
+					if err != nil {
+						t.Errorf("%s: unexpected error: %v", kind, err)
+					}
+
+					continue
+				}
+
+				var validationErr *common.ValidationError
+
+				if !errors.As(err, &validationErr) || len(validationErr.Fields) != len(tt.wantErrs) {
+					t.Errorf("%s: err = %v, want errors for %v", kind, err, tt.wantErrs)
+					continue
+				}
+
+				for field, want := range tt.wantErrs {
+					if msg := validationErr.Fields[field]; !strings.HasPrefix(msg, want.Error()) || !errors.Is(err, want) {
+						t.Errorf("%s: %s error = %q, want %v", kind, field, msg, want)
+					}
+				}
+			}
+		})
+	}
+}
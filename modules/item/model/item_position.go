@@ -0,0 +1,38 @@
+package model
+
+import "errors"
+
+const (
+	// ItemPositionGap là khoảng cách giữa hai item khi thêm vào cuối hoặc sau khi đánh số lại
+	ItemPositionGap = 1024.0
+	// Hai item sát nhau hơn minPositionGap thì không chèn vào giữa nữa mà phải đánh số lại cả danh sách
+	minPositionGap = 1e-6
+)
+
+var ErrMoveAfterItself = errors.New("item cannot be moved after itself")
+
+// TodoItemReorder chuyển item tới ngay sau item AfterId (fake id hoặc id số), bỏ trống là đưa lên đầu danh sách
+type TodoItemReorder struct {
+	AfterId *string `json:"after_id"`
+}
+
+// PositionBetween tính position nằm giữa prev và next (nil là không có item ở phía đó),
+// trả về false nếu hai item quá sát nhau để chèn vào giữa
+func PositionBetween(prev, next *float64) (float64, bool) {
+	switch {
+	case prev == nil && next == nil:
+		return 0, true
+	case prev == nil:
+		return *next - ItemPositionGap, true
+	case next == nil:
+		return *prev + ItemPositionGap, true
+	}
+
+	if *next-*prev < minPositionGap {
+		return 0, false
+	}
+
+	position := (*prev + *next) / 2
+
+	return position, position > *prev && position < *next
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ItemPriority lưu xuống DB dạng số để ORDER BY priority DESC ra High trước
+type ItemPriority int
+
+const (
+	ItemPriorityLow ItemPriority = iota
+	ItemPriorityMedium
+	ItemPriorityHigh
+)
+
+var allItemPriority = [3]string{"Low", "Medium", "High"}
+
+var ErrInvalidPriority = errors.New("invalid priority")
+
+func (p *ItemPriority) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+
+	return allItemPriority[*p]
+}
+
+func (p *ItemPriority) IsValid() bool {
+	return p != nil && *p >= ItemPriorityLow && int(*p) < len(allItemPriority)
+}
+
+func ParseItemPriority(s string) (ItemPriority, error) {
+	for i := range allItemPriority {
+		if s == allItemPriority[i] {
+			return ItemPriority(i), nil
+		}
+	}
+
+	return ItemPriority(0), fmt.Errorf("%w: %q, must be one of %s", ErrInvalidPriority, s, strings.Join(allItemPriority[:], ", "))
+}
+
+func (p *ItemPriority) Scan(value interface{}) error {
+	var v int64
+
+	switch t := value.(type) {
+	case int64:
+		v = t
+	case []byte:
+		if _, err := fmt.Sscan(string(t), &v); err != nil {
+			return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+		}
+	default:
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %v", value))
+	}
+
+	priority := ItemPriority(v)
+
+	if !priority.IsValid() {
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %v", value))
+	}
+
+	*p = priority
+
+	return nil
+}
+
+func (p *ItemPriority) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return int64(*p), nil
+}
+
+func (p *ItemPriority) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return []byte(fmt.Sprintf("\"%s\"", p.String())), nil
+}
+
+// MarshalText dùng khi render XML
+func (p *ItemPriority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *ItemPriority) UnmarshalJSON(data []byte) error {
+	str := strings.ReplaceAll(string(data), "\"", "") // "High"
+
+	v, err := ParseItemPriority(str)
+
+	if err != nil {
+		return err
+	}
+
+	*p = v
+
+	return nil
+}
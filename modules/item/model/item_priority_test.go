@@ -0,0 +1,91 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestItemPriorityRoundTrip(t *testing.T) {
+	tests := []struct {
+		priority ItemPriority
+		json     string
+		dbValue  int64
+	}{
+		{ItemPriorityLow, `"Low"`, 0},
+		{ItemPriorityMedium, `"Medium"`, 1},
+		{ItemPriorityHigh, `"High"`, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.priority.String(), func(t *testing.T) {
+			priority := tt.priority
+
+			data, err := json.Marshal(&priority)
+
+			if err != nil || string(data) != tt.json {
+				t.Fatalf("MarshalJSON = %s, %v, want %s", data, err, tt.json)
+			}
+
+			var decoded ItemPriority
+
+			if err := json.Unmarshal(data, &decoded); err != nil || decoded != tt.priority {
+				t.Fatalf("UnmarshalJSON = %d, %v, want %d", decoded, err, tt.priority)
+			}
+
+			value, err := priority.Value()
+
+			if err != nil || value != tt.dbValue {
+				t.Fatalf("Value = %v, %v, want %d", value, err, tt.dbValue)
+			}
+
+			for _, raw := range []interface{}{value, []byte(strconv.FormatInt(tt.dbValue, 10))} {
+				var scanned ItemPriority
+
+				if err := scanned.Scan(raw); err != nil || scanned != tt.priority {
+					t.Errorf("Scan(%v) = %d, %v, want %d", raw, scanned, err, tt.priority)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoItemCreationPriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    ItemPriority
+		wantErr bool
+	}{
+		{"omitted defaults to medium", `{"title":"a"}`, ItemPriorityMedium, false},
+		{"high", `{"title":"a","priority":"High"}`, ItemPriorityHigh, false},
+		{"unknown", `{"title":"a","priority":"Urgent"}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data TodoItemCreation
+
+			if err := json.Unmarshal([]byte(tt.body), &data); err != nil {
+				if !tt.wantErr || !errors.Is(err, ErrInvalidPriority) {
+					t.Fatalf("unmarshal: %v", err)
+				}
+
+				return
+			}
+
+			if tt.wantErr {
+				t.Fatal("unknown priority was accepted")
+			}
+
+			if err := data.Validate(LengthLimits{}); err != nil {
+				t.Fatal(err)
+			}
+
+			if data.Priority == nil || *data.Priority != tt.want {
+				t.Errorf("priority = %s, want %s", data.Priority.String(), tt.want.String())
+			}
+		})
+	}
+}
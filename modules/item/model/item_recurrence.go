@@ -0,0 +1,91 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RecurrenceRule là chu kỳ lặp lại của item, rỗng là item không lặp
+type RecurrenceRule string
+
+const (
+	RecurrenceNone    RecurrenceRule = ""
+	RecurrenceDaily   RecurrenceRule = "DAILY"
+	RecurrenceWeekly  RecurrenceRule = "WEEKLY"
+	RecurrenceMonthly RecurrenceRule = "MONTHLY"
+)
+
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+func (r RecurrenceRule) IsValid() bool {
+	switch r {
+	case RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+
+	return false
+}
+
+// Next trả về mốc kế tiếp sau t theo chu kỳ, MONTHLY dùng AddDate nên 31/01 sẽ thành 03/03 (hoặc 02/03)
+func (r RecurrenceRule) Next(t time.Time) time.Time {
+	switch r {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case RecurrenceMonthly:
+		return t.AddDate(0, 1, 0)
+	}
+
+	return t
+}
+
+// UnmarshalJSON không phân biệt hoa thường: "daily" cũng là DAILY
+func (r *RecurrenceRule) UnmarshalJSON(data []byte) error {
+	var str string
+
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	rule := RecurrenceRule(strings.ToUpper(strings.TrimSpace(str)))
+
+	if !rule.IsValid() {
+		return fmt.Errorf("%w: %q, must be one of DAILY, WEEKLY, MONTHLY or empty", ErrInvalidRecurrenceRule, str)
+	}
+
+	*r = rule
+
+	return nil
+}
+
+// NextOccurrence là item mới cho lần lặp kế tiếp của item đã Done, due date tính từ due date cũ
+// (không có thì từ lúc hoàn thành), các field còn lại được chép sang và status về Doing
+func NextOccurrence(item *TodoItem) *TodoItemCreation {
+	base := time.Now().UTC()
+
+	switch {
+	case item.DueDate != nil:
+		base = *item.DueDate
+	case item.CompletedAt != nil:
+		base = *item.CompletedAt
+	}
+
+	dueDate := item.RecurrenceRule.Next(base).UTC()
+	status := ItemStatusDoing
+
+	return &TodoItemCreation{
+		UserId:         item.UserId,
+		Title:          item.Title,
+		Description:    item.Description,
+		Status:         &status,
+		Tags:           item.Tags,
+		DueDate:        &dueDate,
+		Priority:       item.Priority,
+		Image:          item.Image,
+		RecurrenceRule: item.RecurrenceRule,
+	}
+}
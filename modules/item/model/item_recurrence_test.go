@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	base := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule RecurrenceRule
+		want time.Time
+	}{
+		{RecurrenceDaily, time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{RecurrenceWeekly, time.Date(2024, 2, 7, 9, 0, 0, 0, time.UTC)},
+		{RecurrenceMonthly, time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{RecurrenceNone, base},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			if got := tt.rule.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    RecurrenceRule
+		wantErr error
+	}{
+		{`"DAILY"`, RecurrenceDaily, nil},
+		{`" weekly "`, RecurrenceWeekly, nil},
+		{`""`, RecurrenceNone, nil},
+		{`"HOURLY"`, RecurrenceNone, ErrInvalidRecurrenceRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			var rule RecurrenceRule
+
+			if err := json.Unmarshal([]byte(tt.raw), &rule); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if rule != tt.want {
+				t.Errorf("rule = %q, want %q", rule, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	dueDate := time.Date(2024, 5, 10, 17, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2024, 5, 12, 8, 0, 0, 0, time.UTC)
+	done := ItemStatusDone
+
+	tests := []struct {
+		name        string
+		dueDate     *time.Time
+		wantDueDate time.Time
+	}{
+		{"from due date", &dueDate, dueDate.AddDate(0, 0, 1)},
+		{"from completion without due date", nil, completedAt.AddDate(0, 0, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := TodoItem{
+				UserId:         3,
+				Title:          "water plants",
+				Status:         &done,
+				DueDate:        tt.dueDate,
+				CompletedAt:    &completedAt,
+				RecurrenceRule: RecurrenceDaily,
+			}
+
+			next := NextOccurrence(&item)
+
+			if !next.DueDate.Equal(tt.wantDueDate) {
+				t.Errorf("due date = %v, want %v", next.DueDate, tt.wantDueDate)
+			}
+
+			if *next.Status != ItemStatusDoing || next.Title != item.Title || next.UserId != item.UserId || next.RecurrenceRule != RecurrenceDaily {
+				t.Errorf("next = %+v, want a Doing copy of the item", next)
+			}
+		})
+	}
+}
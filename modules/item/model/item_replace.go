@@ -0,0 +1,107 @@
+package model
+
+import (
+	"database/sql"
+	"errors"
+	"social-todo-list/common"
+	"strings"
+	"time"
+)
+
+var ErrDescriptionIsMissing = errors.New("description is required")
+
+// ReplaceColumns là các cột PUT luôn ghi đè, field không gửi lên sẽ thành rỗng/mặc định
+var ReplaceColumns = []string{"title", "description", "status", "priority", "image", "recurrence_rule", "tags", "due_date"}
+
+// TodoItemReplace là body của PUT: title, description và status bắt buộc phải có,
+// các field còn lại không gửi thì về mặc định thay vì giữ giá trị cũ như PATCH
+type TodoItemReplace struct {
+	Title          *string        `json:"title"`
+	Description    *string        `json:"description"`
+	Status         *ItemStatus    `json:"status"`
+	Priority       *ItemPriority  `json:"priority"`
+	Image          *common.Image  `json:"image"`
+	RecurrenceRule RecurrenceRule `json:"recurrence_rule"`
+	Tags           ItemTags       `json:"tags"`
+	// DueDate không bắt buộc ở tương lai như lúc tạo, để client gửi lại nguyên item đã quá hạn
+	DueDate *time.Time `json:"due_date"`
+	Version *int       `json:"version"`
+}
+
+// Sanitize làm sạch title/description bằng sanitize, gọi trước Validate
+func (r *TodoItemReplace) Sanitize(sanitize func(string) string) {
+	if r.Title != nil {
+		title := sanitize(*r.Title)
+		r.Title = &title
+	}
+
+	if r.Description != nil {
+		description := sanitize(*r.Description)
+		r.Description = &description
+	}
+}
+
+func (r *TodoItemReplace) Validate(limits LengthLimits) error {
+	validationErr := common.NewValidationError()
+
+	if r.Title == nil || strings.TrimSpace(*r.Title) == "" {
+		validationErr.Add("title", ErrTitleIsBlank)
+	} else {
+		title := strings.TrimSpace(*r.Title)
+		limits.validate(validationErr, &title, nil)
+	}
+
+	limits.validate(validationErr, nil, r.Description)
+
+	if r.Description == nil {
+		validationErr.Add("description", ErrDescriptionIsMissing)
+	}
+
+	// Xoá item phải đi qua API delete
+	if r.Status == nil {
+		validationErr.Add("status", ErrStatusIsBlank)
+	} else if !r.Status.IsValid() || *r.Status == ItemStatusDeleted {
+		validationErr.Add("status", ErrInvalidStatus)
+	}
+
+	if r.Priority != nil && !r.Priority.IsValid() {
+		validationErr.Add("priority", ErrInvalidPriority)
+	}
+
+	if !r.RecurrenceRule.IsValid() {
+		validationErr.Add("recurrence_rule", ErrInvalidRecurrenceRule)
+	}
+
+	return validationErr.Err()
+}
+
+// ToUpdate chuyển sang TodoItemUpdate với đủ các cột trong ReplaceColumns, phải gọi sau Validate
+func (r *TodoItemReplace) ToUpdate() *TodoItemUpdate {
+	title := strings.TrimSpace(*r.Title)
+	priority := ItemPriorityMedium
+
+	if r.Priority != nil {
+		priority = *r.Priority
+	}
+
+	recurrenceRule := r.RecurrenceRule
+	tags := r.Tags.Normalize()
+
+	dueDate := sql.NullTime{}
+
+	if r.DueDate != nil {
+		dueDate = sql.NullTime{Time: r.DueDate.UTC(), Valid: true}
+	}
+
+	return &TodoItemUpdate{
+		Title:          &title,
+		Description:    r.Description,
+		Status:         r.Status,
+		Priority:       &priority,
+		Image:          r.Image,
+		RecurrenceRule: &recurrenceRule,
+		Tags:           &tags,
+		DueDate:        &dueDate,
+		Version:        r.Version,
+	}
+}
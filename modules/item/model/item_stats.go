@@ -0,0 +1,11 @@
+package model
+
+// ItemStats là số liệu tổng hợp item của một user
+type ItemStats struct {
+	Doing   int64 `json:"doing"`
+	Done    int64 `json:"done"`
+	Deleted int64 `json:"deleted"`
+	// Total tính cả item đã xoá
+	Total             int64 `json:"total"`
+	CompletedLastWeek int64 `json:"completed_last_7_days"`
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 )
 
+// ItemStatus lưu xuống DB dạng số (Doing = 0, Done = 1, Deleted = 2), API vẫn dùng tên trạng thái
 type ItemStatus int
 
 const (
@@ -18,9 +19,17 @@ const (
 var allItemStatus = [3]string{"Doing", "Done", "Deleted"}
 
 func (item *ItemStatus) String() string {
+	if !item.IsValid() {
+		return ""
+	}
+
 	return allItemStatus[*item]
 }
 
+func (item *ItemStatus) IsValid() bool {
+	return item != nil && *item >= ItemStatusDoing && int(*item) < len(allItemStatus)
+}
+
 func parseStr2ItemStatus(s string) (ItemStatus, error) {
 	for i := range allItemStatus {
 		if s == allItemStatus[i] {
@@ -28,24 +37,46 @@ func parseStr2ItemStatus(s string) (ItemStatus, error) {
 		}
 	}
 
-	return ItemStatus(0), errors.New("invalid status string")
+	return ItemStatus(0), fmt.Errorf("%w: %q, must be one of %s", ErrInvalidStatus, s, strings.Join(allItemStatus[:], ", "))
 }
 
-// Đọc dữ liệu từ Database lên
-func (item *ItemStatus) Scan(value interface{}) error {
-	bytes, ok := value.([]byte)
+// ParseDefaultStatus đọc status mặc định của item mới (từ config), item mới không thể là Deleted
+func ParseDefaultStatus(s string) (ItemStatus, error) {
+	status, err := parseStr2ItemStatus(s)
 
-	if !ok {
-		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	if err != nil {
+		return status, err
 	}
 
-	v, err := parseStr2ItemStatus(string(bytes))
+	if status == ItemStatusDeleted {
+		return status, fmt.Errorf("%w: default status cannot be %q", ErrInvalidStatus, s)
+	}
 
-	if err != nil {
-		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	return status, nil
+}
+
+// Đọc dữ liệu từ Database lên, MySQL có thể trả về số dạng []byte
+func (item *ItemStatus) Scan(value interface{}) error {
+	var v int64
+
+	switch t := value.(type) {
+	case int64:
+		v = t
+	case []byte:
+		if _, err := fmt.Sscan(string(t), &v); err != nil {
+			return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+		}
+	default:
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %v", value))
+	}
+
+	status := ItemStatus(v)
+
+	if !status.IsValid() {
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %v", value))
 	}
 
-	*item = v
+	*item = status
 
 	return nil
 }
@@ -56,7 +87,7 @@ func (item *ItemStatus) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	return item.String(), nil
+	return int64(*item), nil
 }
 
 func (item *ItemStatus) MarshalJSON() ([]byte, error) {
@@ -67,6 +98,11 @@ func (item *ItemStatus) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("\"%s\"", item.String())), nil
 }
 
+// MarshalText dùng khi render XML
+func (item *ItemStatus) MarshalText() ([]byte, error) {
+	return []byte(item.String()), nil
+}
+
 func (item *ItemStatus) UnmarshalJSON(data []byte) error {
 	str := strings.ReplaceAll(string(data), "\"", "") // "Doing"
 
@@ -80,3 +116,25 @@ func (item *ItemStatus) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// allowedStatusTransitions là các lần đổi status được phép qua API update.
+// Không có đường ra khỏi Deleted, muốn lấy lại item phải đi qua API restore
+var allowedStatusTransitions = map[ItemStatus][]ItemStatus{
+	ItemStatusDoing: {ItemStatusDone, ItemStatusDeleted},
+	ItemStatusDone:  {ItemStatusDoing, ItemStatusDeleted},
+}
+
+// CanTransitionTo cho biết item đang ở status này có được đổi sang next không, giữ nguyên status luôn hợp lệ
+func (item ItemStatus) CanTransitionTo(next ItemStatus) bool {
+	if item == next {
+		return true
+	}
+
+	for _, allowed := range allowedStatusTransitions[item] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestItemStatusRoundTrip(t *testing.T) {
+	tests := []struct {
+		status  ItemStatus
+		json    string
+		dbValue int64
+	}{
+		{ItemStatusDoing, `"Doing"`, 0},
+		{ItemStatusDone, `"Done"`, 1},
+		{ItemStatusDeleted, `"Deleted"`, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			status := tt.status
+
+			data, err := json.Marshal(&status)
+
+			if err != nil || string(data) != tt.json {
+				t.Fatalf("MarshalJSON = %s, %v, want %s", data, err, tt.json)
+			}
+
+			var decoded ItemStatus
+
+			if err := json.Unmarshal(data, &decoded); err != nil || decoded != tt.status {
+				t.Fatalf("UnmarshalJSON = %d, %v, want %d", decoded, err, tt.status)
+			}
+
+			value, err := status.Value()
+
+			if err != nil || value != tt.dbValue {
+				t.Fatalf("Value = %v, %v, want %d", value, err, tt.dbValue)
+			}
+
+			// SQLite trả về int64, MySQL có thể trả về []byte
+			for _, raw := range []interface{}{value, []byte(strconv.FormatInt(tt.dbValue, 10))} {
+				var scanned ItemStatus
+
+				if err := scanned.Scan(raw); err != nil || scanned != tt.status {
+					t.Errorf("Scan(%v) = %d, %v, want %d", raw, scanned, err, tt.status)
+				}
+			}
+		})
+	}
+}
+
+func TestItemStatusRejectsUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"unknown name", `"Doing123"`},
+		{"lower case", `"doing"`},
+		{"empty", `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status ItemStatus
+
+			if err := json.Unmarshal([]byte(tt.json), &status); !errors.Is(err, ErrInvalidStatus) {
+				t.Errorf("err = %v, want %v", err, ErrInvalidStatus)
+			}
+		})
+	}
+
+	for _, raw := range []interface{}{int64(3), int64(-1), "Doing", []byte("x")} {
+		var status ItemStatus
+
+		if err := status.Scan(raw); err == nil {
+			t.Errorf("Scan(%v) accepted an invalid value as %d", raw, status)
+		}
+	}
+}
+
+func TestTodoItemCreationValidateStatus(t *testing.T) {
+	doing, deleted, invalid := ItemStatusDoing, ItemStatusDeleted, ItemStatus(7)
+
+	tests := []struct {
+		name    string
+		status  *ItemStatus
+		wantErr error
+	}{
+		{"no status", nil, nil},
+		{"doing", &doing, nil},
+		{"deleted", &deleted, ErrInvalidStatus},
+		{"out of range", &invalid, ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := TodoItemCreation{Title: "buy milk", Status: tt.status}
+
+			if err := data.Validate(LengthLimits{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestItemStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from ItemStatus
+		to   ItemStatus
+		want bool
+	}{
+		{ItemStatusDoing, ItemStatusDone, true},
+		{ItemStatusDone, ItemStatusDoing, true},
+		{ItemStatusDoing, ItemStatusDeleted, true},
+		{ItemStatusDone, ItemStatusDeleted, true},
+		{ItemStatusDone, ItemStatusDone, true},
+		{ItemStatusDeleted, ItemStatusDone, false},
+		{ItemStatusDeleted, ItemStatusDoing, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"->"+tt.to.String(), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
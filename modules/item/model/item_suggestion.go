@@ -0,0 +1,20 @@
+package model
+
+import "social-todo-list/common"
+
+// q ngắn hơn MinSuggestQueryLength ký tự thì gợi ý trả rỗng, prefix quá ngắn khớp gần như mọi item
+const MinSuggestQueryLength = 2
+
+// TodoItemSuggestion là một gợi ý title cho ô tìm kiếm, chỉ có id và title
+type TodoItemSuggestion struct {
+	Id     int         `json:"-" gorm:"column:id;"`
+	FakeId *common.UID `json:"id" gorm:"-"`
+	Title  string      `json:"title" gorm:"column:title;"`
+}
+
+func (TodoItemSuggestion) TableName() string { return TodoItem{}.TableName() }
+
+func (s *TodoItemSuggestion) Mask() {
+	uid := common.NewUID(uint32(s.Id), common.DbTypeItem, 1)
+	s.FakeId = &uid
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ItemTags lưu xuống DB dạng mảng JSON, ví dụ ["home","work"]
+type ItemTags []string
+
+// Normalize trim, chuyển về chữ thường, bỏ tag rỗng và tag trùng (giữ thứ tự xuất hiện đầu tiên)
+func (tags ItemTags) Normalize() ItemTags {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	result := make(ItemTags, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+func (ItemTags) GormDataType() string {
+	return "text"
+}
+
+func (tags *ItemTags) Scan(value interface{}) error {
+	var bytes []byte
+
+	switch v := value.(type) {
+	case nil:
+		*tags = nil
+		return nil
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	}
+
+	var result ItemTags
+
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		return errors.New(fmt.Sprintf("fail to scan data from sql: %s", value))
+	}
+
+	*tags = result
+
+	return nil
+}
+
+func (tags ItemTags) Value() (driver.Value, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal([]string(tags))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return string(bytes), nil
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemTagsNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		tags ItemTags
+		want ItemTags
+	}{
+		{"nil", nil, nil},
+		{"lowercase and trim", ItemTags{" Work ", "HOME"}, ItemTags{"work", "home"}},
+		{"duplicates keep first position", ItemTags{"work", "home", "Work"}, ItemTags{"work", "home"}},
+		{"blank tags dropped", ItemTags{"", "  ", "work"}, ItemTags{"work"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tags.Normalize(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemTagsScanValue(t *testing.T) {
+	tests := []struct {
+		name string
+		tags ItemTags
+	}{
+		{"empty is NULL", nil},
+		{"two tags", ItemTags{"work", "home"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.tags.Value()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got ItemTags
+
+			if err := got.Scan(value); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.tags) {
+				t.Errorf("round trip = %v, want %v", got, tt.tags)
+			}
+		})
+	}
+}
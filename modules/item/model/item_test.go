@@ -0,0 +1,263 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"social-todo-list/common"
+	usermodel "social-todo-list/modules/user/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTodoItemMaskHidesUserIds(t *testing.T) {
+	updatedBy := 4242
+	item := TodoItem{
+		SQLModel:  common.SQLModel{Id: 1},
+		UserId:    4141,
+		UpdatedBy: &updatedBy,
+		Title:     "a",
+		Owner:     &usermodel.UserInfo{Id: 4141},
+	}
+	item.Mask()
+
+	body, err := json.Marshal(item)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key   string
+		local int
+	}{
+		{"user_id", 4141},
+		{"updated_by", 4242},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			s, ok := got[tt.key].(string)
+
+			if !ok {
+				t.Fatalf("%s = %v, want a fake id string", tt.key, got[tt.key])
+			}
+
+			id, err := common.ParseLocalId(s, common.DbTypeUser)
+
+			if err != nil || id != tt.local {
+				t.Errorf("%s decodes to %d (%v), want %d", tt.key, id, err, tt.local)
+			}
+		})
+	}
+
+	if strings.Contains(string(body), "4141") || strings.Contains(string(body), "4242") {
+		t.Errorf("raw user id in %s", body)
+	}
+}
+
+func TestItemAuditLogMask(t *testing.T) {
+	log := ItemAuditLog{UserId: 3, Changes: AuditChanges{
+		"user_id": {Old: 3, New: float64(5)},
+		"title":   {Old: "a", New: "b"},
+	}}
+	log.Mask()
+
+	if log.FakeUserId == nil || log.FakeUserId.GetLocalID() != 3 {
+		t.Errorf("FakeUserId = %v", log.FakeUserId)
+	}
+
+	for _, v := range []interface{}{log.Changes["user_id"].Old, log.Changes["user_id"].New} {
+		if uid, ok := v.(common.UID); !ok || uid.GetObjectType() != common.DbTypeUser {
+			t.Errorf("owner change %v not masked", v)
+		}
+	}
+
+	if log.Changes["title"].New != "b" {
+		t.Error("other changes must not be touched")
+	}
+}
+
+func TestOutboxEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		changes AuditChanges
+		want    []OutboxEvent
+	}{
+		{"create", AuditActionCreate, nil, []OutboxEvent{{UserId: 1, ItemId: 10, Type: EventItemCreated}}},
+		{"delete", AuditActionDelete, nil, []OutboxEvent{{UserId: 1, ItemId: 10, Type: EventItemDeleted}}},
+		{"update", AuditActionUpdate, AuditChanges{"title": {Old: "a", New: "b"}}, []OutboxEvent{{UserId: 1, ItemId: 10, Type: EventItemUpdated}}},
+		{
+			"assign",
+			AuditActionUpdate,
+			AuditChanges{"user_id": {Old: 1, New: 2}},
+			[]OutboxEvent{{UserId: 1, ItemId: 10, Type: EventItemDeleted}, {UserId: 2, ItemId: 10, Type: EventItemCreated}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OutboxEvents(10, 1, tt.action, tt.changes)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("events = %+v, want %+v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i].UserId != tt.want[i].UserId || got[i].ItemId != tt.want[i].ItemId || got[i].Type != tt.want[i].Type {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTodoItemCreationBeforeCreateCompletedAt(t *testing.T) {
+	doing, done := ItemStatusDoing, ItemStatusDone
+	earlier := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		data          TodoItemCreation
+		wantCompleted bool
+		wantKept      *time.Time
+	}{
+		{"doing", TodoItemCreation{Status: &doing}, false, nil},
+		{"no status", TodoItemCreation{}, false, nil},
+		{"done", TodoItemCreation{Status: &done}, true, nil},
+		{"done keeps given completed_at", TodoItemCreation{Status: &done, CompletedAt: &earlier}, true, &earlier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+
+			if err := data.BeforeCreate(nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if (data.CompletedAt != nil) != tt.wantCompleted {
+				t.Fatalf("completed_at = %v, want set = %v", data.CompletedAt, tt.wantCompleted)
+			}
+
+			if tt.wantKept != nil && !data.CompletedAt.Equal(*tt.wantKept) {
+				t.Errorf("completed_at = %v, want %v", data.CompletedAt, tt.wantKept)
+			}
+
+			if tt.wantCompleted && tt.wantKept == nil && !data.CompletedAt.Equal(*data.CreatedAt) {
+				t.Errorf("completed_at = %v, want created_at %v", data.CompletedAt, data.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestTodoItemCreationValidateTitle(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		wantTitle string
+		wantErr   error
+	}{
+		{"empty", "", "", ErrTitleIsBlank},
+		{"only spaces", "   ", "", ErrTitleIsBlank},
+		{"tabs and newlines", "\t\n ", "", ErrTitleIsBlank},
+		{"valid", "buy milk", "buy milk", nil},
+		{"valid is trimmed", "  buy milk  ", "buy milk", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := TodoItemCreation{Title: tt.title}
+			err := data.Validate(LengthLimits{})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if data.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", data.Title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestTodoItemCreationValidateCollectsFields(t *testing.T) {
+	deleted := ItemStatusDeleted
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		data       TodoItemCreation
+		wantFields []string
+	}{
+		{"valid", TodoItemCreation{Title: "buy milk"}, nil},
+		{"title only", TodoItemCreation{Title: " "}, []string{"title"}},
+		{"title and status", TodoItemCreation{Title: "", Status: &deleted}, []string{"title", "status"}},
+		{"three fields", TodoItemCreation{Title: "", Status: &deleted, DueDate: &past}, []string{"title", "status", "due_date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.data.Validate(LengthLimits{})
+
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			var validationErr *common.ValidationError
+
+			if !errors.As(err, &validationErr) || len(validationErr.Fields) != len(tt.wantFields) {
+				t.Fatalf("err = %v, want errors for %v", err, tt.wantFields)
+			}
+
+			for _, field := range tt.wantFields {
+				if _, ok := validationErr.Fields[field]; !ok {
+					t.Errorf("fields = %v, missing %s", validationErr.Fields, field)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoItemCreationDueDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"no due date", `{"title":"a"}`, nil},
+		{"future", `{"title":"a","due_date":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`, nil},
+		{"past", `{"title":"a","due_date":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `"}`, ErrDueDateInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data TodoItemCreation
+
+			if err := json.Unmarshal([]byte(tt.body), &data); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := data.Validate(LengthLimits{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var data TodoItemCreation
+
+	if err := json.Unmarshal([]byte(`{"title":"a","due_date":"tomorrow"}`), &data); err == nil {
+		t.Error("non RFC3339 due date was accepted")
+	}
+}
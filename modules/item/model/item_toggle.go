@@ -0,0 +1,22 @@
+package model
+
+// TodoItemToggle là body (không bắt buộc) của API toggle. Gửi done thì status được đặt thẳng
+// là Done (true) hoặc Doing (false), gọi lại bao nhiêu lần cũng cùng kết quả
+type TodoItemToggle struct {
+	Done *bool `json:"done" form:"done"`
+}
+
+// NextStatus là status sau khi toggle item đang ở status current (Doing hoặc Done)
+func (t *TodoItemToggle) NextStatus(current ItemStatus) ItemStatus {
+	done := current != ItemStatusDone
+
+	if t != nil && t.Done != nil {
+		done = *t.Done
+	}
+
+	if done {
+		return ItemStatusDone
+	}
+
+	return ItemStatusDoing
+}
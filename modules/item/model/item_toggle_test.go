@@ -0,0 +1,29 @@
+package model
+
+import "testing"
+
+func TestTodoItemToggleNextStatus(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name    string
+		toggle  *TodoItemToggle
+		current ItemStatus
+		want    ItemStatus
+	}{
+		{"nil body flips doing", nil, ItemStatusDoing, ItemStatusDone},
+		{"empty body flips done", &TodoItemToggle{}, ItemStatusDone, ItemStatusDoing},
+		{"done=true on doing", &TodoItemToggle{Done: &yes}, ItemStatusDoing, ItemStatusDone},
+		{"done=true on done stays", &TodoItemToggle{Done: &yes}, ItemStatusDone, ItemStatusDone},
+		{"done=false on done", &TodoItemToggle{Done: &no}, ItemStatusDone, ItemStatusDoing},
+		{"done=false on doing stays", &TodoItemToggle{Done: &no}, ItemStatusDoing, ItemStatusDoing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.toggle.NextStatus(tt.current); got != tt.want {
+				t.Errorf("NextStatus(%v) = %v, want %v", tt.current, got, tt.want)
+			}
+		})
+	}
+}
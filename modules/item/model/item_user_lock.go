@@ -0,0 +1,9 @@
+package model
+
+// ItemUserLock là dòng khoá theo user, storage khoá dòng này trong transaction
+// để các lần tính position của cùng một user chạy tuần tự
+type ItemUserLock struct {
+	UserId int `gorm:"column:user_id;primaryKey;autoIncrement:false;"`
+}
+
+func (ItemUserLock) TableName() string { return "item_user_locks" }
@@ -0,0 +1,12 @@
+package model
+
+import "errors"
+
+const (
+	// DefaultUpcomingDays là số ngày của view upcoming khi không gửi ?days
+	DefaultUpcomingDays = 7
+	// MaxUpcomingDays là số ngày tối đa của view upcoming, gửi lớn hơn thì lấy MaxUpcomingDays
+	MaxUpcomingDays = 90
+)
+
+var ErrInvalidUpcomingDays = errors.New("days must be a positive integer")
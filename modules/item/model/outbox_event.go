@@ -0,0 +1,55 @@
+package model
+
+import (
+	"social-todo-list/common"
+	"time"
+)
+
+// OutboxEvent là event của item được ghi cùng transaction với thay đổi, job relay đọc các event
+// chưa gửi (PublishedAt nil) để gửi ra ngoài nên event không bị mất khi server tắt giữa chừng.
+// Đây là nguồn event duy nhất, cả websocket lẫn webhook đều nhận event từ outbox
+type OutboxEvent struct {
+	Id     int    `gorm:"column:id;"`
+	UserId int    `gorm:"column:user_id;"`
+	ItemId int    `gorm:"column:item_id;"`
+	Type   string `gorm:"column:type;size:50;"`
+	// Attempts là số lần gửi lỗi, NextAttemptAt là lúc được gửi lại (nil là gửi ngay)
+	Attempts      int        `gorm:"column:attempts;not null;default:0;"`
+	LastError     string     `gorm:"column:last_error;type:text;"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at;"`
+	// Instance relay claim event bằng cách ghi ClaimToken và LockedUntil, instance khác bỏ qua event
+	// tới khi LockedUntil hết hạn (instance claim bị tắt giữa chừng thì event được claim lại)
+	ClaimToken  string     `gorm:"column:claim_token;size:32;not null;default:'';"`
+	LockedUntil *time.Time `gorm:"column:locked_until;"`
+	PublishedAt *time.Time `gorm:"column:published_at;index;"`
+	CreatedAt   *time.Time `gorm:"column:created_at;"`
+}
+
+func (OutboxEvent) TableName() string { return "events_outbox" }
+
+// Event là nội dung được gửi đi, giống event đẩy qua websocket
+func (e *OutboxEvent) Event() common.Event {
+	return NewItemEvent(e.Type, e.ItemId)
+}
+
+// OutboxEvents trả về các event ứng với một dòng audit log, đổi owner (assign) thì owner cũ nhận
+// item.deleted và owner mới nhận item.created như event của API assign
+func OutboxEvents(itemId, userId int, action string, changes AuditChanges) []OutboxEvent {
+	switch action {
+	case AuditActionCreate:
+		return []OutboxEvent{{UserId: userId, ItemId: itemId, Type: EventItemCreated}}
+	case AuditActionDelete:
+		return []OutboxEvent{{UserId: userId, ItemId: itemId, Type: EventItemDeleted}}
+	}
+
+	if change, ok := changes["user_id"]; ok {
+		if newOwner, ok := change.New.(int); ok {
+			return []OutboxEvent{
+				{UserId: userId, ItemId: itemId, Type: EventItemDeleted},
+				{UserId: newOwner, ItemId: itemId, Type: EventItemCreated},
+			}
+		}
+	}
+
+	return []OutboxEvent{{UserId: userId, ItemId: itemId, Type: EventItemUpdated}}
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// writeAuditLog phải được gọi với tx của thay đổi để log và dữ liệu không lệch nhau.
+// Mỗi dòng audit log đi kèm event trong outbox, cùng tx nên event được ghi khi và chỉ khi thay đổi được ghi
+func writeAuditLog(tx *gorm.DB, itemId, userId int, action string, changes model.AuditChanges) error {
+	now := time.Now().UTC()
+
+	if err := tx.Create(&model.ItemAuditLog{
+		ItemId:    itemId,
+		UserId:    userId,
+		Action:    action,
+		Changes:   changes,
+		CreatedAt: &now,
+	}).Error; err != nil {
+		return err
+	}
+
+	return writeOutboxEvents(tx, model.OutboxEvents(itemId, userId, action, changes))
+}
+
+// lockItems đọc (và khoá) các item sắp bị sửa để so sánh giá trị cũ khi ghi audit log
+func lockItems(tx *gorm.DB, query func(db *gorm.DB) *gorm.DB) ([]model.TodoItem, error) {
+	var items []model.TodoItem
+
+	db := tx.Clauses(clause.Locking{Strength: "UPDATE"})
+
+	if err := query(db).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ListItemAuditLogs trả về lịch sử thay đổi, mới nhất trước
+func (s *sqlStore) ListItemAuditLogs(
+	ctx context.Context,
+	cond map[string]interface{},
+	paging *common.Paging,
+) ([]model.ItemAuditLog, error) {
+	var result []model.ItemAuditLog
+
+	db := s.db.WithContext(ctx).Table(model.ItemAuditLog{}.TableName()).Where(cond)
+
+	if err := db.Count(&paging.Total).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	if err := db.Order("id desc").
+		Offset((paging.Page - 1) * paging.Limit).
+		Limit(paging.Limit).Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// CachedStorage bọc sqlStore, GetItem theo id (và user_id) được đọc từ cache, các thao tác ghi
+// xoá item tương ứng khỏi cache sau khi ghi. cache nil thì mọi thứ đi thẳng xuống SQL.
+// Với cache trong bộ nhớ, ghi từ instance khác không xoá được cache ở đây nên item có thể cũ tối đa bằng TTL
+type CachedStorage struct {
+	*sqlStore
+	cache ItemCache
+}
+
+func NewCachedStorage(store *sqlStore, cache ItemCache) *CachedStorage {
+	return &CachedStorage{sqlStore: store, cache: cache}
+}
+
+func (s *CachedStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error) {
+	return s.GetItemColumns(ctx, cond, nil)
+}
+
+// GetItemColumns chỉ dùng cache khi lấy đủ cột và cond chỉ gồm id, user_id
+func (s *CachedStorage) GetItemColumns(ctx context.Context, cond map[string]interface{}, columns []string) (*model.TodoItem, error) {
+	id, ok := cacheableItemId(cond)
+
+	if s.cache == nil || !ok || len(columns) > 0 {
+		return s.sqlStore.GetItemColumns(ctx, cond, columns)
+	}
+
+	data, found := s.cache.Get(ctx, id)
+
+	if !found {
+		item, err := s.sqlStore.GetItem(ctx, map[string]interface{}{"id": id})
+
+		if err != nil {
+			return nil, err
+		}
+
+		s.cache.Set(ctx, item)
+		data = item
+	}
+
+	if userId, ok := cond["user_id"]; ok && userId != data.UserId {
+		return nil, common.RecordNotFound
+	}
+
+	return data, nil
+}
+
+func (s *CachedStorage) UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	defer s.invalidate(ctx, cond)
+
+	return s.sqlStore.UpdateItem(ctx, cond, dataUpdate)
+}
+
+func (s *CachedStorage) ReplaceItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	defer s.invalidate(ctx, cond)
+
+	return s.sqlStore.ReplaceItem(ctx, cond, dataUpdate)
+}
+
+func (s *CachedStorage) DeleteItem(ctx context.Context, cond map[string]interface{}, updatedBy int) error {
+	defer s.invalidate(ctx, cond)
+
+	return s.sqlStore.DeleteItem(ctx, cond, updatedBy)
+}
+
+func (s *CachedStorage) DeleteItems(ctx context.Context, cond map[string]interface{}, ids []int, updatedBy int) (int64, error) {
+	defer s.invalidateIds(ctx, ids...)
+
+	return s.sqlStore.DeleteItems(ctx, cond, ids, updatedBy)
+}
+
+func (s *CachedStorage) UpdateItemsStatus(
+	ctx context.Context,
+	cond map[string]interface{},
+	ids []int,
+	status model.ItemStatus,
+	completedAt *time.Time,
+	updatedBy int,
+) (int64, error) {
+	defer s.invalidateIds(ctx, ids...)
+
+	return s.sqlStore.UpdateItemsStatus(ctx, cond, ids, status, completedAt, updatedBy)
+}
+
+// MoveItemAfter có thể đánh lại position của mọi item của user nên xoá hết item của user khỏi cache
+func (s *CachedStorage) MoveItemAfter(ctx context.Context, userId, id int, afterId *int) error {
+	defer s.invalidateUser(ctx, userId)
+
+	return s.sqlStore.MoveItemAfter(ctx, userId, id, afterId)
+}
+
+// HardDeleteOlderThan không biết id nào bị xoá nên xoá cả cache nếu có item bị purge
+func (s *CachedStorage) HardDeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	purged, err := s.sqlStore.HardDeleteOlderThan(ctx, cutoff)
+
+	if purged > 0 && s.cache != nil {
+		s.cache.Clear(ctx)
+	}
+
+	return purged, err
+}
+
+func (s *CachedStorage) invalidate(ctx context.Context, cond map[string]interface{}) {
+	if s.cache == nil {
+		return
+	}
+
+	if id, ok := cond["id"].(int); ok {
+		s.cache.Delete(ctx, id)
+		return
+	}
+
+	if userId, ok := cond["user_id"].(int); ok {
+		s.cache.DeleteUser(ctx, userId)
+		return
+	}
+
+	// Không biết item nào bị sửa thì xoá hết cho chắc
+	s.cache.Clear(ctx)
+}
+
+func (s *CachedStorage) invalidateIds(ctx context.Context, ids ...int) {
+	if s.cache != nil {
+		s.cache.Delete(ctx, ids...)
+	}
+}
+
+func (s *CachedStorage) invalidateUser(ctx context.Context, userId int) {
+	if s.cache != nil {
+		s.cache.DeleteUser(ctx, userId)
+	}
+}
+
+// cacheableItemId trả về id nếu cond chỉ có id (int) và user_id
+func cacheableItemId(cond map[string]interface{}) (int, bool) {
+	id, ok := cond["id"].(int)
+
+	if !ok {
+		return 0, false
+	}
+
+	for key := range cond {
+		if key != "id" && key != "user_id" {
+			return 0, false
+		}
+	}
+
+	return id, true
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// countQueries đếm số câu SELECT chạy trên db của store từ lúc gọi
+func countQueries(t *testing.T, store *sqlStore) *int {
+	t.Helper()
+
+	count := new(int)
+
+	if err := store.db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		*count++
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+// testItemCache chạy cùng một bộ kiểm tra CachedStorage cho mọi backend của ItemCache
+func testItemCache(t *testing.T, newCache func(t *testing.T) ItemCache) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store *CachedStorage, item model.TodoItem, queries *int)
+	}{
+		{"second read hits the cache", func(t *testing.T, store *CachedStorage, item model.TodoItem, queries *int) {
+			cond := map[string]interface{}{"id": item.Id, "user_id": item.UserId}
+
+			for i := 0; i < 2; i++ {
+				if _, err := store.GetItem(context.Background(), cond); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if *queries != 1 {
+				t.Errorf("queries = %d, want 1", *queries)
+			}
+		}},
+		{"update invalidates", func(t *testing.T, store *CachedStorage, item model.TodoItem, queries *int) {
+			cond := map[string]interface{}{"id": item.Id}
+			title := "buy bread"
+
+			if _, err := store.GetItem(context.Background(), cond); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.UpdateItem(context.Background(), cond, &model.TodoItemUpdate{Title: &title}); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := store.GetItem(context.Background(), cond)
+
+			if err != nil || data.Title != title {
+				t.Errorf("title = %v (%v), want %q", data, err, title)
+			}
+		}},
+		{"delete invalidates", func(t *testing.T, store *CachedStorage, item model.TodoItem, queries *int) {
+			cond := map[string]interface{}{"id": item.Id}
+
+			if _, err := store.GetItem(context.Background(), cond); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.DeleteItem(context.Background(), cond, item.UserId); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := store.GetItem(context.Background(), cond)
+
+			if err != nil || *data.Status != model.ItemStatusDeleted {
+				t.Errorf("status = %v (%v), want Deleted", data, err)
+			}
+		}},
+		{"cached item of another user", func(t *testing.T, store *CachedStorage, item model.TodoItem, queries *int) {
+			if _, err := store.GetItem(context.Background(), map[string]interface{}{"id": item.Id}); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := store.GetItem(context.Background(), map[string]interface{}{"id": item.Id, "user_id": item.UserId + 1}); err != common.RecordNotFound {
+				t.Errorf("err = %v, want RecordNotFound", err)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStore := newTestStore(t)
+			item := createTestItems(t, sqlStore, 1, 1, "item")[0]
+			queries := countQueries(t, sqlStore)
+
+			tt.run(t, NewCachedStorage(sqlStore, newCache(t)), item, queries)
+		})
+	}
+}
+
+func TestCachedStorageMemory(t *testing.T) {
+	testItemCache(t, func(t *testing.T) ItemCache {
+		return NewMemoryItemCache(10, time.Minute)
+	})
+}
+
+func TestCachedStorageWithoutCache(t *testing.T) {
+	sqlStore := newTestStore(t)
+	item := createTestItems(t, sqlStore, 1, 1, "item")[0]
+	queries := countQueries(t, sqlStore)
+	store := NewCachedStorage(sqlStore, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := store.GetItem(context.Background(), map[string]interface{}{"id": item.Id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if *queries != 2 {
+		t.Errorf("queries = %d, want every read to hit SQL", *queries)
+	}
+}
@@ -2,12 +2,17 @@ package storage
 
 import (
 	"context"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
 )
 
 func (s *sqlStore) CreateItem(ctx context.Context, data *model.TodoItemCreation) error {
-	if err := s.db.Create(&data).Error; err != nil {
-		return err
+	if err := common.WithRetry(ctx, writeRetryAttempts, func() error {
+		return s.WithTransaction(ctx, func(txStore *sqlStore) error {
+			return createItems(txStore.db, data)
+		})
+	}); err != nil {
+		return common.ErrDB(err)
 	}
 
 	return nil
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestItemTimestamps(t *testing.T) {
+	ctx := context.Background()
+	done := model.ItemStatusDone
+	title, version := "new title", 2
+
+	tests := []struct {
+		name  string
+		write func(store *sqlStore, id int) error
+	}{
+		{"update", func(store *sqlStore, id int) error {
+			return store.UpdateItem(ctx, map[string]interface{}{"id": id}, &model.TodoItemUpdate{Title: &title})
+		}},
+		{"replace", func(store *sqlStore, id int) error {
+			replace := model.TodoItemReplace{Title: &title, Status: &done, Version: &version}
+			return store.ReplaceItem(ctx, map[string]interface{}{"id": id}, replace.ToUpdate())
+		}},
+		{"bulk status", func(store *sqlStore, id int) error {
+			_, err := store.UpdateItemsStatus(ctx, map[string]interface{}{"user_id": 1}, []int{id}, done, nil, 1)
+			return err
+		}},
+		{"delete", func(store *sqlStore, id int) error {
+			return store.DeleteItem(ctx, map[string]interface{}{"id": id}, 1)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			data := model.TodoItemCreation{Title: "title", UserId: 1}
+
+			if err := store.CreateItem(ctx, &data); err != nil {
+				t.Fatal(err)
+			}
+
+			created, err := store.GetItem(ctx, map[string]interface{}{"id": data.Id})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if created.CreatedAt == nil || time.Since(*created.CreatedAt).Abs() > time.Second {
+				t.Fatalf("created_at = %v, want within a second of now", created.CreatedAt)
+			}
+
+			if created.CreatedAt.Location() != time.UTC || created.UpdatedAt == nil || !created.UpdatedAt.Equal(*created.CreatedAt) {
+				t.Fatalf("created_at = %v, updated_at = %v, want the same UTC time", created.CreatedAt, created.UpdatedAt)
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			if err := tt.write(store, data.Id); err != nil {
+				t.Fatal(err)
+			}
+
+			updated, err := store.GetItem(ctx, map[string]interface{}{"id": data.Id})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !updated.UpdatedAt.After(*created.UpdatedAt) {
+				t.Errorf("updated_at = %v, want after %v", updated.UpdatedAt, created.UpdatedAt)
+			}
+
+			if !updated.CreatedAt.Equal(*created.CreatedAt) {
+				t.Errorf("created_at changed from %v to %v", created.CreatedAt, updated.CreatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+const createItemsBatchSize = 100
+
+// createItems insert item kèm position và audit log, tx phải là transaction.
+// Vượt DailyQuota thì trả model.ErrDailyQuotaExceeded và không insert item nào
+func createItems(tx *gorm.DB, data ...*model.TodoItemCreation) error {
+	if err := lockCreators(tx, data...); err != nil {
+		return err
+	}
+
+	if err := assignPositions(tx, data...); err != nil {
+		return err
+	}
+
+	if err := tx.CreateInBatches(data, createItemsBatchSize).Error; err != nil {
+		return err
+	}
+
+	for _, item := range data {
+		if err := writeAuditLog(tx, item.Id, item.UserId, model.AuditActionCreate, model.CreationChanges(item)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sqlStore) CreateItems(ctx context.Context, data []*model.TodoItemCreation) error {
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		return createItems(txStore.db, data...)
+	}); err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestCreateItemsSetsCompletedAtForDone(t *testing.T) {
+	store := newTestStore(t)
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+
+	data := []*model.TodoItemCreation{
+		{Title: "done", UserId: 1, Status: &done},
+		{Title: "doing", UserId: 1, Status: &doing},
+	}
+
+	if err := store.CreateItems(context.Background(), data); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range data {
+		var got model.TodoItem
+
+		if err := store.db.Where("id = ?", item.Id).First(&got).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if wantCompleted := *item.Status == done; (got.CompletedAt != nil) != wantCompleted {
+			t.Errorf("%s: completed_at = %v", item.Title, got.CompletedAt)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"gorm.io/gorm"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// lockCreators khoá từng user có item sắp được tạo rồi mới kiểm tra DailyQuota, tx phải là transaction.
+// Đếm và insert nằm trong cùng một lần khoá nên các lần tạo đồng thời không vượt được hạn mức.
+// Đếm cả item đã xoá mềm, xoá item không trả lại hạn mức trong ngày (UTC)
+func lockCreators(tx *gorm.DB, data ...*model.TodoItemCreation) error {
+	now := time.Now().UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var userIds []int
+	creating := make(map[int]int64)
+	quota := make(map[int]int)
+
+	for _, item := range data {
+		if _, ok := creating[item.UserId]; !ok {
+			userIds = append(userIds, item.UserId)
+		}
+
+		creating[item.UserId]++
+
+		if item.DailyQuota > quota[item.UserId] {
+			quota[item.UserId] = item.DailyQuota
+		}
+	}
+
+	for _, userId := range userIds {
+		if err := lockUserItems(tx, userId); err != nil {
+			return err
+		}
+
+		if quota[userId] <= 0 {
+			continue
+		}
+
+		var count int64
+
+		if err := tx.Table(model.TodoItem{}.TableName()).
+			Where("user_id = ? AND created_at >= ?", userId, startOfDay).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count+creating[userId] > int64(quota[userId]) {
+			return model.ErrDailyQuotaExceeded
+		}
+	}
+
+	return nil
+}
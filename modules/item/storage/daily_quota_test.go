@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/modules/item/model"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateItemsDailyQuota(t *testing.T) {
+	ctx := context.Background()
+	yesterday := time.Now().UTC().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		quota     int
+		existing  int
+		yesterday int
+		batch     int
+		wantErr   error
+	}{
+		{"no quota", 0, 5, 0, 3, nil},
+		{"batch fits", 3, 0, 0, 3, nil},
+		{"batch over quota", 3, 0, 0, 4, model.ErrDailyQuotaExceeded},
+		{"existing items count", 3, 2, 0, 2, model.ErrDailyQuotaExceeded},
+		{"yesterday not counted", 3, 0, 5, 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			for i := 0; i < tt.existing+tt.yesterday; i++ {
+				item := model.TodoItem{Title: "existing", UserId: 1}
+
+				if i >= tt.existing {
+					item.CreatedAt = &yesterday
+				}
+
+				if err := store.db.Create(&item).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data := make([]*model.TodoItemCreation, tt.batch)
+
+			for i := range data {
+				data[i] = &model.TodoItemCreation{Title: "new", UserId: 1, DailyQuota: tt.quota}
+			}
+
+			err := store.CreateItems(ctx, data)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			var created int64
+
+			if err := store.db.Model(&model.TodoItem{}).Where("title = ?", "new").Count(&created).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if want := int64(tt.batch); tt.wantErr != nil && created != 0 || tt.wantErr == nil && created != want {
+				t.Fatalf("created %d items", created)
+			}
+		})
+	}
+}
+
+func TestConcurrentCreatesRespectDailyQuota(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	quota := 3
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs <- store.CreateItem(ctx, &model.TodoItemCreation{Title: "item", UserId: 1, DailyQuota: quota})
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	rejected := 0
+
+	for err := range errs {
+		if errors.Is(err, model.ErrDailyQuotaExceeded) {
+			rejected++
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var created int64
+
+	if err := store.db.Model(&model.TodoItem{}).Count(&created).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if created != int64(quota) || rejected != 10-quota {
+		t.Fatalf("created %d, rejected %d, want %d and %d", created, rejected, quota, 10-quota)
+	}
+}
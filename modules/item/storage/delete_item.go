@@ -2,20 +2,47 @@ package storage
 
 import (
 	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
+	"time"
 )
 
-func (s *sqlStore) DeleteItem(ctx context.Context, cond map[string]interface{}) error {
+// DeleteItem xoá mềm item khớp cond, updatedBy là người xoá
+func (s *sqlStore) DeleteItem(ctx context.Context, cond map[string]interface{}, updatedBy int) error {
 
 	deletedStatus := model.ItemStatusDeleted
 
-	if err := s.db.Table(model.TodoItem{}.TableName()).
-		Where(cond).
-		Updates(map[string]interface{}{
-			"status": deletedStatus.String(),
-		}).Error; err != nil {
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		items, err := lockItems(txStore.db, func(db *gorm.DB) *gorm.DB { return db.Where(cond) })
 
-		return err
+		if err != nil {
+			return err
+		}
+
+		if err := txStore.db.Table(model.TodoItem{}.TableName()).
+			Where(cond).
+			Updates(map[string]interface{}{
+				"status":     deletedStatus,
+				"updated_at": time.Now().UTC(),
+				"updated_by": updatedBy,
+				"version":    gorm.Expr("version + 1"),
+			}).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			changes := model.AuditChanges{"status": {Old: item.Status, New: &deletedStatus}}
+
+			if err := writeAuditLog(txStore.db, item.Id, item.UserId, model.AuditActionDelete, changes); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+
+		return common.ErrDB(err)
 	}
 
 	return nil
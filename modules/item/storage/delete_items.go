@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// DeleteItems xoá mềm các item thuộc ids trong một câu UPDATE, item đã xoá được bỏ qua
+// nên số trả về chỉ gồm các item thực sự bị xoá lần này. updatedBy là người xoá
+func (s *sqlStore) DeleteItems(ctx context.Context, cond map[string]interface{}, ids []int, updatedBy int) (int64, error) {
+	deletedStatus := model.ItemStatusDeleted
+
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where(cond).Where("id IN ?", ids).Where("(status IS NULL OR status <> ?)", deletedStatus)
+	}
+
+	var rowsAffected int64
+
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		items, err := lockItems(txStore.db, scope)
+
+		if err != nil {
+			return err
+		}
+
+		db := scope(txStore.db.Table(model.TodoItem{}.TableName())).
+			Updates(map[string]interface{}{
+				"status":     deletedStatus,
+				"updated_at": time.Now().UTC(),
+				"updated_by": updatedBy,
+				"version":    gorm.Expr("version + 1"),
+			})
+
+		if db.Error != nil {
+			return db.Error
+		}
+
+		rowsAffected = db.RowsAffected
+
+		for _, item := range items {
+			changes := model.AuditChanges{"status": {Old: item.Status, New: &deletedStatus}}
+
+			if err := writeAuditLog(txStore.db, item.Id, item.UserId, model.AuditActionDelete, changes); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return 0, common.ErrDB(err)
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestDeleteItemsSkipsUnownedAndDeleted(t *testing.T) {
+	ctx := context.Background()
+	doing, deleted := model.ItemStatusDoing, model.ItemStatusDeleted
+	owner := map[string]interface{}{"user_id": 1}
+
+	tests := []struct {
+		name        string
+		ids         func(owned, alreadyDeleted, other int) []int
+		wantDeleted int64
+		wantEvents  int64
+	}{
+		{"owned and unowned", func(owned, _, other int) []int { return []int{owned, other} }, 1, 1},
+		{"already deleted", func(_, alreadyDeleted, _ int) []int { return []int{alreadyDeleted} }, 0, 0},
+		{"missing id", func(int, int, int) []int { return []int{9999} }, 0, 0},
+		{"mix of everything", func(owned, alreadyDeleted, other int) []int { return []int{owned, alreadyDeleted, other, 9999} }, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			items := []model.TodoItem{
+				{Title: "owned", UserId: 1, Status: &doing},
+				{Title: "already deleted", UserId: 1, Status: &deleted},
+				{Title: "other user", UserId: 2, Status: &doing},
+			}
+
+			for i := range items {
+				if err := store.db.Create(&items[i]).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := store.DeleteItems(ctx, owner, tt.ids(items[0].Id, items[1].Id, items[2].Id), 1)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.wantDeleted {
+				t.Errorf("deleted = %d, want %d", got, tt.wantDeleted)
+			}
+
+			var logs, events int64
+
+			if err := store.db.Model(&model.ItemAuditLog{}).Where("action = ?", model.AuditActionDelete).Count(&logs).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.db.Model(&model.OutboxEvent{}).Where("type = ?", model.EventItemDeleted).Count(&events).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if logs != tt.wantEvents || events != tt.wantEvents {
+				t.Errorf("audit logs = %d, outbox events = %d, want %d", logs, events, tt.wantEvents)
+			}
+
+			var other model.TodoItem
+
+			if err := store.db.Where("id = ?", items[2].Id).First(&other).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if *other.Status != doing {
+				t.Errorf("item of another user was deleted")
+			}
+		})
+	}
+}
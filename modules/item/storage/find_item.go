@@ -2,14 +2,32 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"gorm.io/gorm"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
 )
 
 func (s *sqlStore) GetItem(ctx context.Context, cond map[string]interface{}) (*model.TodoItem, error) {
+	return s.GetItemColumns(ctx, cond, nil)
+}
+
+// GetItemColumns chỉ SELECT các cột trong columns, columns rỗng thì lấy hết
+func (s *sqlStore) GetItemColumns(ctx context.Context, cond map[string]interface{}, columns []string) (*model.TodoItem, error) {
 	var data model.TodoItem
 
-	if err := s.db.Where(cond).First(&data).Error; err != nil {
-		return nil, err
+	db := s.db.WithContext(ctx).Where(cond)
+
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	if err := db.First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.RecordNotFound
+		}
+
+		return nil, common.ErrDB(err)
 	}
 
 	return &data, nil
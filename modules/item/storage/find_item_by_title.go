@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+)
+
+// HasActiveItemWithTitle kiểm tra user đã có item chưa xoá nào trùng title (không phân biệt hoa thường)
+func (s *sqlStore) HasActiveItemWithTitle(ctx context.Context, userId int, title string) (bool, error) {
+	deletedStatus := model.ItemStatusDeleted
+
+	var count int64
+
+	if err := s.db.WithContext(ctx).Table(model.TodoItem{}.TableName()).
+		Where("user_id = ? AND (status IS NULL OR status <> ?)", userId, deletedStatus).
+		Where("LOWER(title) = ?", strings.ToLower(strings.TrimSpace(title))).
+		Count(&count).Error; err != nil {
+		return false, common.ErrDB(err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHasActiveItemWithTitle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 2, "Buy milk")
+
+	if err := store.DeleteItem(ctx, map[string]interface{}{"id": items[1].Id}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		userId int
+		title  string
+		want   bool
+	}{
+		{"same title", 1, "Buy milk 1", true},
+		{"normalized title", 1, "  buy MILK 1 ", true},
+		{"reusable after soft delete", 1, "Buy milk 2", false},
+		{"other user", 2, "Buy milk 1", false},
+		{"new title", 1, "Buy bread", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.HasActiveItemWithTitle(ctx, tt.userId, tt.title)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("HasActiveItemWithTitle(%d, %q) = %v, want %v", tt.userId, tt.title, got, tt.want)
+			}
+		})
+	}
+}
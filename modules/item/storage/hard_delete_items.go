@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	commentmodel "social-todo-list/modules/comment/model"
+	"social-todo-list/modules/item/model"
+	subtaskmodel "social-todo-list/modules/subtask/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	"time"
+)
+
+// HardDeleteOlderThan xoá hẳn khỏi DB các item đã xoá mềm có updated_at trước cutoff.
+// Comment, like, subtask, audit log, outbox event và idempotency key của các item đó
+// bị xoá trong cùng transaction để không còn bản ghi mồ côi
+func (s *sqlStore) HardDeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	deletedStatus := model.ItemStatusDeleted
+
+	var rowsAffected int64
+
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		var ids []int
+
+		if err := txStore.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.TodoItem{}).
+			Where("status = ? AND updated_at < ?", deletedStatus, cutoff).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		dependents := []interface{}{
+			&commentmodel.Comment{},
+			&likemodel.Like{},
+			&subtaskmodel.Subtask{},
+			&model.ItemAuditLog{},
+			&model.OutboxEvent{},
+			&model.IdempotencyKey{},
+		}
+
+		for _, dependent := range dependents {
+			if err := txStore.db.Where("item_id IN ?", ids).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		db := txStore.db.Where("id IN ?", ids).Delete(&model.TodoItem{})
+
+		if err := db.Error; err != nil {
+			return err
+		}
+
+		rowsAffected = db.RowsAffected
+
+		return nil
+	}); err != nil {
+		return 0, common.ErrDB(err)
+	}
+
+	return rowsAffected, nil
+}
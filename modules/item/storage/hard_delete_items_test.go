@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	commentmodel "social-todo-list/modules/comment/model"
+	"social-todo-list/modules/item/model"
+	subtaskmodel "social-todo-list/modules/subtask/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	"testing"
+	"time"
+)
+
+func TestHardDeleteOlderThanRemovesDependents(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+
+	deleted, doing := model.ItemStatusDeleted, model.ItemStatusDoing
+
+	items := []model.TodoItem{
+		{Title: "purged", UserId: 1, Status: &deleted},
+		{Title: "recently deleted", UserId: 1, Status: &deleted},
+		{Title: "active", UserId: 1, Status: &doing},
+	}
+
+	for i := range items {
+		if err := store.db.Create(&items[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// updated_at do GORM tự set lúc tạo, sửa lại để item đầu tiên quá hạn lưu trữ
+	if err := store.db.Model(&model.TodoItem{}).Where("id = ?", items[0].Id).
+		UpdateColumn("updated_at", old).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range items {
+		rows := []interface{}{
+			&commentmodel.Comment{ItemId: item.Id, UserId: 2, Content: "hi"},
+			&likemodel.Like{ItemId: item.Id, UserId: 2},
+			&subtaskmodel.Subtask{ItemId: item.Id, Title: "step"},
+			&model.ItemAuditLog{ItemId: item.Id, UserId: 1, Action: model.AuditActionDelete},
+			&model.OutboxEvent{ItemId: item.Id, UserId: 1, Type: model.EventItemDeleted},
+			&model.IdempotencyKey{ItemId: item.Id, UserId: 1, Key: item.Title, ExpiresAt: now},
+		}
+
+		for _, row := range rows {
+			if err := store.db.Create(row).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	purged, err := store.HardDeleteOlderThan(ctx, now.Add(-24*time.Hour))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if purged != 1 {
+		t.Fatalf("purged %d items, want 1", purged)
+	}
+
+	tables := []struct {
+		name  string
+		model interface{}
+	}{
+		{"todo_items", &model.TodoItem{}},
+		{"comments", &commentmodel.Comment{}},
+		{"item_likes", &likemodel.Like{}},
+		{"subtasks", &subtaskmodel.Subtask{}},
+		{"item_audit_log", &model.ItemAuditLog{}},
+		{"events_outbox", &model.OutboxEvent{}},
+		{"item_idempotency_keys", &model.IdempotencyKey{}},
+	}
+
+	for _, tt := range tables {
+		t.Run(tt.name, func(t *testing.T) {
+			column := "item_id"
+
+			if tt.name == "todo_items" {
+				column = "id"
+			}
+
+			var purgedRows, keptRows int64
+
+			if err := store.db.Model(tt.model).Where(column+" = ?", items[0].Id).Count(&purgedRows).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.db.Model(tt.model).Where(column+" <> ?", items[0].Id).Count(&keptRows).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if purgedRows != 0 {
+				t.Errorf("%d rows of the purged item are left", purgedRows)
+			}
+
+			if keptRows != 2 {
+				t.Errorf("%d rows of other items are left, want 2", keptRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+func (s *sqlStore) FindIdempotencyKey(ctx context.Context, userId int, key string) (*model.IdempotencyKey, error) {
+	var data model.IdempotencyKey
+
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND idempotency_key = ?", userId, key).
+		First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.RecordNotFound
+		}
+
+		return nil, common.ErrDB(err)
+	}
+
+	return &data, nil
+}
+
+// CreateItemWithIdempotencyKey tạo item và lưu key trong cùng một transaction.
+// Key đã hết hạn bị xoá trước, còn nếu một request khác vừa lưu cùng key thì insert key lỗi
+// và item cũng bị rollback, không tạo trùng
+func (s *sqlStore) CreateItemWithIdempotencyKey(
+	ctx context.Context,
+	data *model.TodoItemCreation,
+	key *model.IdempotencyKey,
+) error {
+	if err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND idempotency_key = ? AND expires_at <= ?", key.UserId, key.Key, time.Now().UTC()).
+			Delete(&model.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
+		if err := createItems(tx, data); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		key.ItemId = data.Id
+		key.CreatedAt = &now
+
+		return tx.Create(key).Error
+	}); err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+const (
+	ItemCacheNone   = "none"
+	ItemCacheMemory = "memory"
+	ItemCacheRedis  = "redis"
+)
+
+// ItemCache là nơi CachedStorage lưu item theo id. Cache lỗi (ví dụ mất kết nối Redis) thì
+// Get coi như miss, các hàm còn lại bỏ qua lỗi để request vẫn đọc/ghi được xuống DB
+type ItemCache interface {
+	Get(ctx context.Context, id int) (*model.TodoItem, bool)
+	Set(ctx context.Context, item *model.TodoItem)
+	Delete(ctx context.Context, ids ...int)
+	// DeleteUser xoá mọi item của user, dùng khi một thao tác sửa nhiều item không biết trước id
+	DeleteUser(ctx context.Context, userId int)
+	Clear(ctx context.Context)
+}
+
+// NewItemCache chọn cache theo ITEM_CACHE_BACKEND, "none" (mặc định) trả về nil để tắt cache.
+// Redis chưa kết nối được lúc khởi động vẫn không lỗi, request sẽ đọc thẳng DB cho tới khi Redis lên lại
+func NewItemCache(cfg common.Config) (ItemCache, error) {
+	switch cfg.ItemCacheBackend {
+	case ItemCacheNone, "":
+		return nil, nil
+	case ItemCacheMemory:
+		return NewMemoryItemCache(cfg.ItemCacheSize, cfg.ItemCacheTTL), nil
+	case ItemCacheRedis:
+		// Timeout ngắn để Redis chậm hoặc mất kết nối không làm request chậm theo, lỗi thì đọc DB
+		client := redis.NewClient(&redis.Options{
+			Addr:         cfg.RedisAddr,
+			Password:     cfg.RedisPassword,
+			DB:           cfg.RedisDB,
+			DialTimeout:  500 * time.Millisecond,
+			ReadTimeout:  200 * time.Millisecond,
+			WriteTimeout: 200 * time.Millisecond,
+		})
+
+		return NewRedisItemCache(client, cfg.ItemCacheTTL), nil
+	default:
+		return nil, fmt.Errorf("unsupported ITEM_CACHE_BACKEND %q", cfg.ItemCacheBackend)
+	}
+}
+
+type memoryItemCache struct {
+	lru *common.LRUCache[int, model.TodoItem]
+}
+
+// NewMemoryItemCache cache trong bộ nhớ của instance, trả về nil (tắt cache) nếu size hoặc ttl <= 0
+func NewMemoryItemCache(size int, ttl time.Duration) ItemCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+
+	return &memoryItemCache{lru: common.NewLRUCache[int, model.TodoItem](size, ttl)}
+}
+
+// Get trả về bản sao để biz sửa item (Mask, Owner...) không ảnh hưởng tới cache
+func (c *memoryItemCache) Get(_ context.Context, id int) (*model.TodoItem, bool) {
+	item, ok := c.lru.Get(id)
+
+	if !ok {
+		return nil, false
+	}
+
+	return &item, true
+}
+
+func (c *memoryItemCache) Set(_ context.Context, item *model.TodoItem) {
+	c.lru.Set(item.Id, *item)
+}
+
+func (c *memoryItemCache) Delete(_ context.Context, ids ...int) {
+	c.lru.Delete(ids...)
+}
+
+func (c *memoryItemCache) DeleteUser(_ context.Context, userId int) {
+	c.lru.DeleteFunc(func(_ int, item model.TodoItem) bool { return item.UserId == userId })
+}
+
+func (c *memoryItemCache) Clear(_ context.Context) {
+	c.lru.DeleteFunc(func(int, model.TodoItem) bool { return true })
+}
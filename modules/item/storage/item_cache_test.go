@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"social-todo-list/common"
+	"testing"
+	"time"
+)
+
+func TestNewItemCache(t *testing.T) {
+	tests := []struct {
+		backend   string
+		wantCache bool
+		wantErr   bool
+	}{
+		{"", false, false},
+		{ItemCacheNone, false, false},
+		{ItemCacheMemory, true, false},
+		{ItemCacheRedis, true, false},
+		{"memcached", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			cache, err := NewItemCache(common.Config{
+				ItemCacheBackend: tt.backend,
+				ItemCacheSize:    10,
+				ItemCacheTTL:     time.Minute,
+				RedisAddr:        "127.0.0.1:0",
+			})
+
+			if (err != nil) != tt.wantErr || (cache != nil) != tt.wantCache {
+				t.Errorf("cache = %v, err = %v, want cache %v, error %v", cache, err, tt.wantCache, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// TestWritePathsEmitOutboxEvents kiểm tra các API ghi hàng loạt cũng có event trong outbox (nguồn event của websocket)
+func TestWritePathsEmitOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	doing, done, deleted := model.ItemStatusDoing, model.ItemStatusDone, model.ItemStatusDeleted
+
+	tests := []struct {
+		name  string
+		write func(store *sqlStore, ids []int) error
+		want  []string
+	}{
+		{
+			"batch create and import",
+			func(store *sqlStore, ids []int) error {
+				return store.CreateItems(ctx, []*model.TodoItemCreation{
+					{UserId: 1, Title: "c", Status: &doing},
+					{UserId: 1, Title: "d", Status: &doing},
+				})
+			},
+			[]string{model.EventItemCreated, model.EventItemCreated},
+		},
+		{
+			"bulk status",
+			func(store *sqlStore, ids []int) error {
+				_, err := store.UpdateItemsStatus(ctx, map[string]interface{}{"user_id": 1}, ids, done, nil, 1)
+				return err
+			},
+			[]string{model.EventItemUpdated, model.EventItemUpdated},
+		},
+		{
+			"bulk delete",
+			func(store *sqlStore, ids []int) error {
+				_, err := store.DeleteItems(ctx, map[string]interface{}{"user_id": 1}, ids, 1)
+				return err
+			},
+			[]string{model.EventItemDeleted, model.EventItemDeleted},
+		},
+		{
+			"restore",
+			func(store *sqlStore, ids []int) error {
+				if err := store.db.Model(&model.TodoItem{}).Where("id = ?", ids[0]).
+					Update("status", deleted).Error; err != nil {
+					return err
+				}
+
+				return store.UpdateItem(ctx, map[string]interface{}{"id": ids[0]}, &model.TodoItemUpdate{Status: &doing})
+			},
+			[]string{model.EventItemUpdated},
+		},
+		{
+			"reorder",
+			func(store *sqlStore, ids []int) error {
+				return store.MoveItemAfter(ctx, 1, ids[0], &ids[1])
+			},
+			[]string{model.EventItemUpdated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			items := []*model.TodoItemCreation{
+				{UserId: 1, Title: "a", Status: &doing},
+				{UserId: 1, Title: "b", Status: &doing},
+			}
+
+			if err := store.CreateItems(ctx, items); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.db.Where("1 = 1").Delete(&model.OutboxEvent{}).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.write(store, []int{items[0].Id, items[1].Id}); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+
+			if err := store.db.Model(&model.OutboxEvent{}).Order("id").Pluck("type", &got).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("outbox events %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("outbox events %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
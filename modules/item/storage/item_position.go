@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// Thứ tự khi sort=position, các item trùng position (item cũ trước khi có cột position) thì mới trước
+const positionOrder = "position asc, id desc"
+
+type itemPosition struct {
+	Id       int     `gorm:"column:id;"`
+	Position float64 `gorm:"column:position;"`
+}
+
+// assignPositions đặt các item mới vào cuối danh sách của user, theo đúng thứ tự trong data.
+// Các user phải được khoá trước (lockCreators) để các lần tạo đồng thời không bị trùng position
+func assignPositions(db *gorm.DB, data ...*model.TodoItemCreation) error {
+	next := make(map[int]float64)
+
+	for _, item := range data {
+		position, ok := next[item.UserId]
+
+		if !ok {
+			var maxPosition float64
+
+			if err := db.Table(model.TodoItem{}.TableName()).
+				Where("user_id = ?", item.UserId).
+				Select("COALESCE(MAX(position), 0)").
+				Scan(&maxPosition).Error; err != nil {
+				return err
+			}
+
+			position = maxPosition + model.ItemPositionGap
+		}
+
+		item.Position = position
+		next[item.UserId] = position + model.ItemPositionGap
+	}
+
+	return nil
+}
+
+// MoveItemAfter chuyển item tới ngay sau afterId (nil là lên đầu), chỉ update position của một dòng.
+// Khi hai item liền kề không còn chỗ chèn thì đánh số lại cả danh sách rồi tính lại.
+// Chạy trong transaction, khoá user và các dòng liên quan để các lần reorder hay tạo item đồng thời không đè lên nhau
+func (s *sqlStore) MoveItemAfter(ctx context.Context, userId, id int, afterId *int) error {
+	err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		var current itemPosition
+
+		if err := lockUserItems(txStore.db, userId); err != nil {
+			return err
+		}
+
+		if err := txStore.activeItems(userId, 0).Where("id = ?", id).Take(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return common.RecordNotFound
+			}
+
+			return err
+		}
+
+		for rebalanced := false; ; rebalanced = true {
+			position, ok, err := txStore.positionAfter(userId, id, afterId)
+
+			if err != nil {
+				return err
+			}
+
+			if ok || rebalanced {
+				// Chỉ chủ item mới reorder được nên userId cũng là người sửa
+				if err := txStore.db.Table(model.TodoItem{}.TableName()).
+					Where("id = ?", id).
+					Updates(map[string]interface{}{"position": position, "updated_by": userId}).Error; err != nil {
+					return err
+				}
+
+				changes := model.AuditChanges{"position": {Old: current.Position, New: position}}
+
+				return writeAuditLog(txStore.db, id, userId, model.AuditActionUpdate, changes)
+			}
+
+			if err := txStore.rebalancePositions(userId); err != nil {
+				return err
+			}
+		}
+	})
+
+	if err == common.RecordNotFound {
+		return err
+	}
+
+	if err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
+
+// activeItems là các item chưa xoá của user, trừ item đang được chuyển
+func (s *sqlStore) activeItems(userId, excludeId int) *gorm.DB {
+	deletedStatus := model.ItemStatusDeleted
+
+	return s.db.Table(model.TodoItem{}.TableName()).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND (status IS NULL OR status <> ?) AND id <> ?", userId, deletedStatus, excludeId)
+}
+
+func (s *sqlStore) positionAfter(userId, id int, afterId *int) (float64, bool, error) {
+	var prev *float64
+	next := s.activeItems(userId, id).Order(positionOrder)
+
+	if afterId != nil {
+		var after itemPosition
+
+		if err := s.activeItems(userId, id).Where("id = ?", *afterId).Take(&after).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return 0, false, common.RecordNotFound
+			}
+
+			return 0, false, err
+		}
+
+		prev = &after.Position
+		next = next.Where("position > ? OR (position = ? AND id < ?)", after.Position, after.Position, after.Id)
+	}
+
+	var nextItem itemPosition
+	var nextPosition *float64
+
+	if err := next.Take(&nextItem).Error; err == nil {
+		nextPosition = &nextItem.Position
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, err
+	}
+
+	position, ok := model.PositionBetween(prev, nextPosition)
+
+	return position, ok, nil
+}
+
+// rebalancePositions đánh số lại position cách đều nhau, giữ nguyên thứ tự đang hiển thị
+func (s *sqlStore) rebalancePositions(userId int) error {
+	var ids []int
+
+	if err := s.activeItems(userId, 0).Order(positionOrder).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if err := s.db.Table(model.TodoItem{}.TableName()).
+			Where("id = ?", id).
+			Update("position", float64(i+1)*model.ItemPositionGap).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
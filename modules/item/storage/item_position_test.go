@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"sync"
+	"testing"
+)
+
+func TestCreateItemsAssignsDistinctPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		batches [][]int
+	}{
+		{name: "single user sequential", batches: [][]int{{1}, {1}, {1, 1}}},
+		{name: "two users in one batch", batches: [][]int{{1, 2, 1, 2}}},
+		{name: "first item of a user", batches: [][]int{{3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			ctx := context.Background()
+
+			for _, batch := range tt.batches {
+				data := make([]*model.TodoItemCreation, len(batch))
+
+				for i, userId := range batch {
+					data[i] = &model.TodoItemCreation{Title: "item", UserId: userId}
+				}
+
+				if err := store.CreateItems(ctx, data); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			assertDistinctPositions(t, store)
+		})
+	}
+}
+
+func TestConcurrentCreatesAndReorderKeepPositionsDistinct(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	doing := model.ItemStatusDoing
+	first := &model.TodoItemCreation{Title: "first", UserId: 1, Status: &doing}
+
+	if err := store.CreateItems(ctx, []*model.TodoItemCreation{first}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 11)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs <- store.CreateItems(ctx, []*model.TodoItemCreation{{Title: "item", UserId: 1}})
+		}()
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		errs <- store.MoveItemAfter(ctx, 1, first.Id, nil)
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertDistinctPositions(t, store)
+
+	var locks int64
+
+	if err := store.db.Model(&model.ItemUserLock{}).Count(&locks).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if locks != 1 {
+		t.Fatalf("lock rows = %d, want 1", locks)
+	}
+}
+
+// assertDistinctPositions kiểm tra không có hai item của cùng user trùng position
+func assertDistinctPositions(t *testing.T, store *sqlStore) {
+	t.Helper()
+
+	var items []model.TodoItem
+
+	if err := store.db.Find(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[int]map[float64]int)
+
+	for _, item := range items {
+		if seen[item.UserId] == nil {
+			seen[item.UserId] = make(map[float64]int)
+		}
+
+		if other, ok := seen[item.UserId][item.Position]; ok {
+			t.Fatalf("items %d and %d of user %d share position %v", other, item.Id, item.UserId, item.Position)
+		}
+
+		seen[item.UserId][item.Position] = item.Id
+	}
+}
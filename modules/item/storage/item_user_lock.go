@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"social-todo-list/modules/item/model"
+)
+
+// lockUserItems khoá dòng item_user_locks của user tới hết transaction, tx phải là transaction.
+// Dòng được tạo ở lần khoá đầu tiên, nên cả user chưa có item nào cũng được khoá
+func lockUserItems(tx *gorm.DB, userId int) error {
+	lock := model.ItemUserLock{UserId: userId}
+
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock).Error; err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userId).Take(&lock).Error
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// IterateItems đọc từng dòng bằng Rows() rồi gọi fn, không load hết item vào bộ nhớ.
+// fn trả lỗi thì dừng và trả về lỗi đó
+func (s *sqlStore) IterateItems(
+	ctx context.Context,
+	cond map[string]interface{},
+	fn func(item *model.TodoItem) error,
+) error {
+	deletedStatus := model.ItemStatusDeleted
+
+	db := s.db.WithContext(ctx).Model(&model.TodoItem{}).
+		Where(cond).
+		Where("(status IS NULL OR status <> ?)", deletedStatus).
+		Order("id asc")
+
+	rows, err := db.Rows()
+
+	if err != nil {
+		return common.ErrDB(err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var item model.TodoItem
+
+		if err := db.ScanRows(rows, &item); err != nil {
+			return common.ErrDB(err)
+		}
+
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestIterateItems(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 3, "item")
+	createTestItems(t, store, 2, 1, "other")
+
+	if err := store.DeleteItem(ctx, map[string]interface{}{"id": items[1].Id}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name    string
+		stopAt  int
+		wantIds []int
+		wantErr error
+	}{
+		{"own live items in id order", 0, []int{items[0].Id, items[2].Id}, nil},
+		{"callback error stops iteration", items[0].Id, []int{items[0].Id}, errStop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []int
+
+			err := store.IterateItems(ctx, map[string]interface{}{"user_id": 1}, func(item *model.TodoItem) error {
+				ids = append(ids, item.Id)
+
+				if item.Id == tt.stopAt {
+					return errStop
+				}
+
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIds) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIds)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"gorm.io/gorm/clause"
 	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
+	"strings"
+	"time"
 )
 
 func (s *sqlStore) ListItem(
@@ -14,23 +18,121 @@ func (s *sqlStore) ListItem(
 ) ([]model.TodoItem, error) {
 	var result []model.TodoItem
 
-	db := s.db.Where("status <> ?", "Deleted")
+	// status NULL (item cũ) không phải Deleted, "status <> ?" một mình sẽ bỏ sót các item đó
+	db := s.db.WithContext(ctx).Where("(status IS NULL OR status <> ?)", model.ItemStatusDeleted)
 
 	if f := filter; f != nil {
-		if v := f.Status; v != "" {
-			db = db.Where("status = ?", v)
+		if v := f.UserId; v > 0 {
+			db = db.Where("user_id = ?", v)
+		}
+
+		if statuses, err := f.Statuses(); err == nil && len(statuses) > 0 {
+			db = db.Where("status IN ?", statuses)
+		}
+
+		if priorities, err := f.Priorities(); err == nil && len(priorities) > 0 {
+			db = db.Where("priority IN ?", priorities)
+		}
+
+		// Escape để % và _ trong từ khoá được tìm đúng ký tự thay vì là wildcard
+		if v := strings.TrimSpace(f.Search); v != "" {
+			term := "%" + escapeLike(strings.ToLower(v)) + "%"
+			db = db.Where("(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!')", term, term)
+		}
+
+		// tags là mảng JSON đã được normalize nên chỉ cần tìm phần tử đã encode JSON (có cả dấu nháy)
+		if v := strings.ToLower(strings.TrimSpace(f.Tag)); v != "" {
+			needle, _ := json.Marshal(v)
+			db = db.Where("tags LIKE ? ESCAPE '!'", "%"+escapeLike(string(needle))+"%")
+		}
+
+		if f.Overdue {
+			db = db.Where("due_date < ? AND (status IS NULL OR status <> ?)", time.Now().UTC(), model.ItemStatusDone)
+		}
+
+		if v := f.DueBefore; v != nil {
+			db = db.Where("due_date < ?", v.UTC())
+		}
+
+		if v := f.DueAfter; v != nil {
+			db = db.Where("due_date >= ?", v.UTC())
+		}
+
+		// "Hôm nay" tính theo timezone của filter, AddDate theo giờ địa phương để đúng cả ngày đổi giờ (DST)
+		if f.DueToday {
+			loc := f.Location()
+			start := common.StartOfDay(time.Now(), loc)
+			end := start.In(loc).AddDate(0, 0, 1).UTC()
+			db = db.Where("due_date >= ? AND due_date < ?", start, end)
+		}
+
+		switch {
+		case f.OnlyArchived:
+			db = db.Where("archived = ?", true)
+		case !f.IncludeArchived:
+			db = db.Where("archived = ?", false)
 		}
 	}
 
 	if err := db.Table(model.TodoItem{}.TableName()).Count(&paging.Total).Error; err != nil {
 
-		return nil, err
+		return nil, common.ErrDB(err)
 	}
 
-	if err := db.Order("id desc").
-		Offset((paging.Page - 1) * paging.Limit).
-		Limit(paging.Limit).Find(&result).Error; err != nil {
-		return nil, err
+	limit := paging.Limit
+
+	if paging.CursorMode {
+		// Cursor dựa trên id nên luôn sort theo id desc, cursor rỗng là trang đầu tiên
+		if v := paging.FakeCursor; v != "" {
+			uid, err := common.FromBase58(v)
+
+			if err != nil {
+				return nil, common.ErrInvalidRequest(err)
+			}
+
+			db = db.Where("id < ?", uid.GetLocalID())
+		}
+
+		// Lấy thêm một dòng để biết còn trang sau không, trang cuối vừa đủ Limit thì không trả cursor
+		db = db.Order("id desc")
+		limit++
+	} else if filter != nil && filter.Sort == model.SortSmart {
+		db = db.Order(smartOrder(time.Now().UTC())).Offset((paging.Page - 1) * paging.Limit)
+	} else {
+		db = db.Order(filter.OrderBy()).Offset((paging.Page - 1) * paging.Limit)
+	}
+
+	if columns := model.FieldColumns(filter.FieldList()); columns != nil {
+		db = db.Select(columns)
 	}
+
+	if err := db.Limit(limit).Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	if paging.CursorMode && len(result) > paging.Limit {
+		result = result[:paging.Limit]
+		last := result[len(result)-1]
+		last.Mask()
+		paging.NextCursor = last.FakeId.String()
+	}
+
 	return result, nil
 }
+
+// smartOrder là ORDER BY của sort=smart, sort trong DB để phân trang vẫn đúng.
+// Điều kiện quá hạn giống filter overdue, cuối cùng là id để thứ tự giữa các trang luôn ổn định
+func smartOrder(now time.Time) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL: "CASE WHEN due_date < ? AND (status IS NULL OR status <> ?) THEN 0 ELSE 1 END, " +
+			"CASE WHEN due_date IS NULL THEN 1 ELSE 0 END, " +
+			"due_date asc, priority desc, created_at asc, id asc",
+		Vars:               []interface{}{now, model.ItemStatusDone},
+		WithoutParentheses: true,
+	}}
+}
+
+// escapeLike escape các ký tự đặc biệt của LIKE, dùng cùng ESCAPE '!'
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
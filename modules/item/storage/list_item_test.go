@@ -0,0 +1,630 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// createTestItems tạo n item Doing của userId với title "<prefix> 1".."<prefix> n"
+func createTestItems(t *testing.T, store *sqlStore, userId, n int, prefix string) []model.TodoItem {
+	t.Helper()
+
+	items := make([]model.TodoItem, n)
+
+	for i := range items {
+		doing := model.ItemStatusDoing
+		items[i] = model.TodoItem{Title: fmt.Sprintf("%s %d", prefix, i+1), UserId: userId, Status: &doing}
+	}
+
+	if err := store.db.Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return items
+}
+
+func TestListItemPaging(t *testing.T) {
+	store := newTestStore(t)
+	createTestItems(t, store, 1, 25, "item")
+	createTestItems(t, store, 2, 3, "other")
+
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		wantItems int
+	}{
+		{"first page", 1, 10, 10},
+		{"middle page", 2, 10, 10},
+		{"last page", 3, 10, 5},
+		{"past the end", 4, 10, 0},
+		{"default limit", 0, 0, 10},
+		{"one page", 1, 100, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{Page: tt.page, Limit: tt.limit}
+
+			if err := paging.Process(); err != nil {
+				t.Fatal(err)
+			}
+
+			items, err := store.ListItem(context.Background(), &model.Filter{UserId: 1}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != tt.wantItems {
+				t.Errorf("items = %d, want %d", len(items), tt.wantItems)
+			}
+
+			if paging.Total != 25 {
+				t.Errorf("total = %d, want 25", paging.Total)
+			}
+
+			for _, item := range items {
+				if item.UserId != 1 {
+					t.Errorf("item %d of user %d listed for user 1", item.Id, item.UserId)
+				}
+			}
+		})
+	}
+}
+
+func TestListItemSkipsDeleted(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 3, "item")
+
+	if err := store.DeleteItem(ctx, map[string]interface{}{"id": items[1].Id}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		filter  *model.Filter
+		wantIds []int
+	}{
+		{"default", &model.Filter{UserId: 1}, []int{items[2].Id, items[0].Id}},
+		{"status filter cannot bring deleted back", &model.Filter{UserId: 1, Status: []string{"Deleted"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, tt.filter, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(result))
+
+			for i := range result {
+				ids[i] = result[i].Id
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIds) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIds)
+			}
+
+			if paging.Total != int64(len(tt.wantIds)) {
+				t.Errorf("total = %d, want %d", paging.Total, len(tt.wantIds))
+			}
+		})
+	}
+}
+
+func TestListItemStatusFilter(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 6, "item")
+
+	// items[0..2] Doing, items[3..4] Done, items[5] Deleted
+	done := model.ItemStatusDone
+
+	if _, err := store.UpdateItemsStatus(ctx, map[string]interface{}{"user_id": 1}, []int{items[3].Id, items[4].Id}, done, nil, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteItem(ctx, map[string]interface{}{"id": items[5].Id}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		status    []string
+		limit     int
+		wantItems int
+		wantTotal int64
+	}{
+		{"no filter lists every non-deleted item", nil, 10, 5, 5},
+		{"doing", []string{"Doing"}, 10, 3, 3},
+		{"done", []string{"Done"}, 10, 2, 2},
+		{"doing and done", []string{"Doing", "Done"}, 10, 5, 5},
+		{"total is the filtered count across pages", []string{"Doing"}, 2, 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{Limit: tt.limit}
+			_ = paging.Process()
+
+			filter := &model.Filter{UserId: 1, Status: tt.status}
+			result, err := store.ListItem(ctx, filter, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(result) != tt.wantItems || paging.Total != tt.wantTotal {
+				t.Fatalf("items = %d, total = %d, want %d and %d", len(result), paging.Total, tt.wantItems, tt.wantTotal)
+			}
+
+			for _, item := range result {
+				if len(tt.status) > 0 && !containsString(tt.status, item.Status.String()) {
+					t.Errorf("item %d has status %s, want one of %v", item.Id, item.Status.String(), tt.status)
+				}
+			}
+		})
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestListItemSearch(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	doing := model.ItemStatusDoing
+
+	items := []model.TodoItem{
+		{Title: "Buy MILK", Description: "at the corner shop", UserId: 1, Status: &doing},
+		{Title: "Call mom", Description: "ask about the milk recipe", UserId: 1, Status: &doing},
+		{Title: "Sale 50% off", Description: "", UserId: 1, Status: &doing},
+		{Title: "file_name", Description: "rename it", UserId: 1, Status: &doing},
+		{Title: "filename", Description: "", UserId: 1, Status: &doing},
+	}
+
+	if err := store.db.Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		search  string
+		status  []string
+		wantIds []int
+	}{
+		{"title only, case-insensitive", "buy milk", nil, []int{items[0].Id}},
+		{"description only", "recipe", nil, []int{items[1].Id}},
+		{"title and description", "milk", nil, []int{items[1].Id, items[0].Id}},
+		{"no match", "nothing like this", nil, nil},
+		{"empty search is a no-op", "  ", nil, []int{items[4].Id, items[3].Id, items[2].Id, items[1].Id, items[0].Id}},
+		{"percent is literal", "%", nil, []int{items[2].Id}},
+		{"underscore is literal", "file_", nil, []int{items[3].Id}},
+		{"combined with status", "milk", []string{"Done"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, &model.Filter{UserId: 1, Search: tt.search, Status: tt.status}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(result))
+
+			for i := range result {
+				ids[i] = result[i].Id
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIds) || paging.Total != int64(len(tt.wantIds)) {
+				t.Errorf("ids = %v, total = %d, want %v", ids, paging.Total, tt.wantIds)
+			}
+		})
+	}
+}
+
+func TestListItemSort(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	doing := model.ItemStatusDoing
+	low, medium, high := model.ItemPriorityLow, model.ItemPriorityMedium, model.ItemPriorityHigh
+
+	items := []model.TodoItem{
+		{Title: "b", UserId: 1, Status: &doing, Priority: &low},
+		{Title: "c", UserId: 1, Status: &doing, Priority: &high},
+		{Title: "a", UserId: 1, Status: &doing, Priority: &medium},
+	}
+
+	if err := store.db.Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		filter     model.Filter
+		wantTitles string
+	}{
+		{"default id desc", model.Filter{}, "[a c b]"},
+		{"id asc", model.Filter{Sort: "id", Order: "asc"}, "[b c a]"},
+		{"title asc", model.Filter{Sort: "title", Order: "asc"}, "[a b c]"},
+		{"title desc", model.Filter{Sort: "title", Order: "desc"}, "[c b a]"},
+		{"priority desc", model.Filter{Sort: "priority", Order: "desc"}, "[c a b]"},
+		{"priority filter", model.Filter{Priority: []string{"Low", "High"}, Sort: "title", Order: "asc"}, "[b c]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			filter := tt.filter
+			filter.UserId = 1
+
+			result, err := store.ListItem(ctx, &filter, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			titles := make([]string, len(result))
+
+			for i := range result {
+				titles[i] = result[i].Title
+			}
+
+			if fmt.Sprint(titles) != tt.wantTitles {
+				t.Errorf("titles = %v, want %s", titles, tt.wantTitles)
+			}
+		})
+	}
+}
+
+func TestListItemCursorPaging(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		items     int
+		wantPages []int
+	}{
+		{"25 items in pages of 10", 25, []int{10, 10, 5}},
+		{"last page exactly full", 20, []int{10, 10}},
+		{"fewer than one page", 3, []int{3}},
+		{"no items", 0, []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			if tt.items > 0 {
+				createTestItems(t, store, 1, tt.items, "item")
+			}
+
+			var pages []int
+			seen := map[int]bool{}
+			lastId := 0
+			cursor := ""
+
+			for page := 0; page < 10; page++ {
+				paging := common.Paging{Limit: 10, FakeCursor: cursor, CursorMode: true}
+				_ = paging.Process()
+
+				result, err := store.ListItem(ctx, &model.Filter{UserId: 1}, &paging)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				pages = append(pages, len(result))
+
+				for _, item := range result {
+					if seen[item.Id] || lastId != 0 && item.Id >= lastId {
+						t.Fatalf("item %d out of order or repeated", item.Id)
+					}
+
+					seen[item.Id] = true
+					lastId = item.Id
+				}
+
+				if paging.NextCursor == "" {
+					break
+				}
+
+				cursor = paging.NextCursor
+			}
+
+			if fmt.Sprint(pages) != fmt.Sprint(tt.wantPages) || len(seen) != tt.items {
+				t.Errorf("pages = %v (%d items), want %v (%d items)", pages, len(seen), tt.wantPages, tt.items)
+			}
+		})
+	}
+}
+
+func TestListItemOffsetModeHasNoCursor(t *testing.T) {
+	store := newTestStore(t)
+	createTestItems(t, store, 1, 25, "item")
+
+	paging := common.Paging{Limit: 10}
+	_ = paging.Process()
+
+	if _, err := store.ListItem(context.Background(), &model.Filter{UserId: 1}, &paging); err != nil {
+		t.Fatal(err)
+	}
+
+	if paging.NextCursor != "" {
+		t.Errorf("next_cursor = %q in offset mode", paging.NextCursor)
+	}
+}
+
+func TestListItemTagFilter(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	items := []model.TodoItemCreation{
+		{Title: "write report", UserId: 1, Tags: model.ItemTags{"work", "urgent"}},
+		{Title: "water plants", UserId: 1, Tags: model.ItemTags{"home"}},
+		{Title: "read book", UserId: 1, Tags: model.ItemTags{"homework"}},
+	}
+
+	for i := range items {
+		if err := store.CreateItem(ctx, &items[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved, err := store.GetItem(ctx, map[string]interface{}{"id": items[0].Id})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(saved.Tags) != "[work urgent]" {
+		t.Errorf("tags read back = %v, want [work urgent]", saved.Tags)
+	}
+
+	tests := []struct {
+		name    string
+		tag     string
+		wantIds []int
+	}{
+		{"one of two tags", "urgent", []int{items[0].Id}},
+		{"case-insensitive", "WORK", []int{items[0].Id}},
+		{"whole tag only", "home", []int{items[1].Id}},
+		{"no match", "garden", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, &model.Filter{UserId: 1, Tag: tt.tag}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(result))
+
+			for i := range result {
+				ids[i] = result[i].Id
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIds) {
+				t.Errorf("ids = %v, want %v", ids, tt.wantIds)
+			}
+		})
+	}
+}
+
+func TestListItemOverdue(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 5, "item")
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	// item 1, 2 quá hạn, item 3 quá hạn nhưng đã Done, item 4 chưa đến hạn, item 5 không có due date
+	updates := []struct {
+		item    model.TodoItem
+		dueDate time.Time
+		done    bool
+	}{
+		{items[0], past, false},
+		{items[1], past, false},
+		{items[2], past, true},
+		{items[3], future, false},
+	}
+
+	for _, u := range updates {
+		columns := map[string]interface{}{"due_date": u.dueDate.UTC()}
+
+		if u.done {
+			columns["status"] = model.ItemStatusDone
+		}
+
+		if err := store.db.Model(&model.TodoItem{}).Where("id = ?", u.item.Id).Updates(columns).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		wantIds   []int
+		wantTotal int64
+	}{
+		{"all overdue", 10, []int{items[1].Id, items[0].Id}, 2},
+		{"paged", 1, []int{items[1].Id}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := common.Paging{Limit: tt.limit}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, &model.Filter{UserId: 1, Overdue: true}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]int, len(result))
+
+			for i := range result {
+				ids[i] = result[i].Id
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIds) || paging.Total != tt.wantTotal {
+				t.Errorf("ids = %v, total = %d, want %v, %d", ids, paging.Total, tt.wantIds, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestListItemDueTodayTimezone(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 3, "item")
+
+	la, err := time.LoadLocation("America/Los_Angeles")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	utcStart := common.StartOfDay(now, time.UTC)
+
+	// Các mốc nằm sát ranh giới ngày: sáng sớm và tối muộn theo UTC, tối muộn theo Los Angeles.
+	// Tuỳ giờ chạy test mà mốc rơi vào "hôm nay" của timezone này hay không, nhưng hai timezone luôn cho kết quả khác nhau
+	dueDates := []time.Time{
+		utcStart.Add(30 * time.Minute),
+		utcStart.Add(23*time.Hour + 30*time.Minute),
+		common.StartOfDay(now, la).Add(23*time.Hour + 59*time.Minute),
+	}
+
+	for i, dueDate := range dueDates {
+		if err := store.db.Model(&model.TodoItem{}).Where("id = ?", items[i].Id).Update("due_date", dueDate).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := map[string]string{}
+
+	for _, loc := range []*time.Location{time.UTC, la} {
+		t.Run(loc.String(), func(t *testing.T) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, &model.Filter{UserId: 1, DueToday: true, Timezone: loc.String()}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[int]bool{}
+
+			for _, item := range result {
+				got[item.Id] = true
+			}
+
+			y, m, d := now.In(loc).Date()
+
+			for i, dueDate := range dueDates {
+				dy, dm, dd := dueDate.In(loc).Date()
+
+				if want := dy == y && dm == m && dd == d; got[items[i].Id] != want {
+					t.Errorf("item due %v listed = %v, want %v", dueDate.In(loc), got[items[i].Id], want)
+				}
+			}
+
+			results[loc.String()] = fmt.Sprint(got)
+		})
+	}
+
+	if results["UTC"] == results["America/Los_Angeles"] {
+		t.Errorf("due today in UTC and Los Angeles = %s, want different items around the day boundary", results["UTC"])
+	}
+}
+
+func TestListItemSmartSort(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	low, medium, high := model.ItemPriorityLow, model.ItemPriorityMedium, model.ItemPriorityHigh
+
+	now := time.Now().UTC()
+	yesterday, twoHoursAgo, threeDaysAgo := now.AddDate(0, 0, -1), now.Add(-2*time.Hour), now.AddDate(0, 0, -3)
+	tomorrow, nextWeek := now.AddDate(0, 0, 1), now.AddDate(0, 0, 7)
+
+	items := []model.TodoItem{
+		{Title: "no due low", UserId: 1, Status: &doing, Priority: &low},
+		{Title: "tomorrow low", UserId: 1, Status: &doing, Priority: &low, DueDate: &tomorrow},
+		{Title: "overdue high", UserId: 1, Status: &doing, Priority: &high, DueDate: &twoHoursAgo},
+		{Title: "next week", UserId: 1, Status: &doing, Priority: &medium, DueDate: &nextWeek},
+		{Title: "no due high", UserId: 1, Status: &doing, Priority: &high},
+		{Title: "done in the past", UserId: 1, Status: &done, Priority: &high, DueDate: &threeDaysAgo},
+		{Title: "tomorrow high", UserId: 1, Status: &doing, Priority: &high, DueDate: &tomorrow},
+		{Title: "overdue low", UserId: 1, Status: &doing, Priority: &low, DueDate: &yesterday},
+	}
+
+	if err := store.db.Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Quá hạn trước, rồi theo due date (item Done không tính là quá hạn), cùng hạn thì priority cao trước, không có hạn xếp cuối
+	tests := []struct {
+		page       int
+		wantTitles string
+	}{
+		{1, "[overdue low overdue high done in the past]"},
+		{2, "[tomorrow high tomorrow low next week]"},
+		{3, "[no due high no due low]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("page %d", tt.page), func(t *testing.T) {
+			paging := common.Paging{Page: tt.page, Limit: 3}
+			_ = paging.Process()
+
+			result, err := store.ListItem(ctx, &model.Filter{UserId: 1, Sort: model.SortSmart}, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			titles := make([]string, len(result))
+
+			for i := range result {
+				titles[i] = result[i].Title
+			}
+
+			if fmt.Sprint(titles) != tt.wantTitles || paging.Total != int64(len(items)) {
+				t.Errorf("titles = %v, total = %d, want %s, %d", titles, paging.Total, tt.wantTitles, len(items))
+			}
+		})
+	}
+}
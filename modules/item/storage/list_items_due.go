@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// ListItemsDue lấy item chưa Done, chưa xoá, chưa archive của user có due_date trong [from, to),
+// from nil là không giới hạn dưới (lấy cả item quá hạn). Item không có due_date không bao giờ được lấy
+func (s *sqlStore) ListItemsDue(
+	ctx context.Context,
+	userId int,
+	from *time.Time,
+	to time.Time,
+	paging *common.Paging,
+) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+
+	db := s.db.WithContext(ctx).
+		Where("user_id = ? AND (status IS NULL OR status NOT IN ?) AND archived = ?", userId, []model.ItemStatus{model.ItemStatusDone, model.ItemStatusDeleted}, false).
+		Where("due_date IS NOT NULL AND due_date < ?", to)
+
+	if from != nil {
+		db = db.Where("due_date >= ?", *from)
+	}
+
+	if err := db.Model(&model.TodoItem{}).Count(&paging.Total).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	if err := db.Order("due_date asc, priority desc, id asc").
+		Offset((paging.Page - 1) * paging.Limit).
+		Limit(paging.Limit).
+		Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
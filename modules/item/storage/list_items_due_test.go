@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestListItemsDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	yesterday, tomorrow, nextWeek := now.Add(-24*time.Hour), now.Add(24*time.Hour), now.Add(7*24*time.Hour)
+
+	seed := []model.TodoItem{
+		{Title: "overdue", UserId: 1, Status: &doing, DueDate: &yesterday},
+		{Title: "tomorrow without status", UserId: 1, DueDate: &tomorrow},
+		{Title: "next week", UserId: 1, Status: &doing, DueDate: &nextWeek},
+		{Title: "done tomorrow", UserId: 1, Status: &done, DueDate: &tomorrow},
+		{Title: "no due date", UserId: 1, Status: &doing},
+		{Title: "other user", UserId: 2, Status: &doing, DueDate: &tomorrow},
+	}
+
+	from := now
+
+	tests := []struct {
+		name      string
+		from      *time.Time
+		to        time.Time
+		paging    common.Paging
+		want      []string
+		wantTotal int64
+	}{
+		{"everything due", nil, nextWeek.Add(time.Hour), common.Paging{Page: 1, Limit: 10}, []string{"overdue", "tomorrow without status", "next week"}, 3},
+		{"from now", &from, nextWeek.Add(time.Hour), common.Paging{Page: 1, Limit: 10}, []string{"tomorrow without status", "next week"}, 2},
+		{"first page", nil, nextWeek.Add(time.Hour), common.Paging{Page: 1, Limit: 2}, []string{"overdue", "tomorrow without status"}, 3},
+		{"second page", nil, nextWeek.Add(time.Hour), common.Paging{Page: 2, Limit: 2}, []string{"next week"}, 3},
+	}
+
+	store := newTestStore(t)
+
+	for i := range seed {
+		if err := store.db.Create(&seed[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paging := tt.paging
+
+			items, err := store.ListItemsDue(ctx, 1, tt.from, tt.to, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if paging.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", paging.Total, tt.wantTotal)
+			}
+
+			if len(items) != len(tt.want) {
+				t.Fatalf("got %d items, want %v", len(items), tt.want)
+			}
+
+			for i := range items {
+				if items[i].Title != tt.want[i] {
+					t.Errorf("items[%d] = %q, want %q", i, items[i].Title, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestListItemIncludesNullStatus(t *testing.T) {
+	ctx := context.Background()
+	yesterday := time.Now().UTC().Add(-24 * time.Hour)
+	deleted := model.ItemStatusDeleted
+
+	tests := []struct {
+		name   string
+		filter model.Filter
+		want   int
+	}{
+		{"all", model.Filter{UserId: 1}, 1},
+		{"overdue", model.Filter{UserId: 1, Overdue: true}, 1},
+	}
+
+	store := newTestStore(t)
+
+	seed := []model.TodoItem{
+		{Title: "legacy", UserId: 1, DueDate: &yesterday},
+		{Title: "deleted", UserId: 1, Status: &deleted, DueDate: &yesterday},
+	}
+
+	for i := range seed {
+		if err := store.db.Create(&seed[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			paging := common.Paging{Page: 1, Limit: 10}
+
+			items, err := store.ListItem(ctx, &filter, &paging)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != tt.want || items[0].Title != "legacy" {
+				t.Fatalf("items = %+v, want only the item without status", items)
+			}
+		})
+	}
+}
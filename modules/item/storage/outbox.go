@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// writeOutboxEvents phải được gọi với tx của thay đổi, rollback thì event cũng không còn
+func writeOutboxEvents(tx *gorm.DB, events []model.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	for i := range events {
+		events[i].CreatedAt = &now
+	}
+
+	return tx.Create(&events).Error
+}
+
+// ClaimOutboxEvents claim tối đa limit event chưa gửi đã tới lúc gửi (lại), cũ nhất trước.
+// Event được khoá tới now + lease nên mỗi event chỉ được một instance relay gửi tại một thời điểm:
+// SELECT ... FOR UPDATE SKIP LOCKED bỏ qua event instance khác đang claim, UPDATE có điều kiện
+// locked_until và đọc lại theo claim token đảm bảo chỉ trả về event claim được (kể cả DB không có SKIP LOCKED)
+func (s *sqlStore) ClaimOutboxEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.OutboxEvent, error) {
+	token, err := newClaimToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	claimable := func(db *gorm.DB) *gorm.DB {
+		return db.Where("published_at IS NULL AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+			Where("(locked_until IS NULL OR locked_until <= ?)", now)
+	}
+
+	var result []model.OutboxEvent
+
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		var ids []int
+
+		if err := claimable(txStore.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})).
+			Model(&model.OutboxEvent{}).
+			Order("id asc").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := claimable(txStore.db.Model(&model.OutboxEvent{})).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"claim_token":  token,
+				"locked_until": now.Add(lease),
+			}).Error; err != nil {
+			return err
+		}
+
+		return txStore.db.Where("id IN ? AND claim_token = ?", ids, token).Order("id asc").Find(&result).Error
+	}); err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
+
+func newClaimToken() (string, error) {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func (s *sqlStore) MarkOutboxEventPublished(ctx context.Context, id int, at time.Time) error {
+	if err := s.db.WithContext(ctx).Table(model.OutboxEvent{}.TableName()).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"published_at": at,
+			"locked_until": nil,
+		}).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
+
+// MarkOutboxEventFailed tăng số lần lỗi, hẹn lúc gửi lại và bỏ khoá để instance nào cũng claim lại được
+func (s *sqlStore) MarkOutboxEventFailed(ctx context.Context, id int, reason string, nextAttemptAt time.Time) error {
+	if err := s.db.WithContext(ctx).Table(model.OutboxEvent{}.TableName()).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      reason,
+			"next_attempt_at": nextAttemptAt,
+			"locked_until":    nil,
+		}).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
+
+// DeletePublishedOutboxEvents xoá các event đã gửi trước before, trả về số event đã xoá
+func (s *sqlStore) DeletePublishedOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	db := s.db.WithContext(ctx).
+		Where("published_at IS NOT NULL AND published_at < ?", before).
+		Delete(&model.OutboxEvent{})
+
+	if err := db.Error; err != nil {
+		return 0, common.ErrDB(err)
+	}
+
+	return db.RowsAffected, nil
+}
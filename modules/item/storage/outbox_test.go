@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestClaimOutboxEvents(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	later := now.Add(time.Hour)
+
+	events := []model.OutboxEvent{
+		{ItemId: 1, UserId: 1, Type: model.EventItemCreated},
+		{ItemId: 2, UserId: 1, Type: model.EventItemCreated},
+		{ItemId: 3, UserId: 1, Type: model.EventItemCreated, PublishedAt: &now},
+		{ItemId: 4, UserId: 1, Type: model.EventItemCreated, NextAttemptAt: &later},
+	}
+
+	if err := writeOutboxEvents(store.db, events); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		want  []int
+		limit int
+	}{
+		{"claims pending events up to limit", now, []int{1}, 1},
+		{"skips events claimed by another relay", now, []int{2}, 10},
+		{"nothing left while claims are held", now, nil, 10},
+		// Lease hết hạn (relay claim trước bị tắt) thì event được claim lại, kể cả event hẹn gửi lại đã tới giờ
+		{"reclaims after the lease expires", now.Add(2 * time.Minute), []int{1, 2}, 10},
+		{"retry after next_attempt_at", later.Add(2 * time.Minute), []int{1, 2, 4}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed, err := store.ClaimOutboxEvents(ctx, tt.now, time.Minute, tt.limit)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []int
+
+			for _, event := range claimed {
+				got = append(got, event.ItemId)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("claimed items %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("claimed items %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkOutboxEventReleasesClaim(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	events := []model.OutboxEvent{
+		{ItemId: 1, UserId: 1, Type: model.EventItemCreated},
+		{ItemId: 2, UserId: 1, Type: model.EventItemCreated},
+	}
+
+	if err := writeOutboxEvents(store.db, events); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.ClaimOutboxEvents(ctx, now, time.Hour, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.MarkOutboxEventPublished(ctx, events[0].Id, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.MarkOutboxEventFailed(ctx, events[1].Id, "timeout", now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Event lỗi được claim lại ngay khi tới giờ gửi lại dù lease chưa hết, event đã gửi thì không
+	claimed, err := store.ClaimOutboxEvents(ctx, now, time.Hour, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(claimed) != 1 || claimed[0].Id != events[1].Id || claimed[0].Attempts != 1 {
+		t.Fatalf("claimed %+v, want only the failed event with 1 attempt", claimed)
+	}
+}
+
+func TestDeletePublishedOutboxEvents(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+
+	events := []model.OutboxEvent{
+		{ItemId: 1, UserId: 1, Type: model.EventItemCreated, PublishedAt: &old},
+		{ItemId: 2, UserId: 1, Type: model.EventItemCreated, PublishedAt: &recent},
+		{ItemId: 3, UserId: 1, Type: model.EventItemCreated},
+	}
+
+	if err := writeOutboxEvents(store.db, events); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := store.DeletePublishedOutboxEvents(ctx, now.Add(-24*time.Hour))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var left []int
+
+	if err := store.db.Model(&model.OutboxEvent{}).Order("item_id").Pluck("item_id", &left).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted != 1 || len(left) != 2 || left[0] != 2 || left[1] != 3 {
+		t.Fatalf("deleted %d, left items %v, want 1 deleted and items [2 3] left", deleted, left)
+	}
+}
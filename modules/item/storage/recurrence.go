@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// errOccurrenceExists rollback item vừa insert khi item gốc đã có lần lặp kế tiếp
+var errOccurrenceExists = errors.New("next occurrence already exists")
+
+// ListItemsToRecur trả về item lặp lại đã Done nhưng chưa sinh lần lặp kế tiếp
+func (s *sqlStore) ListItemsToRecur(ctx context.Context, limit int) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+
+	doneStatus := model.ItemStatusDone
+
+	if err := s.db.WithContext(ctx).
+		Where("recurrence_rule <> ? AND next_occurrence_id IS NULL AND status = ?", model.RecurrenceNone, doneStatus).
+		Order("id asc").
+		Limit(limit).
+		Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
+
+// CreateNextOccurrence insert next và gắn nó vào item itemId trong cùng transaction.
+// Trả về false (không insert gì) nếu item đã có lần lặp kế tiếp, ví dụ job chạy hai lần
+// hoặc instance khác vừa sinh xong, hay item không còn Done
+func (s *sqlStore) CreateNextOccurrence(ctx context.Context, itemId int, next *model.TodoItemCreation) (bool, error) {
+	doneStatus := model.ItemStatusDone
+
+	err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		if err := createItems(txStore.db, next); err != nil {
+			return err
+		}
+
+		db := txStore.db.Table(model.TodoItem{}.TableName()).
+			Where("id = ? AND next_occurrence_id IS NULL AND status = ?", itemId, doneStatus).
+			Update("next_occurrence_id", next.Id)
+
+		if err := db.Error; err != nil {
+			return err
+		}
+
+		if db.RowsAffected == 0 {
+			return errOccurrenceExists
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errOccurrenceExists) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, common.ErrDB(err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestCreateNextOccurrence(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	dueDate := time.Date(2024, 5, 10, 17, 0, 0, 0, time.UTC)
+
+	done, doing := model.ItemStatusDone, model.ItemStatusDoing
+
+	for _, item := range []model.TodoItem{
+		{Title: "daily done", UserId: 1, Status: &done, DueDate: &dueDate, RecurrenceRule: model.RecurrenceDaily},
+		{Title: "daily doing", UserId: 1, Status: &doing, DueDate: &dueDate, RecurrenceRule: model.RecurrenceDaily},
+		{Title: "one-off done", UserId: 1, Status: &done, DueDate: &dueDate},
+	} {
+		if err := store.db.Create(&item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := store.ListItemsToRecur(ctx, 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 1 || items[0].Title != "daily done" {
+		t.Fatalf("items to recur = %+v, want only the done daily item", items)
+	}
+
+	tests := []struct {
+		name        string
+		wantCreated bool
+	}{
+		{"first run", true},
+		{"back-to-back run", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, err := store.CreateNextOccurrence(ctx, items[0].Id, model.NextOccurrence(&items[0]))
+
+			if err != nil || created != tt.wantCreated {
+				t.Fatalf("created = %v, err = %v, want %v", created, err, tt.wantCreated)
+			}
+
+			var next []model.TodoItem
+
+			if err := store.db.Where("title = ? AND status = ?", "daily done", doing).Find(&next).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(next) != 1 || !next[0].DueDate.Equal(dueDate.AddDate(0, 0, 1)) {
+				t.Errorf("next occurrences = %+v, want one due %v", next, dueDate.AddDate(0, 0, 1))
+			}
+		})
+	}
+
+	if items, err := store.ListItemsToRecur(ctx, 100); err != nil || len(items) != 0 {
+		t.Errorf("items to recur after generation = %d (%v), want 0", len(items), err)
+	}
+}
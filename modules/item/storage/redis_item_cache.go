@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"social-todo-list/modules/item/model"
+	"strconv"
+	"time"
+)
+
+const redisItemKeyPrefix = "social-todo:item:"
+
+// redisItem thêm lại các field bị ẩn khi trả JSON cho client (id thật, user id thật, next_occurrence_id)
+type redisItem struct {
+	Id     int `json:"id"`
+	UserId int `json:"user_id"`
+	model.TodoItem
+	UpdatedBy        *int `json:"updated_by,omitempty"`
+	NextOccurrenceId *int `json:"next_occurrence_id,omitempty"`
+}
+
+type redisItemCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisItemCache lưu item dạng JSON trong Redis để các instance dùng chung cache.
+// Mỗi user có thêm một set chứa id các item đang được cache để DeleteUser không phải SCAN
+func NewRedisItemCache(client *redis.Client, ttl time.Duration) ItemCache {
+	if client == nil || ttl <= 0 {
+		return nil
+	}
+
+	return &redisItemCache{client: client, ttl: ttl}
+}
+
+func redisItemKey(id int) string {
+	return redisItemKeyPrefix + strconv.Itoa(id)
+}
+
+func redisUserKey(userId int) string {
+	return fmt.Sprintf("%suser:%d", redisItemKeyPrefix, userId)
+}
+
+func (c *redisItemCache) Get(ctx context.Context, id int) (*model.TodoItem, bool) {
+	data, err := c.client.Get(ctx, redisItemKey(id)).Bytes()
+
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logError(ctx, "get", err)
+		}
+
+		return nil, false
+	}
+
+	var cached redisItem
+
+	if err := json.Unmarshal(data, &cached); err != nil {
+		c.logError(ctx, "decode", err)
+		return nil, false
+	}
+
+	item := cached.TodoItem
+	item.Id = cached.Id
+	item.UserId = cached.UserId
+	item.UpdatedBy = cached.UpdatedBy
+	item.NextOccurrenceId = cached.NextOccurrenceId
+
+	return &item, true
+}
+
+func (c *redisItemCache) Set(ctx context.Context, item *model.TodoItem) {
+	data, err := json.Marshal(redisItem{
+		Id:               item.Id,
+		UserId:           item.UserId,
+		TodoItem:         *item,
+		UpdatedBy:        item.UpdatedBy,
+		NextOccurrenceId: item.NextOccurrenceId,
+	})
+
+	if err != nil {
+		c.logError(ctx, "encode", err)
+		return
+	}
+
+	userKey := redisUserKey(item.UserId)
+
+	if _, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, redisItemKey(item.Id), data, c.ttl)
+		pipe.SAdd(ctx, userKey, item.Id)
+		pipe.Expire(ctx, userKey, c.ttl)
+		return nil
+	}); err != nil {
+		c.logError(ctx, "set", err)
+	}
+}
+
+func (c *redisItemCache) Delete(ctx context.Context, ids ...int) {
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, len(ids))
+
+	for i, id := range ids {
+		keys[i] = redisItemKey(id)
+	}
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		c.logError(ctx, "delete", err)
+	}
+}
+
+func (c *redisItemCache) DeleteUser(ctx context.Context, userId int) {
+	userKey := redisUserKey(userId)
+
+	members, err := c.client.SMembers(ctx, userKey).Result()
+
+	if err != nil {
+		c.logError(ctx, "delete user", err)
+		return
+	}
+
+	keys := []string{userKey}
+
+	for _, id := range members {
+		keys = append(keys, redisItemKeyPrefix+id)
+	}
+
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		c.logError(ctx, "delete user", err)
+	}
+}
+
+// Clear xoá theo prefix bằng SCAN, chỉ dùng cho job chạy nền (purge) vì phải duyệt hết key
+func (c *redisItemCache) Clear(ctx context.Context) {
+	iter := c.client.Scan(ctx, 0, redisItemKeyPrefix+"*", 100).Iterator()
+
+	for iter.Next(ctx) {
+		if err := c.client.Del(ctx, iter.Val()).Err(); err != nil {
+			c.logError(ctx, "clear", err)
+			return
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		c.logError(ctx, "clear", err)
+	}
+}
+
+func (c *redisItemCache) logError(ctx context.Context, op string, err error) {
+	slog.WarnContext(ctx, "item cache error, falling back to DB", slog.String("op", op), slog.Any("error", err))
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+// newTestRedisCache tạo ItemCache trên miniredis, trả về cả server để test tắt Redis hoặc đọc key
+func newTestRedisCache(t *testing.T) (ItemCache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRedisItemCache(client, time.Minute), server
+}
+
+func TestCachedStorageRedis(t *testing.T) {
+	testItemCache(t, func(t *testing.T) ItemCache {
+		cache, _ := newTestRedisCache(t)
+		return cache
+	})
+}
+
+func TestRedisItemCache(t *testing.T) {
+	ctx := context.Background()
+	updatedBy, nextId := 9, 12
+
+	item := model.TodoItem{Title: "buy milk", UserId: 3, UpdatedBy: &updatedBy, NextOccurrenceId: &nextId}
+	item.Id = 5
+
+	tests := []struct {
+		name       string
+		invalidate func(cache ItemCache)
+		wantFound  bool
+	}{
+		{"round trip keeps hidden ids", func(ItemCache) {}, true},
+		{"delete", func(cache ItemCache) { cache.Delete(ctx, item.Id) }, false},
+		{"delete user", func(cache ItemCache) { cache.DeleteUser(ctx, item.UserId) }, false},
+		{"clear", func(cache ItemCache) { cache.Clear(ctx) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, server := newTestRedisCache(t)
+
+			cache.Set(ctx, &item)
+
+			if ttl := server.TTL(redisItemKey(item.Id)); ttl != time.Minute {
+				t.Errorf("ttl = %v, want 1m", ttl)
+			}
+
+			tt.invalidate(cache)
+
+			got, found := cache.Get(ctx, item.Id)
+
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+
+			if found && (got.Id != item.Id || got.UserId != item.UserId || *got.UpdatedBy != updatedBy || *got.NextOccurrenceId != nextId || got.Title != item.Title) {
+				t.Errorf("item = %+v, want %+v", got, item)
+			}
+		})
+	}
+}
+
+func TestRedisItemCacheUnavailable(t *testing.T) {
+	cache, server := newTestRedisCache(t)
+	server.Close()
+
+	sqlStore := newTestStore(t)
+	item := createTestItems(t, sqlStore, 1, 1, "item")[0]
+	store := NewCachedStorage(sqlStore, cache)
+	cond := map[string]interface{}{"id": item.Id}
+	title := "buy bread"
+
+	if err := store.UpdateItem(context.Background(), cond, &model.TodoItemUpdate{Title: &title}); err != nil {
+		t.Fatalf("update with redis down: %v", err)
+	}
+
+	data, err := store.GetItem(context.Background(), cond)
+
+	if err != nil || data.Title != title {
+		t.Errorf("get with redis down = %v, %v, want the item from the DB", data, err)
+	}
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// ListItemsToRemind trả về item chưa nhắc có due_date trong (from, to], item đã Done hoặc đã xoá không được lấy
+func (s *sqlStore) ListItemsToRemind(ctx context.Context, from, to time.Time, limit int) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+
+	doneStatus, deletedStatus := model.ItemStatusDone, model.ItemStatusDeleted
+
+	if err := s.db.WithContext(ctx).
+		Where("reminded_at IS NULL AND due_date > ? AND due_date <= ?", from, to).
+		Where("(status IS NULL OR status NOT IN ?)", []model.ItemStatus{doneStatus, deletedStatus}).
+		Order("due_date asc").
+		Limit(limit).
+		Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
+
+// ClaimReminder đánh dấu item đã được nhắc, trả về false nếu item đã được đánh dấu trước đó
+// (ví dụ bởi instance khác) để mỗi item chỉ được nhắc một lần
+func (s *sqlStore) ClaimReminder(ctx context.Context, id int, at time.Time) (bool, error) {
+	db := s.db.WithContext(ctx).Table(model.TodoItem{}.TableName()).
+		Where("id = ? AND reminded_at IS NULL", id).
+		Update("reminded_at", at)
+
+	if err := db.Error; err != nil {
+		return false, common.ErrDB(err)
+	}
+
+	return db.RowsAffected == 1, nil
+}
+
+// ReleaseReminder bỏ đánh dấu khi gửi thông báo lỗi để lần quét sau gửi lại
+func (s *sqlStore) ReleaseReminder(ctx context.Context, id int) error {
+	if err := s.db.WithContext(ctx).Table(model.TodoItem{}.TableName()).
+		Where("id = ?", id).
+		Update("reminded_at", nil).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestListItemsToRemind(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	now := time.Now().UTC()
+
+	doing, done, deleted := model.ItemStatusDoing, model.ItemStatusDone, model.ItemStatusDeleted
+	soon, later := now.Add(time.Hour), now.Add(48*time.Hour)
+
+	tests := []struct {
+		title      string
+		status     *model.ItemStatus
+		dueDate    *time.Time
+		reminded   bool
+		wantListed bool
+	}{
+		{"due soon", &doing, &soon, false, true},
+		{"legacy without status", nil, &soon, false, true},
+		{"done", &done, &soon, false, false},
+		{"deleted", &deleted, &soon, false, false},
+		{"outside window", &doing, &later, false, false},
+		{"without due date", &doing, nil, false, false},
+		{"already reminded", &doing, &soon, true, false},
+	}
+
+	for _, tt := range tests {
+		item := model.TodoItem{Title: tt.title, UserId: 1, Status: tt.status, DueDate: tt.dueDate}
+
+		if tt.reminded {
+			item.RemindedAt = &now
+		}
+
+		if err := store.db.Create(&item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := store.ListItemsToRemind(ctx, now, now.Add(24*time.Hour), 100)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listed := map[string]bool{}
+
+	for _, item := range items {
+		listed[item.Title] = true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if listed[tt.title] != tt.wantListed {
+				t.Errorf("listed = %v, want %v", listed[tt.title], tt.wantListed)
+			}
+		})
+	}
+
+	t.Run("claimed once", func(t *testing.T) {
+		first, err := store.ClaimReminder(ctx, items[0].Id, now)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		second, err := store.ClaimReminder(ctx, items[0].Id, now)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !first || second {
+			t.Errorf("claims = %v, %v, want true then false", first, second)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestReplaceItemResetsOmittedFields(t *testing.T) {
+	ctx := context.Background()
+	doing := model.ItemStatusDoing
+	title, description := "replaced", ""
+	dueDate := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		replace     model.TodoItemReplace
+		wantTags    model.ItemTags
+		wantDueDate *time.Time
+	}{
+		{"omitted tags and due date are cleared", model.TodoItemReplace{}, nil, nil},
+		{"sent tags and due date are written", model.TodoItemReplace{Tags: model.ItemTags{"Work", "work"}, DueDate: &dueDate}, model.ItemTags{"work"}, &dueDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			oldDueDate := time.Now().UTC().Add(48 * time.Hour)
+			item := model.TodoItem{Title: "old", UserId: 1, Status: &doing, Tags: model.ItemTags{"home"}, DueDate: &oldDueDate}
+
+			if err := store.db.Create(&item).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			replace := tt.replace
+			replace.Title, replace.Description, replace.Status = &title, &description, &doing
+
+			if err := replace.Validate(model.LengthLimits{}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Version do biz gán trước khi ghi
+			update := replace.ToUpdate()
+			version := 2
+			update.Version = &version
+
+			if err := store.ReplaceItem(ctx, map[string]interface{}{"id": item.Id}, update); err != nil {
+				t.Fatal(err)
+			}
+
+			var got model.TodoItem
+
+			if err := store.db.Where("id = ?", item.Id).First(&got).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got.Tags, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", got.Tags, tt.wantTags)
+			}
+
+			if (got.DueDate == nil) != (tt.wantDueDate == nil) || got.DueDate != nil && !got.DueDate.Equal(*tt.wantDueDate) {
+				t.Errorf("due_date = %v, want %v", got.DueDate, tt.wantDueDate)
+			}
+
+			var logs []model.ItemAuditLog
+
+			if err := store.db.Where("item_id = ?", item.Id).Find(&logs).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(logs) != 1 || !hasChanges(logs[0].Changes, "tags", "due_date") {
+				t.Errorf("audit log = %+v, want tags and due_date changes", logs)
+			}
+		})
+	}
+}
+
+func hasChanges(changes model.AuditChanges, fields ...string) bool {
+	for _, field := range fields {
+		if _, ok := changes[field]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
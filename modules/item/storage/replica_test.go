@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+// TestSQLStorageReplica dùng hai file sqlite thay cho primary và replica, mỗi file có một item mang tên của nó
+func TestSQLStorageReplica(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dsns := map[string]string{"primary": filepath.Join(dir, "primary.db"), "replica": filepath.Join(dir, "replica.db")}
+
+	for name, dsn := range dsns {
+		db, err := common.NewDatabase(common.Config{DBDriver: common.DBDriverSQLite, DBDsn: dsn})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		migrateTestDB(t, db)
+
+		if err := db.Create(&model.TodoItem{Title: name, UserId: 1}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	}
+
+	db, err := common.NewDatabase(common.Config{DBDriver: common.DBDriverSQLite, DBDsn: dsns["primary"], DBReplicaDsn: dsns["replica"]})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+
+	store, primary := NewSQLStorage(db), NewSQLStorage(common.Primary(db))
+
+	tests := []struct {
+		name string
+		read func() (string, error)
+		want string
+	}{
+		{"list reads the replica", func() (string, error) {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			items, err := store.ListItem(ctx, &model.Filter{UserId: 1}, &paging)
+
+			if err != nil || len(items) != 1 {
+				return "", err
+			}
+
+			return items[0].Title, nil
+		}, "replica"},
+		{"get reads the replica", func() (string, error) {
+			item, err := store.GetItem(ctx, map[string]interface{}{"id": 1})
+
+			if err != nil {
+				return "", err
+			}
+
+			return item.Title, nil
+		}, "replica"},
+		{"get on Primary reads the primary", func() (string, error) {
+			item, err := primary.GetItem(ctx, map[string]interface{}{"id": 1})
+
+			if err != nil {
+				return "", err
+			}
+
+			return item.Title, nil
+		}, "primary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.read()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("read %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := store.CreateItem(ctx, &model.TodoItemCreation{Title: "written", UserId: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	for source, want := range map[string]int64{"primary": 1, "replica": 0} {
+		var count int64
+
+		reader := db
+
+		if source == "primary" {
+			reader = common.Primary(db)
+		}
+
+		if err := reader.Model(&model.TodoItem{}).Where("title = ?", "written").Count(&count).Error; err != nil || count != want {
+			t.Errorf("%s has %d written items (%v), want %d", source, count, err, want)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package storage
 
 import "gorm.io/gorm"
 
+// Số lần chạy lại các thao tác ghi khi gặp lỗi DB tạm thời (deadlock, mất kết nối)
+const writeRetryAttempts = 3
+
 type sqlStore struct {
 	db *gorm.DB
 }
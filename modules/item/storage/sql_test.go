@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	commentmodel "social-todo-list/modules/comment/model"
+	"social-todo-list/modules/item/model"
+	subtaskmodel "social-todo-list/modules/subtask/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	"testing"
+)
+
+// newTestStore tạo store trên sqlite in-memory, một connection để mọi query thấy cùng một DB
+func newTestStore(t *testing.T) *sqlStore {
+	t.Helper()
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	migrateTestDB(t, db)
+
+	return NewSQLStorage(db)
+}
+
+// migrateTestDB tạo các bảng mà storage của item dùng
+func migrateTestDB(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	if err := db.AutoMigrate(
+		&model.TodoItem{},
+		&model.IdempotencyKey{},
+		&model.ItemAuditLog{},
+		&model.OutboxEvent{},
+		&model.ItemUserLock{},
+		&commentmodel.Comment{},
+		&likemodel.Like{},
+		&subtaskmodel.Subtask{},
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithTransactionDB(t *testing.T) {
+	fnErr := errors.New("rollback")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantItems int64
+	}{
+		{"commit", nil, 1},
+		{"rollback on error", fnErr, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			err := store.WithTransactionDB(context.Background(), func(tx *gorm.DB) error {
+				if err := tx.Create(&model.TodoItem{Title: "a", UserId: 1}).Error; err != nil {
+					return err
+				}
+
+				return tt.err
+			})
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			var count int64
+
+			if err := store.db.Model(&model.TodoItem{}).Count(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if count != tt.wantItems {
+				t.Errorf("items = %d, want %d", count, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestStorageAbortsOnCancelledContext(t *testing.T) {
+	store := newTestStore(t)
+	items := createTestItems(t, store, 1, 2, "item")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"get", func() error {
+			_, err := store.GetItem(ctx, map[string]interface{}{"id": items[0].Id})
+			return err
+		}},
+		{"list", func() error {
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			_, err := store.ListItem(ctx, &model.Filter{UserId: 1}, &paging)
+			return err
+		}},
+		{"create", func() error {
+			return store.CreateItem(ctx, &model.TodoItemCreation{Title: "a", UserId: 1})
+		}},
+		{"update", func() error {
+			title := "b"
+			return store.UpdateItem(ctx, map[string]interface{}{"id": items[1].Id}, &model.TodoItemUpdate{Title: &title})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("err = %v, want context.Canceled", err)
+			}
+
+			if status := common.ToAppError(err).StatusCode; status != http.StatusServiceUnavailable {
+				t.Errorf("status = %d, want 503", status)
+			}
+		})
+	}
+
+	var count int64
+
+	if err := store.db.Model(&model.TodoItem{}).Count(&count).Error; err != nil || count != 2 {
+		t.Errorf("items = %d (%v), want the cancelled create to insert nothing", count, err)
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// CountItemsByStatus đếm item theo status trong một câu GROUP BY, key là tên status (item chưa có status là "")
+func (s *sqlStore) CountItemsByStatus(ctx context.Context, cond map[string]interface{}) (map[string]int64, error) {
+	type sqlData struct {
+		Status *model.ItemStatus `gorm:"column:status;"`
+		Count  int64             `gorm:"column:count;"`
+	}
+
+	var rows []sqlData
+
+	if err := s.db.WithContext(ctx).Table(model.TodoItem{}.TableName()).
+		Select("status, COUNT(*) AS count").
+		Where(cond).
+		Group("status").
+		Find(&rows).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	result := make(map[string]int64, len(rows))
+
+	for _, row := range rows {
+		result[row.Status.String()] += row.Count
+	}
+
+	return result, nil
+}
+
+// CountItemsCompletedSince đếm item đang Done có completed_at từ since trở đi
+func (s *sqlStore) CountItemsCompletedSince(ctx context.Context, cond map[string]interface{}, since time.Time) (int64, error) {
+	doneStatus := model.ItemStatusDone
+
+	var count int64
+
+	if err := s.db.WithContext(ctx).Table(model.TodoItem{}.TableName()).
+		Where(cond).
+		Where("status = ? AND completed_at >= ?", doneStatus, since).
+		Count(&count).Error; err != nil {
+		return 0, common.ErrDB(err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestItemStatsQueries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name          string
+		seed          func(store *sqlStore)
+		wantCounts    map[string]int64
+		wantCompleted int64
+	}{
+		{"no items", func(store *sqlStore) {}, map[string]int64{}, 0},
+		{
+			"mixed statuses and dates",
+			func(store *sqlStore) {
+				items := createTestItems(t, store, 1, 6, "item")
+				createTestItems(t, store, 2, 2, "other")
+
+				// item 1 Done hôm qua, item 2 Done 10 ngày trước, item 3 Done nhưng không có completed_at, item 4 đã xoá
+				updates := []struct {
+					id      int
+					columns map[string]interface{}
+				}{
+					{items[0].Id, map[string]interface{}{"status": model.ItemStatusDone, "completed_at": now.AddDate(0, 0, -1)}},
+					{items[1].Id, map[string]interface{}{"status": model.ItemStatusDone, "completed_at": now.AddDate(0, 0, -10)}},
+					{items[2].Id, map[string]interface{}{"status": model.ItemStatusDone}},
+					{items[3].Id, map[string]interface{}{"status": model.ItemStatusDeleted}},
+				}
+
+				for _, u := range updates {
+					if err := store.db.Model(&model.TodoItem{}).Where("id = ?", u.id).Updates(u.columns).Error; err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			map[string]int64{"Doing": 2, "Done": 3, "Deleted": 1},
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			tt.seed(store)
+
+			cond := map[string]interface{}{"user_id": 1}
+
+			counts, err := store.CountItemsByStatus(ctx, cond)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(counts) != len(tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+
+			for status, want := range tt.wantCounts {
+				if counts[status] != want {
+					t.Errorf("counts[%s] = %d, want %d", status, counts[status], want)
+				}
+			}
+
+			completed, err := store.CountItemsCompletedSince(ctx, cond, now.AddDate(0, 0, -7))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if completed != tt.wantCompleted {
+				t.Errorf("completed since 7 days ago = %d, want %d", completed, tt.wantCompleted)
+			}
+		})
+	}
+}
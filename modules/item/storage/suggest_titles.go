@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+)
+
+// SuggestTitles lấy tối đa limit item chưa xoá, chưa archive có title bắt đầu bằng prefix, mới sửa trước.
+// Chỉ so khớp prefix (LIKE 'q%') để dùng được index của cột title
+func (s *sqlStore) SuggestTitles(ctx context.Context, userId int, prefix string, limit int) ([]model.TodoItemSuggestion, error) {
+	var result []model.TodoItemSuggestion
+
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND (status IS NULL OR status <> ?) AND archived = ?", userId, model.ItemStatusDeleted, false).
+		Where("title LIKE ? ESCAPE '!'", escapeLike(prefix)+"%").
+		Order("updated_at desc, id desc").
+		Limit(limit).
+		Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
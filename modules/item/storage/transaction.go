@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// WithTransaction chạy fn trong một transaction, txStore dùng chung transaction đó.
+// fn trả lỗi hoặc panic thì rollback toàn bộ, ngược lại commit.
+// Lỗi của fn được trả nguyên vẹn để biz tự quyết định cách wrap
+func (s *sqlStore) WithTransaction(ctx context.Context, fn func(txStore *sqlStore) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&sqlStore{db: tx})
+	})
+}
+
+// WithTransactionDB giống WithTransaction nhưng đưa *gorm.DB của transaction cho fn,
+// dùng khi code ngoài package (ví dụ lệnh seed) cần ghi thêm bảng khác cùng transaction với item
+func (s *sqlStore) WithTransactionDB(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		return fn(txStore.db)
+	})
+}
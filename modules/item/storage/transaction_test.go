@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestWithTransaction(t *testing.T) {
+	fnErr := errors.New("rollback")
+
+	tests := []struct {
+		name      string
+		fn        func(txStore *sqlStore) error
+		wantErr   error
+		wantPanic bool
+		wantItems int64
+	}{
+		{"commit", func(txStore *sqlStore) error {
+			return createTestTxItems(txStore, 2)
+		}, nil, false, 2},
+		{"rollback on error after inserts", func(txStore *sqlStore) error {
+			if err := createTestTxItems(txStore, 2); err != nil {
+				return err
+			}
+
+			return fnErr
+		}, fnErr, false, 0},
+		{"rollback on panic", func(txStore *sqlStore) error {
+			if err := createTestTxItems(txStore, 2); err != nil {
+				return err
+			}
+
+			panic("boom")
+		}, nil, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+
+			func() {
+				defer func() {
+					if r := recover(); (r != nil) != tt.wantPanic {
+						t.Errorf("panic = %v, want %v", r, tt.wantPanic)
+					}
+				}()
+
+				if err := store.WithTransaction(context.Background(), tt.fn); !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			}()
+
+			var count int64
+
+			if err := store.db.Model(&model.TodoItem{}).Count(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if count != tt.wantItems {
+				t.Errorf("items = %d, want %d", count, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestCreateItemsRollsBack(t *testing.T) {
+	store := newTestStore(t)
+
+	// Không có bảng audit log thì insert item thành công nhưng bước ghi audit log sau đó lỗi
+	if err := store.db.Migrator().DropTable(&model.ItemAuditLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []*model.TodoItemCreation{{Title: "a", UserId: 1}, {Title: "b", UserId: 1}, {Title: "c", UserId: 1}}
+
+	if err := store.CreateItems(context.Background(), data); err == nil {
+		t.Fatal("want error")
+	}
+
+	var count int64
+
+	if err := store.db.Model(&model.TodoItem{}).Count(&count).Error; err != nil || count != 0 {
+		t.Errorf("items = %d (%v), want the whole batch rolled back", count, err)
+	}
+}
+
+// createTestTxItems insert từng item một qua txStore
+func createTestTxItems(txStore *sqlStore, n int) error {
+	for i := 0; i < n; i++ {
+		if err := txStore.db.Create(&model.TodoItem{Title: "item", UserId: 1}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
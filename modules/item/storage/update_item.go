@@ -2,13 +2,77 @@ package storage
 
 import (
 	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
 	"social-todo-list/modules/item/model"
 )
 
 func (s *sqlStore) UpdateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	return s.updateItem(ctx, cond, dataUpdate, nil)
+}
+
+// ReplaceItem ghi đè cả các cột trong model.ReplaceColumns kể cả khi giá trị rỗng hoặc nil
+func (s *sqlStore) ReplaceItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate) error {
+	columns := append([]string{"version", "updated_at"}, model.ReplaceColumns...)
+
+	if dataUpdate.CompletedAt != nil {
+		columns = append(columns, "completed_at")
+	}
+
+	if dataUpdate.UpdatedBy != nil {
+		columns = append(columns, "updated_by")
+	}
+
+	return s.updateItem(ctx, cond, dataUpdate, columns)
+}
+
+// updateItem với columns rỗng thì chỉ ghi các field khác nil của dataUpdate
+func (s *sqlStore) updateItem(ctx context.Context, cond map[string]interface{}, dataUpdate *model.TodoItemUpdate, columns []string) error {
+	var rowsAffected int64
+
+	if err := common.WithRetry(ctx, writeRetryAttempts, func() error {
+		return s.WithTransaction(ctx, func(txStore *sqlStore) error {
+			items, err := lockItems(txStore.db, func(db *gorm.DB) *gorm.DB { return db.Where(cond) })
+
+			if err != nil {
+				return err
+			}
+
+			db := txStore.db.Where(cond)
+
+			if len(columns) > 0 {
+				db = db.Select(columns)
+			}
+
+			db = db.Updates(dataUpdate)
+			rowsAffected = db.RowsAffected
+
+			if db.Error != nil || rowsAffected == 0 {
+				return db.Error
+			}
+
+			for i := range items {
+				changes := model.UpdateChanges(&items[i], dataUpdate)
+
+				// Giá trị gửi lên trùng giá trị cũ thì không có gì để ghi log hay phát event
+				if len(changes) == 0 {
+					continue
+				}
+
+				if err := writeAuditLog(txStore.db, items[i].Id, items[i].UserId, model.AuditActionUpdate, changes); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}); err != nil {
+		return common.ErrDB(err)
+	}
 
-	if err := s.db.Where(cond).Updates(dataUpdate).Error; err != nil {
-		return err
+	// Không có dòng nào khớp cond (ví dụ version đã bị đổi)
+	if rowsAffected == 0 {
+		return common.RecordNotFound
 	}
 
 	return nil
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestUpdateItemSkipsAuditWhenNothingChanged(t *testing.T) {
+	ctx := context.Background()
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	sameTitle, newTitle := "title", "new title"
+
+	tests := []struct {
+		name      string
+		update    model.TodoItemUpdate
+		wantLogs  int64
+		wantEvent int64
+	}{
+		{"same values", model.TodoItemUpdate{Title: &sameTitle, Status: &doing}, 0, 0},
+		{"only version bump", model.TodoItemUpdate{}, 0, 0},
+		{"title changed", model.TodoItemUpdate{Title: &newTitle}, 1, 1},
+		{"status changed", model.TodoItemUpdate{Title: &sameTitle, Status: &done}, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			item := model.TodoItem{Title: sameTitle, UserId: 1, Status: &doing}
+
+			if err := store.db.Create(&item).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			version := 2
+			update := tt.update
+			update.Version = &version
+
+			if err := store.UpdateItem(ctx, map[string]interface{}{"id": item.Id}, &update); err != nil {
+				t.Fatal(err)
+			}
+
+			var logs, events int64
+
+			if err := store.db.Model(&model.ItemAuditLog{}).Where("item_id = ?", item.Id).Count(&logs).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.db.Model(&model.OutboxEvent{}).Where("item_id = ?", item.Id).Count(&events).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if logs != tt.wantLogs || events != tt.wantEvent {
+				t.Fatalf("audit logs = %d, events = %d, want %d and %d", logs, events, tt.wantLogs, tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestUpdateItemOnlyWritesSentFields(t *testing.T) {
+	ctx := context.Background()
+	done := model.ItemStatusDone
+	newTitle, newDescription := "new title", "new description"
+
+	tests := []struct {
+		name            string
+		update          model.TodoItemUpdate
+		wantTitle       string
+		wantDescription string
+		wantStatus      model.ItemStatus
+	}{
+		{"only status", model.TodoItemUpdate{Status: &done}, "title", "description", model.ItemStatusDone},
+		{"only title", model.TodoItemUpdate{Title: &newTitle}, newTitle, "description", model.ItemStatusDoing},
+		{"only description", model.TodoItemUpdate{Description: &newDescription}, "title", newDescription, model.ItemStatusDoing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			doing := model.ItemStatusDoing
+			item := model.TodoItem{Title: "title", Description: "description", UserId: 1, Status: &doing}
+
+			if err := store.db.Create(&item).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			update := tt.update
+
+			if err := store.UpdateItem(ctx, map[string]interface{}{"id": item.Id}, &update); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := store.GetItem(ctx, map[string]interface{}{"id": item.Id})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Title != tt.wantTitle || got.Description != tt.wantDescription || *got.Status != tt.wantStatus {
+				t.Errorf("item = %q, %q, %s, want %q, %q, %s",
+					got.Title, got.Description, got.Status.String(), tt.wantTitle, tt.wantDescription, tt.wantStatus.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"time"
+)
+
+// UpdateItemsStatus đổi status của các item thuộc ids (trừ item đã xoá) trong một câu UPDATE.
+// completedAt khác nil thì giữ completed_at cũ nếu đã có, nil thì xoá completed_at.
+// updatedBy là user thực hiện update
+func (s *sqlStore) UpdateItemsStatus(
+	ctx context.Context,
+	cond map[string]interface{},
+	ids []int,
+	status model.ItemStatus,
+	completedAt *time.Time,
+	updatedBy int,
+) (int64, error) {
+	deletedStatus := model.ItemStatusDeleted
+
+	updates := map[string]interface{}{
+		"status":       status,
+		"updated_at":   time.Now().UTC(),
+		"completed_at": nil,
+		"version":      gorm.Expr("version + 1"),
+		"updated_by":   updatedBy,
+	}
+
+	if completedAt != nil {
+		updates["completed_at"] = gorm.Expr("COALESCE(completed_at, ?)", *completedAt)
+	}
+
+	scope := func(db *gorm.DB) *gorm.DB {
+		return db.Where(cond).Where("id IN ?", ids).Where("(status IS NULL OR status <> ?)", deletedStatus)
+	}
+
+	var rowsAffected int64
+
+	if err := s.WithTransaction(ctx, func(txStore *sqlStore) error {
+		items, err := lockItems(txStore.db, scope)
+
+		if err != nil {
+			return err
+		}
+
+		db := scope(txStore.db.Table(model.TodoItem{}.TableName())).Updates(updates)
+
+		if db.Error != nil {
+			return db.Error
+		}
+
+		rowsAffected = db.RowsAffected
+
+		for _, item := range items {
+			if item.Status != nil && *item.Status == status {
+				continue
+			}
+
+			changes := model.AuditChanges{"status": {Old: item.Status, New: &status}}
+
+			if err := writeAuditLog(txStore.db, item.Id, item.UserId, model.AuditActionUpdate, changes); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return 0, common.ErrDB(err)
+	}
+
+	return rowsAffected, nil
+}
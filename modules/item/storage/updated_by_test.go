@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestWritePathsSetUpdatedBy(t *testing.T) {
+	ctx := context.Background()
+	owner := map[string]interface{}{"user_id": 1}
+
+	tests := []struct {
+		name  string
+		write func(store *sqlStore, ids []int) error
+	}{
+		{"delete", func(store *sqlStore, ids []int) error {
+			return store.DeleteItem(ctx, map[string]interface{}{"id": ids[0], "user_id": 1}, 1)
+		}},
+		{"bulk delete", func(store *sqlStore, ids []int) error {
+			_, err := store.DeleteItems(ctx, owner, ids[:1], 1)
+			return err
+		}},
+		{"reorder", func(store *sqlStore, ids []int) error {
+			return store.MoveItemAfter(ctx, 1, ids[0], &ids[1])
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			doing := model.ItemStatusDoing
+			lastEditor := 2
+
+			items := []model.TodoItem{
+				{Title: "a", UserId: 1, Status: &doing, UpdatedBy: &lastEditor},
+				{Title: "b", UserId: 1, Status: &doing, Position: model.ItemPositionGap},
+			}
+
+			for i := range items {
+				if err := store.db.Create(&items[i]).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := tt.write(store, []int{items[0].Id, items[1].Id}); err != nil {
+				t.Fatal(err)
+			}
+
+			var got model.TodoItem
+
+			if err := store.db.Where("id = ?", items[0].Id).First(&got).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if got.UpdatedBy == nil || *got.UpdatedBy != 1 {
+				t.Errorf("updated_by = %v, want 1", got.UpdatedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/storage"
+)
+
+// ArchiveItem ẩn item khỏi list mặc định, xem lại bằng ?include_archived=true hoặc ?only_archived=true
+func ArchiveItem(db *gorm.DB, cache storage.ItemCache) func(c *gin.Context) {
+	return setItemArchived(db, cache, true)
+}
+
+func UnarchiveItem(db *gorm.DB, cache storage.ItemCache) func(c *gin.Context) {
+	return setItemArchived(db, cache, false)
+}
+
+func setItemArchived(db *gorm.DB, cache storage.ItemCache, archived bool) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewArchiveItemBiz(store, requester)
+
+		if err := business.SetArchived(c.Request.Context(), id, archived); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
@@ -0,0 +1,96 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestArchiveItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	visible := model.TodoItem{Title: "visible", UserId: 1, Status: &doing}
+	archived := model.TodoItem{Title: "archived", UserId: 1, Status: &done}
+
+	for _, item := range []*model.TodoItem{&visible, &archived} {
+		if err := db.Create(item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+	r.POST("/items/:id/archive", ArchiveItem(db, nil))
+	r.DELETE("/items/:id/archive", UnarchiveItem(db, nil))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+		return w
+	}
+
+	listTitles := func(t *testing.T, query string) map[string]bool {
+		t.Helper()
+
+		var resp listItemsResponse
+
+		w := serve(http.MethodGet, "/items"+query)
+
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("list: status = %d, body = %s", w.Code, w.Body)
+		}
+
+		titles := map[string]bool{}
+
+		for _, item := range resp.Data {
+			titles[item["title"].(string)] = true
+		}
+
+		return titles
+	}
+
+	if w := serve(http.MethodPost, itemPath(archived.Id)+"/archive"); w.Code != http.StatusOK {
+		t.Fatalf("archive: status = %d, body = %s", w.Code, w.Body)
+	}
+
+	tests := []struct {
+		name      string
+		unarchive bool
+		query     string
+		want      map[string]bool
+	}{
+		{"default list hides archived", false, "", map[string]bool{"visible": true}},
+		{"include archived", false, "?include_archived=true", map[string]bool{"visible": true, "archived": true}},
+		{"only archived", false, "?only_archived=true", map[string]bool{"archived": true}},
+		{"unarchived is back in the default list", true, "", map[string]bool{"visible": true, "archived": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.unarchive {
+				if w := serve(http.MethodDelete, itemPath(archived.Id)+"/archive"); w.Code != http.StatusOK {
+					t.Fatalf("unarchive: status = %d, body = %s", w.Code, w.Body)
+				}
+			}
+
+			if got := listTitles(t, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var stored model.TodoItem
+
+	if err := db.First(&stored, archived.Id).Error; err != nil || *stored.Status != model.ItemStatusDone {
+		t.Errorf("status = %v (%v), want archiving to keep Done", stored.Status, err)
+	}
+}
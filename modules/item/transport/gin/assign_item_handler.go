@@ -0,0 +1,57 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+	userstorage "social-todo-list/modules/user/storage"
+)
+
+// AssignItem chuyển item của requester cho user khác, body {"user_id": "..."}
+func AssignItem(db *gorm.DB, cache storage.ItemCache) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var data model.TodoItemAssign
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		if data.UserId == "" {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(model.ErrAssigneeIsMissing)))
+			return
+		}
+
+		userId, err := common.ParseLocalId(data.UserId, common.DbTypeUser)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		userStore := userstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewAssignItemBiz(store, userStore, requester)
+
+		if err := business.AssignItem(c.Request.Context(), id, userId); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
@@ -0,0 +1,118 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	usermodel "social-todo-list/modules/user/model"
+	"strings"
+	"testing"
+)
+
+func TestAssignItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []*usermodel.User{{Email: "a@example.com"}, {Email: "b@example.com"}, {Email: "c@example.com"}} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// userId là requester của request tiếp theo
+	userId := 1
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(userId)) })
+	r.GET("/items", ListItem(db))
+	r.POST("/items/:id/assign", AssignItem(db, nil))
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", common.MIMEJSON)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	listCount := func(t *testing.T, requester int) int {
+		t.Helper()
+
+		userId = requester
+
+		var resp listItemsResponse
+
+		w := serve(http.MethodGet, "/items", "")
+
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("list: status = %d, body = %s", w.Code, w.Body)
+		}
+
+		return len(resp.Data)
+	}
+
+	assignee := func(id int) string {
+		return `{"user_id":"` + common.NewUID(uint32(id), common.DbTypeUser, 1).String() + `"}`
+	}
+
+	tests := []struct {
+		name       string
+		requester  int
+		body       string
+		wantStatus int
+		wantKey    string
+		wantOwner  int
+	}{
+		{"assignee does not exist", 1, assignee(99), http.StatusBadRequest, "ErrInvalidRequest", 1},
+		{"missing user_id", 1, `{}`, http.StatusBadRequest, "ErrInvalidRequest", 1},
+		{"owner assigns to user 2", 1, assignee(2), http.StatusOK, "", 2},
+		{"previous owner can no longer assign", 1, assignee(3), http.StatusNotFound, "ErrItemNotFound", 2},
+		{"new owner assigns to user 3", 2, assignee(3), http.StatusOK, "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId = tt.requester
+
+			w := serve(http.MethodPost, itemPath(item.Id)+"/assign", tt.body)
+
+			if w.Code != tt.wantStatus || (tt.wantKey != "" && !strings.Contains(w.Body.String(), `"error_key":"`+tt.wantKey+`"`)) {
+				t.Fatalf("status = %d, body = %s, want %d %s", w.Code, w.Body, tt.wantStatus, tt.wantKey)
+			}
+
+			for owner := 1; owner <= 3; owner++ {
+				want := 0
+
+				if owner == tt.wantOwner {
+					want = 1
+				}
+
+				if got := listCount(t, owner); got != want {
+					t.Errorf("user %d sees %d items, want %d", owner, got, want)
+				}
+			}
+		})
+	}
+
+	var logs []model.ItemAuditLog
+
+	if err := db.Where("item_id = ?", item.Id).Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 2 || logs[0].Changes["user_id"].New != float64(2) || logs[1].Changes["user_id"].New != float64(3) {
+		t.Errorf("audit log = %+v, want one user_id change per assignment", logs)
+	}
+}
@@ -0,0 +1,49 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+// CloneItem tạo bản sao của item, ?title_suffix= thay cho suffix mặc định " (copy)" (gửi rỗng là giữ nguyên title)
+func CloneItem(db *gorm.DB, dailyQuota int, lengthLimits model.LengthLimits, sanitizer *common.HTMLSanitizer) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		titleSuffix, ok := c.GetQuery("title_suffix")
+
+		if !ok {
+			titleSuffix = model.DefaultCloneTitleSuffix
+		}
+
+		// Nội dung item gốc đã được làm sạch lúc lưu, làm sạch lại sẽ escape hai lần nên chỉ làm sạch suffix
+		titleSuffix = sanitizer.Sanitize(titleSuffix)
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		creator := biz.NewCreateItemBiz(store, 0, dailyQuota, model.ItemStatusDoing, lengthLimits, nil, requester)
+		business := biz.NewCloneItemBiz(store, creator, requester)
+
+		cloneId, err := business.CloneItem(c.Request.Context(), id, titleSuffix)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		itemsCreatedTotal.Inc()
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(common.NewUID(uint32(cloneId), common.DbTypeItem, 1)))
+	}
+}
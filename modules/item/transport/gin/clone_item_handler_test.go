@@ -0,0 +1,108 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	"testing"
+	"time"
+)
+
+func TestCloneItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		requester  int
+		query      string
+		wantStatus int
+		wantTitle  string
+	}{
+		{"default suffix", 1, "", http.StatusOK, "buy milk (copy)"},
+		{"custom suffix", 1, "?title_suffix=%20again", http.StatusOK, "buy milk again"},
+		{"not the owner", 2, "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			done := model.ItemStatusDone
+			completedAt := time.Now().Add(-time.Hour)
+			original := model.TodoItem{Title: "buy milk", Description: "2 bottles", UserId: 1, Status: &done, CompletedAt: &completedAt, Tags: model.ItemTags{"home", "shopping"}}
+
+			if err := db.Create(&original).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.Create(&likemodel.Like{ItemId: original.Id, UserId: 2}).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(tt.requester)) })
+			r.POST("/items/:id/clone", CloneItem(db, 0, model.LengthLimits{}, nil))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, itemPath(original.Id)+"/clone"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			var items []model.TodoItem
+
+			if err := db.Order("id").Find(&items).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if stored := items[0]; stored.Title != "buy milk" || *stored.Status != model.ItemStatusDone || stored.CompletedAt == nil {
+				t.Errorf("original = %+v, want it unchanged", stored)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				if len(items) != 1 {
+					t.Errorf("items = %d, want no clone", len(items))
+				}
+
+				return
+			}
+
+			var resp struct {
+				Data common.UID `json:"data"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(items) != 2 {
+				t.Fatalf("body = %s, items = %d, err = %v", w.Body, len(items), err)
+			}
+
+			clone := items[1]
+
+			if int(resp.Data.GetLocalID()) != clone.Id || clone.Id == original.Id {
+				t.Errorf("returned id = %d, want the new row %d", resp.Data.GetLocalID(), clone.Id)
+			}
+
+			if clone.Title != tt.wantTitle || clone.Description != original.Description || clone.UserId != 1 {
+				t.Errorf("clone = %q %q user %d, want %q", clone.Title, clone.Description, clone.UserId, tt.wantTitle)
+			}
+
+			if *clone.Status != model.ItemStatusDoing || clone.CompletedAt != nil {
+				t.Errorf("clone status = %v completed_at = %v, want Doing and not completed", *clone.Status, clone.CompletedAt)
+			}
+
+			if !reflect.DeepEqual(clone.Tags, original.Tags) {
+				t.Errorf("clone tags = %v, want %v", clone.Tags, original.Tags)
+			}
+
+			var likes int64
+
+			if err := db.Model(&likemodel.Like{}).Where("item_id = ?", clone.Id).Count(&likes).Error; err != nil || likes != 0 {
+				t.Errorf("clone likes = %d (%v), want none", likes, err)
+			}
+		})
+	}
+}
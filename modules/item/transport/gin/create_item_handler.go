@@ -8,29 +8,76 @@ import (
 	"social-todo-list/modules/item/biz"
 	"social-todo-list/modules/item/model"
 	"social-todo-list/modules/item/storage"
+	"strconv"
+	"time"
 )
 
-func CreateItem(db *gorm.DB) func(c *gin.Context) {
+// CreateItem nhận header Idempotency-Key (không bắt buộc) để client retry mà không tạo trùng item,
+// ?dry_run=true chỉ validate và trả về item sẽ được tạo, không ghi gì xuống DB
+func CreateItem(
+	db *gorm.DB,
+	idempotencyTTL time.Duration,
+	dailyQuota int,
+	defaultStatus model.ItemStatus,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var data model.TodoItemCreation
 
 		if err := c.ShouldBind(&data); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 			return
 		}
 
-		store := storage.NewSQLStorage(db)
+		if v := c.Query("allow_duplicate"); v != "" {
+			allowDuplicate, err := strconv.ParseBool(v)
 
-		business := biz.NewCreateItemBiz(store)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+				return
+			}
 
-		if err := business.CreateNewItem(c.Request.Context(), &data); err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"error": err.Error(),
-			})
+			data.AllowDuplicate = allowDuplicate
+		}
+
+		dryRun, err := parseDryRun(c)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		idempotencyKey := c.GetHeader(model.HeaderIdempotencyKey)
+
+		create := func(db *gorm.DB) error {
+			business := biz.NewCreateItemBiz(storage.NewSQLStorage(db), idempotencyTTL, dailyQuota, defaultStatus, lengthLimits, sanitizer, requester)
+			return business.CreateNewItem(c.Request.Context(), idempotencyKey, &data)
+		}
+
+		if dryRun {
+			item, err := dryRunItem(c.Request.Context(), db, create, func() int { return data.Id })
+
+			if err != nil {
+				appErr := common.ToAppError(err)
+				c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+				return
+			}
+
+			c.JSON(http.StatusOK, common.SimpleSuccessResponse(item).WithDryRun())
+			return
+		}
+
+		if err := create(db); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 			return
 		}
-		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data.Id))
+
+		itemsCreatedTotal.Inc()
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(common.NewUID(uint32(data.Id), common.DbTypeItem, 1)))
 	}
 }
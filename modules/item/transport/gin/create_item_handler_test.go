@@ -0,0 +1,122 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCreateItemValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.POST("/items", CreateItem(db, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil))
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantDetails []string
+	}{
+		{"valid", `{"title":"buy milk"}`, http.StatusOK, nil},
+		{"one bad field", `{"title":" "}`, http.StatusUnprocessableEntity, []string{"title"}},
+		{"two bad fields", `{"title":"","due_date":"2000-01-01T00:00:00Z"}`, http.StatusUnprocessableEntity, []string{"due_date", "title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", common.MIMEJSON)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if tt.wantDetails == nil {
+				return
+			}
+
+			var resp struct {
+				Key     string            `json:"error_key"`
+				Details map[string]string `json:"details"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			fields := make([]string, 0, len(resp.Details))
+
+			for field := range resp.Details {
+				fields = append(fields, field)
+			}
+
+			sort.Strings(fields)
+
+			if resp.Key != "ErrValidation" || !reflect.DeepEqual(fields, tt.wantDetails) {
+				t.Errorf("body = %s, want ErrValidation with details for %v", w.Body, tt.wantDetails)
+			}
+		})
+	}
+}
+
+func TestCreateItemCountsCreatedItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.POST("/items", CreateItem(db, 0, 0, model.ItemStatusDoing, model.LengthLimits{}, nil))
+	r.GET("/metrics", common.MetricsHandler())
+
+	before := createdItemsMetric(t, r)
+
+	for _, body := range []string{`{"title":"buy milk"}`, `{"title":"buy bread"}`, `{"title":""}`} {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set("Content-Type", common.MIMEJSON)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if after := createdItemsMetric(t, r); after != before+2 {
+		t.Errorf("items_created_total = %v, want %v", after, before+2)
+	}
+}
+
+// createdItemsMetric scrape /metrics và trả về giá trị của items_created_total
+func createdItemsMetric(t *testing.T, r *gin.Engine) float64 {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if value, ok := strings.CutPrefix(line, "items_created_total "); ok {
+			v, err := strconv.ParseFloat(value, 64)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return v
+		}
+	}
+
+	t.Fatalf("items_created_total not found in /metrics")
+
+	return 0
+}
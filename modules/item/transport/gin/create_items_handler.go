@@ -0,0 +1,76 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+	"strconv"
+)
+
+// CreateItems mặc định chỉ tạo khi mọi item đều hợp lệ. ?best_effort=true thì vẫn tạo các item hợp lệ
+// và trả 207 kèm kết quả của từng item theo thứ tự gửi lên
+func CreateItems(db *gorm.DB, dailyQuota int, defaultStatus model.ItemStatus, lengthLimits model.LengthLimits, sanitizer *common.HTMLSanitizer) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		bestEffort := false
+
+		if v := c.Query("best_effort"); v != "" {
+			var err error
+
+			if bestEffort, err = strconv.ParseBool(v); err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+				return
+			}
+		}
+
+		var data []*model.TodoItemCreation
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewCreateItemsBiz(store, dailyQuota, defaultStatus, lengthLimits, sanitizer, requester)
+
+		if bestEffort {
+			results, err := business.CreateItemsBestEffort(c.Request.Context(), data)
+
+			if err != nil {
+				appErr := common.ToAppError(err)
+				c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+				return
+			}
+
+			for _, result := range results {
+				if result.Id != nil {
+					itemsCreatedTotal.Inc()
+				}
+			}
+
+			c.JSON(http.StatusMultiStatus, common.SimpleSuccessResponse(results))
+			return
+		}
+
+		if err := business.CreateItems(c.Request.Context(), data); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		ids := make([]common.UID, len(data))
+
+		for i := range data {
+			ids[i] = common.NewUID(uint32(data[i].Id), common.DbTypeItem, 1)
+		}
+
+		itemsCreatedTotal.Add(float64(len(data)))
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(ids))
+	}
+}
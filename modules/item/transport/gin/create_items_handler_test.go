@@ -0,0 +1,154 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+func TestCreateItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+		wantCreated int
+	}{
+		{"batch of 3", `[{"title":"a"},{"title":"b"},{"title":"c"}]`, http.StatusOK, "", 3},
+		{"blank title at index 1", `[{"title":"a"},{"title":"  "},{"title":"c"}]`, http.StatusUnprocessableEntity, `"[1].title"`, 0},
+		{"empty array", `[]`, http.StatusBadRequest, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+			r.POST("/items/batch", CreateItems(db, 0, model.ItemStatusDoing, model.LengthLimits{}, nil))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items/batch", strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantMessage) {
+				t.Errorf("body = %s, want it to mention %q", w.Body, tt.wantMessage)
+			}
+
+			var created []model.TodoItem
+
+			if err := db.Order("id").Find(&created).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(created) != tt.wantCreated {
+				t.Fatalf("created %d items, want %d", len(created), tt.wantCreated)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data []common.UID `json:"data"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, item := range created {
+				if int(body.Data[i].GetLocalID()) != item.Id {
+					t.Errorf("id %d = %d, want %d (ids must follow request order)", i, body.Data[i].GetLocalID(), item.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateItemsBestEffort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `[{"title":"a"},{"title":"  "},{"title":"c"}]`
+
+	tests := []struct {
+		name        string
+		query       string
+		wantStatus  int
+		wantCreated []string
+	}{
+		{"best effort keeps the valid rows", "?best_effort=true", http.StatusMultiStatus, []string{"a", "c"}},
+		{"strict creates nothing", "", http.StatusUnprocessableEntity, nil},
+		{"strict when flag is false", "?best_effort=false", http.StatusUnprocessableEntity, nil},
+		{"invalid flag", "?best_effort=maybe", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+			r.POST("/items/batch", CreateItems(db, 0, model.ItemStatusDoing, model.LengthLimits{}, nil))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/items/batch"+tt.query, strings.NewReader(body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			var created []model.TodoItem
+
+			if err := db.Order("id").Find(&created).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(created) != len(tt.wantCreated) {
+				t.Fatalf("created %d items, want %d", len(created), len(tt.wantCreated))
+			}
+
+			for i, item := range created {
+				if item.Title != tt.wantCreated[i] {
+					t.Errorf("item %d title = %q, want %q", i, item.Title, tt.wantCreated[i])
+				}
+			}
+
+			if tt.wantStatus != http.StatusMultiStatus {
+				return
+			}
+
+			var resp struct {
+				Data []model.BatchItemResult `json:"data"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data) != 3 {
+				t.Fatalf("body = %s, err = %v", w.Body, err)
+			}
+
+			ok, failed := resp.Data[0], resp.Data[1]
+
+			if ok.Index != 0 || ok.Id == nil || int(ok.Id.GetLocalID()) != created[0].Id {
+				t.Errorf("result 0 = %+v, want the id of %q", ok, created[0].Title)
+			}
+
+			if failed.Index != 1 || failed.Id != nil || failed.Error == "" {
+				t.Errorf("result 1 = %+v, want an error", failed)
+			}
+
+			if last := resp.Data[2]; last.Index != 2 || last.Id == nil || int(last.Id.GetLocalID()) != created[1].Id {
+				t.Errorf("result 2 = %+v, want the id of %q", last, created[1].Title)
+			}
+		})
+	}
+}
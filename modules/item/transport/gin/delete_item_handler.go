@@ -7,27 +7,24 @@ import (
 	"social-todo-list/common"
 	"social-todo-list/modules/item/biz"
 	"social-todo-list/modules/item/storage"
-	"strconv"
 )
 
-func DeleteItem(db *gorm.DB) func(c *gin.Context) {
+func DeleteItem(db *gorm.DB, hideForbidden bool, cache storage.ItemCache) func(c *gin.Context) {
 	return func(c *gin.Context) {
-		id, err := strconv.Atoi(c.Param("id"))
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
 
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
 			return
 		}
 
-		store := storage.NewSQLStorage(db)
-		business := biz.NewDeleteItemBiz(store)
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewDeleteItemBiz(store, hideForbidden, requester)
 
 		if err := business.DeleteItemById(c.Request.Context(), id); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 
 			return
 		}
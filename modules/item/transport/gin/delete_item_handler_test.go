@@ -0,0 +1,58 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestDeleteItemIsSoft(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items/:id", GetItem(db, false, nil))
+	r.DELETE("/items/:id", DeleteItem(db, false, nil))
+
+	// Các bước chạy theo thứ tự trên cùng một item
+	steps := []struct {
+		name       string
+		method     string
+		wantStatus int
+	}{
+		{"get before delete", http.MethodGet, http.StatusOK},
+		{"delete", http.MethodDelete, http.StatusOK},
+		{"get after delete", http.MethodGet, http.StatusNotFound},
+		{"delete again", http.MethodDelete, http.StatusBadRequest},
+	}
+
+	for _, step := range steps {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(step.method, itemPath(item.Id), nil))
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+	}
+
+	var stored model.TodoItem
+
+	if err := db.First(&stored, item.Id).Error; err != nil {
+		t.Fatalf("deleted item is gone from the table: %v", err)
+	}
+
+	if stored.Status == nil || *stored.Status != model.ItemStatusDeleted {
+		t.Errorf("status = %s, want Deleted", stored.Status.String())
+	}
+}
@@ -0,0 +1,37 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+func DeleteItems(db *gorm.DB, cache storage.ItemCache) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var data model.TodoItemsDelete
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewDeleteItemsBiz(store, requester)
+
+		deleted, err := business.DeleteItems(c.Request.Context(), &data)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(gin.H{"deleted": deleted}))
+	}
+}
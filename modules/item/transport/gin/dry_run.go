@@ -0,0 +1,59 @@
+package ginitem
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+	"strconv"
+)
+
+// errDryRun được trả ra cuối transaction để gorm rollback mọi thứ đã ghi
+var errDryRun = errors.New("dry run")
+
+// itemWriter chạy biz create/update trên db, dry run thì db là transaction sẽ bị rollback
+// nên cả event trong outbox cũng không còn, websocket không được báo
+type itemWriter func(db *gorm.DB) error
+
+// parseDryRun đọc query ?dry_run=true, không có thì là false
+func parseDryRun(c *gin.Context) (bool, error) {
+	v := c.Query("dry_run")
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+// dryRunItem chạy write trong transaction, đọc lại item theo id() rồi rollback,
+// vẫn đi qua đủ validation và kiểm tra quyền của biz nhưng không có row nào bị ghi
+func dryRunItem(ctx context.Context, db *gorm.DB, write itemWriter, id func() int) (*model.TodoItem, error) {
+	var data *model.TodoItem
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := write(tx); err != nil {
+			return err
+		}
+
+		item, err := storage.NewSQLStorage(tx).GetItem(ctx, map[string]interface{}{"id": id()})
+
+		if err != nil {
+			return err
+		}
+
+		data = item
+
+		return errDryRun
+	})
+
+	if !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	data.Mask()
+
+	return data, nil
+}
@@ -0,0 +1,74 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	limits := model.LengthLimits{Title: 20}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.POST("/items", CreateItem(db, 0, 0, model.ItemStatusDoing, limits, nil))
+	r.PATCH("/items/:id", UpdateItem(db, false, limits, nil, nil))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"create", http.MethodPost, "/items?dry_run=true", `{"title":"buy bread"}`, http.StatusOK, `"title":"buy bread"`},
+		{"create with invalid title", http.MethodPost, "/items?dry_run=true", `{"title":""}`, http.StatusUnprocessableEntity, `"ErrValidation"`},
+		{"update", http.MethodPatch, itemPath(item.Id) + "?dry_run=true", `{"title":"buy bread","status":"Done"}`, http.StatusOK, `"status":"Done"`},
+		{"update with invalid status", http.MethodPatch, itemPath(item.Id) + "?dry_run=true", `{"status":"Bogus"}`, http.StatusBadRequest, `"ErrInvalidRequest"`},
+		{"update with too long title", http.MethodPatch, itemPath(item.Id) + "?dry_run=true", `{"title":"` + strings.Repeat("a", 21) + `"}`, http.StatusUnprocessableEntity, `"ErrValidation"`},
+		{"update of missing item", http.MethodPatch, itemPath(item.Id+1) + "?dry_run=true", `{"title":"buy bread"}`, http.StatusNotFound, `"ErrItemNotFound"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", common.MIMEJSON)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus || !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Fatalf("status = %d, body = %s, want %d with %s", w.Code, w.Body, tt.wantStatus, tt.wantBody)
+			}
+
+			if w.Code == http.StatusOK && !strings.Contains(w.Body.String(), `"dry_run":true`) {
+				t.Errorf("body = %s, want the dry_run marker", w.Body)
+			}
+
+			var items []model.TodoItem
+
+			if err := db.Find(&items).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if len(items) != 1 || items[0].Title != "buy milk" || *items[0].Status != model.ItemStatusDoing || items[0].Version != item.Version {
+				t.Errorf("items = %+v, want the table untouched", items)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/storage"
+)
+
+func ExportItems(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewExportItemsBiz(store, requester)
+
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="items.csv"`)
+
+		if err := business.ExportItems(c.Request.Context(), c.Writer); err != nil {
+			// Đã stream một phần file thì không đổi được status code nữa, chỉ huỷ response
+			if c.Writer.Written() {
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+
+			appErr := common.ToAppError(err)
+			c.Header("Content-Type", "")
+			c.Header("Content-Disposition", "")
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+	}
+}
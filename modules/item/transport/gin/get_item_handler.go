@@ -6,36 +6,79 @@ import (
 	"net/http"
 	"social-todo-list/common"
 	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
 	"social-todo-list/modules/item/storage"
-	"strconv"
+	subtaskstorage "social-todo-list/modules/subtask/storage"
+	likestorage "social-todo-list/modules/userlikeitem/storage"
+	"strings"
 )
 
-func GetItem(db *gorm.DB) func(c *gin.Context) {
+// GetItem đọc qua cache nên db phải lấy từ common.Primary để cache không nạp bản cũ của replica
+func GetItem(db *gorm.DB, hideForbidden bool, cache storage.ItemCache) func(c *gin.Context) {
 	return func(c *gin.Context) {
-		id, err := strconv.Atoi(c.Param("id"))
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
 
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
 			return
 		}
 
-		//data.Id = id
+		fields, err := model.ParseFields(c.Query("fields"))
 
-		store := storage.NewSQLStorage(db)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
 
-		business := biz.NewGetItemBiz(store)
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		likeStore := likestorage.NewSQLStorage(db)
+		subtaskStore := subtaskstorage.NewSQLStorage(db)
 
-		data, err := business.GetItemById(c.Request.Context(), id)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewGetItemBiz(store, likeStore, subtaskStore, hideForbidden, requester)
+
+		data, err := business.GetItemById(c.Request.Context(), id, fields...)
 
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
-			
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+
+			return
+		}
+
+		// Response chỉ có một phần field luôn là JSON, còn lại theo Accept
+		format := common.MIMEJSON
+
+		if len(fields) == 0 {
+			format = common.NegotiatedFormat(c)
+		}
+
+		// JSON, XML hay chỉ một phần field là các biểu diễn khác nhau nên ETag cũng khác
+		etag := common.NewETag(data.ETag(), format, strings.Join(fields, ","))
+
+		c.Header(common.HeaderETag, etag)
+
+		if common.MatchETag(c.GetHeader(common.HeaderIfNoneMatch), etag) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+
+		data.Mask()
+
+		// Response chỉ có một phần field là map nên chỉ trả được JSON
+		if len(fields) > 0 {
+			picked, err := common.PickFields(data, fields)
+
+			if err != nil {
+				appErr := common.ToAppError(err)
+				c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+				return
+			}
+
+			c.JSON(http.StatusOK, common.SimpleSuccessResponse(picked))
 			return
 		}
-		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data))
+
+		common.Render(c, http.StatusOK, common.SimpleSuccessResponse(data))
 	}
 }
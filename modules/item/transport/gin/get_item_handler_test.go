@@ -0,0 +1,221 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	subtaskmodel "social-todo-list/modules/subtask/model"
+	usermodel "social-todo-list/modules/user/model"
+	likemodel "social-todo-list/modules/userlikeitem/model"
+	"strings"
+	"testing"
+)
+
+// newTestDB tạo sqlite in-memory với các bảng mà handler của item dùng, một connection để mọi query thấy cùng một DB
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(
+		&model.TodoItem{},
+		&model.IdempotencyKey{},
+		&model.ItemAuditLog{},
+		&model.OutboxEvent{},
+		&model.ItemUserLock{},
+		&likemodel.Like{},
+		&subtaskmodel.Subtask{},
+		&usermodel.User{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// itemPath là path /items/:id với id đã mã hoá thành UID như client nhận được
+func itemPath(id int) string {
+	return "/items/" + common.NewUID(uint32(id), common.DbTypeItem, 1).String()
+}
+
+func TestGetItemNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.GET("/items/:id", func(c *gin.Context) {
+		c.Set(common.CurrentUser, common.NewRequester(1))
+	}, GetItem(db, false, nil))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantKey    string
+	}{
+		{"found", itemPath(item.Id), http.StatusOK, ""},
+		{"not found", itemPath(item.Id + 1), http.StatusNotFound, "ErrItemNotFound"},
+		{"invalid id", "/items/abc", http.StatusBadRequest, "ErrInvalidRequest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if tt.wantKey != "" && !strings.Contains(w.Body.String(), `"error_key":"`+tt.wantKey+`"`) {
+				t.Errorf("body = %s, want error key %s", w.Body, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestGetItemETagPerRepresentation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.GET("/items/:id", func(c *gin.Context) {
+		c.Set(common.CurrentUser, common.NewRequester(1))
+	}, common.ContentNegotiation(), GetItem(db, false, nil))
+
+	path := itemPath(item.Id)
+
+	get := func(url, accept, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Accept", accept)
+
+		if ifNoneMatch != "" {
+			req.Header.Set(common.HeaderIfNoneMatch, ifNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	jsonETag := get(path, common.MIMEJSON, "").Header().Get(common.HeaderETag)
+	xmlETag := get(path, common.MIMEXML, "").Header().Get(common.HeaderETag)
+	fieldsETag := get(path+"?fields=title", common.MIMEXML, "").Header().Get(common.HeaderETag)
+
+	if jsonETag == "" || jsonETag == xmlETag || jsonETag == fieldsETag || xmlETag == fieldsETag {
+		t.Fatalf("etags must differ per representation: json %s, xml %s, fields %s", jsonETag, xmlETag, fieldsETag)
+	}
+
+	tests := []struct {
+		name        string
+		accept      string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"json revalidated with json etag", common.MIMEJSON, jsonETag, http.StatusNotModified},
+		{"xml revalidated with xml etag", common.MIMEXML, xmlETag, http.StatusNotModified},
+		{"xml with json etag", common.MIMEXML, jsonETag, http.StatusOK},
+		{"json with xml etag", common.MIMEJSON, xmlETag, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(path, tt.accept, tt.ifNoneMatch)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			if vary := w.Header().Values("Vary"); len(vary) == 0 || vary[0] != "Accept" {
+				t.Errorf("vary = %v, want Accept", vary)
+			}
+		})
+	}
+}
+
+func TestGetItemConditional(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items/:id", GetItem(db, false, nil))
+	r.PATCH("/items/:id", UpdateItem(db, false, model.LengthLimits{}, nil, nil))
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, itemPath(item.Id), nil)
+
+		if ifNoneMatch != "" {
+			req.Header.Set(common.HeaderIfNoneMatch, ifNoneMatch)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w
+	}
+
+	first := get("")
+	etag := first.Header().Get(common.HeaderETag)
+
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first fetch: status = %d, etag = %q", first.Code, etag)
+	}
+
+	if w := get(etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("conditional fetch: status = %d, body = %s, want 304 without body", w.Code, w.Body)
+	}
+
+	if w := get("not-quoted"); w.Code != http.StatusOK {
+		t.Fatalf("malformed If-None-Match: status = %d, want 200", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, itemPath(item.Id), strings.NewReader(`{"title":"buy bread"}`))
+	req.Header.Set("Content-Type", common.MIMEJSON)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	after := get(etag)
+
+	if after.Code != http.StatusOK || after.Header().Get(common.HeaderETag) == etag {
+		t.Fatalf("after update: status = %d, etag = %q, want 200 with a new etag", after.Code, after.Header().Get(common.HeaderETag))
+	}
+}
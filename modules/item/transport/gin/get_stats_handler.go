@@ -0,0 +1,36 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/storage"
+)
+
+// GetStats nhận ?timezone= (tên IANA, mặc định UTC) để tính các số liệu theo ngày
+func GetStats(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		loc, err := common.LoadTimezone(c.Query("timezone"))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewStatsBiz(store, requester)
+
+		data, err := business.GetStats(c.Request.Context(), loc)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data))
+	}
+}
@@ -0,0 +1,39 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+func ImportItems(db *gorm.DB, dailyQuota int, defaultStatus model.ItemStatus, lengthLimits model.LengthLimits, sanitizer *common.HTMLSanitizer) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var data []*model.TodoItemCreation
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewImportItemsBiz(store, dailyQuota, defaultStatus, lengthLimits, sanitizer, requester)
+
+		report, err := business.ImportItems(c.Request.Context(), data)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		itemsCreatedTotal.Add(float64(report.Imported))
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(report))
+	}
+}
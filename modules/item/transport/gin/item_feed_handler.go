@@ -0,0 +1,84 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"social-todo-list/common"
+	"time"
+)
+
+const (
+	feedWriteTimeout = 10 * time.Second
+	// Client phải trả pong trong feedPongTimeout, server ping mỗi feedPingInterval (nhỏ hơn timeout)
+	feedPongTimeout  = 60 * time.Second
+	feedPingInterval = 50 * time.Second
+)
+
+// Upgrader mặc định chỉ nhận kết nối cùng origin với host. Client gửi token qua subprotocol
+// thì phải được chọn lại subprotocol "bearer", nếu không trình duyệt sẽ đóng kết nối
+var feedUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	Subprotocols:    []string{common.WebsocketTokenProtocol},
+}
+
+// ItemFeed đẩy event tạo/sửa/xoá item của requester qua websocket cho tới khi client ngắt kết nối
+func ItemFeed(bus *common.EventBus) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+
+		// Upgrade lỗi thì upgrader đã tự trả response lỗi cho client
+		conn, err := feedUpgrader.Upgrade(c.Writer, c.Request, nil)
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		sub := bus.Subscribe(requester.GetUserId())
+		defer sub.Close()
+
+		// Client không gửi gì lên, chỉ cần đọc để nhận pong và phát hiện ngắt kết nối
+		closed := make(chan struct{})
+
+		go func() {
+			defer close(closed)
+
+			_ = conn.SetReadDeadline(time.Now().Add(feedPongTimeout))
+			conn.SetPongHandler(func(string) error {
+				return conn.SetReadDeadline(time.Now().Add(feedPongTimeout))
+			})
+
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		ticker := time.NewTicker(feedPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+
+				_ = conn.SetWriteDeadline(time.Now().Add(feedWriteTimeout))
+
+				if err := conn.WriteJSON(event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(feedWriteTimeout)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
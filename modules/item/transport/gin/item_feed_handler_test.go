@@ -0,0 +1,96 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestItemFeedBrowserAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokenizer := common.NewJWTTokenizer("secret")
+	token, err := tokenizer.Generate(common.TokenPayload{UserId: 3}, 60)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := common.NewEventBus()
+	r := gin.New()
+	r.GET("/ws/items", common.RequireWebsocketAuth(tokenizer), ItemFeed(bus))
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/items"
+
+	tests := []struct {
+		name         string
+		url          string
+		protocols    []string
+		wantProtocol string
+	}{
+		{"query param", url + "?access_token=" + token.Token, nil, ""},
+		{"subprotocol", url, []string{common.WebsocketTokenProtocol, token.Token}, common.WebsocketTokenProtocol},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := websocket.Dialer{Subprotocols: tt.protocols}
+			conn, resp, err := dialer.Dial(tt.url, nil)
+
+			if err != nil {
+				t.Fatalf("dial: %v (response %v)", err, resp)
+			}
+
+			defer conn.Close()
+
+			if got := conn.Subprotocol(); got != tt.wantProtocol {
+				t.Errorf("subprotocol = %q, want %q", got, tt.wantProtocol)
+			}
+
+			// Handler subscribe sau khi upgrade, publish tới khi client nhận được event
+			received := make(chan common.Event, 1)
+
+			go func() {
+				var event common.Event
+
+				if err := conn.ReadJSON(&event); err == nil {
+					received <- event
+				}
+			}()
+
+			deadline := time.After(2 * time.Second)
+
+			for {
+				bus.Publish(3, common.Event{Type: "item.created"})
+
+				select {
+				case event := <-received:
+					if event.Type != "item.created" {
+						t.Fatalf("event type = %s, want item.created", event.Type)
+					}
+
+					return
+				case <-deadline:
+					t.Fatal("no event received")
+				case <-time.After(20 * time.Millisecond):
+				}
+			}
+		})
+	}
+
+	t.Run("no token", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+
+		if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("err = %v, response %v, want 401", err, resp)
+		}
+	})
+}
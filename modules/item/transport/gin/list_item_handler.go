@@ -8,41 +8,82 @@ import (
 	"social-todo-list/modules/item/biz"
 	"social-todo-list/modules/item/model"
 	"social-todo-list/modules/item/storage"
+	subtaskstorage "social-todo-list/modules/subtask/storage"
+	userstorage "social-todo-list/modules/user/storage"
+	likestorage "social-todo-list/modules/userlikeitem/storage"
 )
 
 func ListItem(db *gorm.DB) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var paging common.Paging
 
-		paging.Process()
-
 		if err := c.ShouldBind(&paging); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		// Có query cursor (kể cả rỗng) là phân trang theo cursor, không có là OFFSET như cũ
+		_, paging.CursorMode = c.GetQuery("cursor")
+
+		if err := paging.Process(); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 			return
 		}
 
 		var filter model.Filter
 
 		if err := c.ShouldBind(&filter); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
 			return
 		}
 
+		// ?filter= là JSON, dùng chung được với các query param riêng lẻ, key trùng thì lấy giá trị trong JSON
+		if raw := c.Query("filter"); raw != "" {
+			if err := filter.ApplyJSON(raw); err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+				return
+			}
+		}
+
 		store := storage.NewSQLStorage(db)
-		business := biz.NewListItemBiz(store)
+		likeStore := likestorage.NewSQLStorage(db)
+		subtaskStore := subtaskstorage.NewSQLStorage(db)
+		userStore := userstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListItemBiz(store, likeStore, userStore, subtaskStore, requester)
 
 		result, err := business.ListItem(c.Request.Context(), &filter, &paging)
+
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result.Items {
+			result.Items[i].Mask()
+		}
+
+		links := common.NewPagingLinks(c.Request, result.Paging)
+		version := common.GetAPIVersion(c)
+
+		// Response chỉ có một phần field là map nên chỉ trả được JSON
+		if fields := filter.FieldList(); len(fields) > 0 {
+			picked := make([]map[string]interface{}, len(result.Items))
+
+			for i := range result.Items {
+				if picked[i], err = common.PickFields(result.Items[i], fields); err != nil {
+					appErr := common.ToAppError(err)
+					c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+					return
+				}
+			}
+
+			c.JSON(http.StatusOK, common.NewSuccessResponse(picked, result.Paging, result.Filter).WithLinks(links).WithAPIVersion(version))
 			return
 		}
 
-		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, filter))
+		common.Render(c, http.StatusOK, common.NewSuccessResponse(result.Items, result.Paging, result.Filter).WithLinks(links).WithAPIVersion(version))
 	}
 }
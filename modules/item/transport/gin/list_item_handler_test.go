@@ -0,0 +1,319 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"sort"
+	"testing"
+	"time"
+)
+
+// listItemsResponse là phần response của GET /items mà test cần đọc
+type listItemsResponse struct {
+	Data   []map[string]interface{} `json:"data"`
+	Paging common.Paging            `json:"paging"`
+	Links  common.PagingLinks       `json:"links"`
+}
+
+func TestListItemFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+
+	if err := db.Create(&model.TodoItem{Title: "buy milk", Description: "2 bottles", UserId: 1, Status: &doing}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantKeys   []string
+	}{
+		{"subset", "?fields=id,title", http.StatusOK, []string{"id", "title"}},
+		{"invalid field", "?fields=id,password", http.StatusBadRequest, nil},
+		{"default full response", "", http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp listItemsResponse
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Data) != 1 {
+				t.Fatalf("body = %s, err = %v", w.Body, err)
+			}
+
+			keys := make([]string, 0, len(resp.Data[0]))
+
+			for key := range resp.Data[0] {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			if tt.wantKeys == nil {
+				if _, ok := resp.Data[0]["description"]; !ok {
+					t.Errorf("keys = %v, want the full item", keys)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
+
+func TestListItemLinks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+
+	for _, title := range []string{"first", "second", "third"} {
+		if err := db.Create(&model.TodoItem{Title: title, UserId: 1, Status: &doing}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+
+	tests := []struct {
+		name     string
+		page     string
+		wantNext bool
+		wantPrev bool
+	}{
+		{"first page", "1", true, false},
+		{"middle page", "2", true, true},
+		{"last page", "3", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?limit=1&page="+tt.page, nil))
+
+			var resp listItemsResponse
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", w.Code, w.Body)
+			}
+
+			if resp.Links.Self != "http://example.com/items?limit=1&page="+tt.page {
+				t.Errorf("self = %q", resp.Links.Self)
+			}
+
+			if (resp.Links.Next != "") != tt.wantNext || (resp.Links.Prev != "") != tt.wantPrev {
+				t.Errorf("links = %+v, want next %v prev %v", resp.Links, tt.wantNext, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func TestListItemPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPage   int
+		wantLimit  int
+	}{
+		{"missing values are defaulted", "", http.StatusOK, 1, 10},
+		{"over the cap is clamped", "?page=2&limit=5000", http.StatusOK, 2, 100},
+		{"negative page", "?page=-1", http.StatusBadRequest, 0, 0},
+		{"negative limit", "?limit=-10", http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp listItemsResponse
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Paging.Page != tt.wantPage || resp.Paging.Limit != tt.wantLimit {
+				t.Errorf("page, limit = %d, %d, want %d, %d", resp.Paging.Page, resp.Paging.Limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestListItemAPIVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+
+	if err := db.Create(&model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/v1/items", common.SetAPIVersion(common.APIVersion1), ListItem(db))
+	r.GET("/v2/items", common.SetAPIVersion(common.APIVersion2), ListItem(db))
+
+	tests := []struct {
+		name        string
+		path        string
+		wantStatus  int
+		wantVersion interface{}
+	}{
+		{"v1", "/v1/items", http.StatusOK, nil},
+		{"v2 is marked", "/v2/items", http.StatusOK, "v2"},
+		{"unknown version", "/v3/items", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp map[string]interface{}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if data, _ := resp["data"].([]interface{}); len(data) != 1 {
+				t.Errorf("data = %v, want the same item under every version", resp["data"])
+			}
+
+			if resp["api_version"] != tt.wantVersion {
+				t.Errorf("api_version = %v, want %v", resp["api_version"], tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestListItemJSONFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	low, high := model.ItemPriorityLow, model.ItemPriorityHigh
+	early := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	items := []model.TodoItem{
+		{Title: "match", Status: &doing, Priority: &high, DueDate: &early, Tags: model.ItemTags{"home"}},
+		{Title: "done", Status: &done, Priority: &high, DueDate: &early},
+		{Title: "low priority", Status: &doing, Priority: &low, DueDate: &early},
+		{Title: "due too late", Status: &doing, Priority: &high, DueDate: &late},
+		{Title: "no due date", Status: &doing, Priority: &high},
+		{Title: "match work", Status: &doing, Priority: &high, DueDate: &early, Tags: model.ItemTags{"work"}},
+	}
+
+	for i := range items {
+		items[i].UserId = 1
+
+		if err := db.Create(&items[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+
+	combined := `{"status":["Doing"],"priority":["High"],"due_before":"2024-12-01T00:00:00Z"}`
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       []string
+	}{
+		{"combined conditions", "?filter=" + url.QueryEscape(combined), http.StatusOK, []string{"match work", "match"}},
+		{"with an individual query param", "?tag=home&filter=" + url.QueryEscape(combined), http.StatusOK, []string{"match"}},
+		{"due_after", "?filter=" + url.QueryEscape(`{"due_after":"2024-12-01T00:00:00Z"}`), http.StatusOK, []string{"due too late"}},
+		{"malformed json", "?filter=" + url.QueryEscape(`{"status":`), http.StatusBadRequest, nil},
+		{"unknown key", "?filter=" + url.QueryEscape(`{"owner_id":2}`), http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp listItemsResponse
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			titles := make([]string, len(resp.Data))
+
+			for i, item := range resp.Data {
+				titles[i] = item["title"].(string)
+			}
+
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("titles = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
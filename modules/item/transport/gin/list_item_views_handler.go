@@ -0,0 +1,107 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+	"strconv"
+)
+
+// ListTodayItems nhận ?timezone= (tên IANA, mặc định UTC) để tính "hôm nay", phân trang bằng ?page= và ?limit=
+func ListTodayItems(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		loc, err := common.LoadTimezone(c.Query("timezone"))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var paging common.Paging
+
+		if err := c.ShouldBind(&paging); err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		if err := paging.Process(); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListItemViewsBiz(store, requester)
+
+		result, err := business.ListToday(c.Request.Context(), loc, &paging)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil).WithLinks(common.NewPagingLinks(c.Request, &paging)))
+	}
+}
+
+// ListUpcomingItems nhận ?days= (mặc định model.DefaultUpcomingDays), ?timezone=, ?page= và ?limit=
+func ListUpcomingItems(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		loc, err := common.LoadTimezone(c.Query("timezone"))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		days := model.DefaultUpcomingDays
+
+		if v := c.Query("days"); v != "" {
+			if days, err = strconv.Atoi(v); err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(model.ErrInvalidUpcomingDays)))
+				return
+			}
+		}
+
+		var paging common.Paging
+
+		if err := c.ShouldBind(&paging); err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		if err := paging.Process(); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListItemViewsBiz(store, requester)
+
+		result, err := business.ListUpcoming(c.Request.Context(), loc, days, &paging)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil).WithLinks(common.NewPagingLinks(c.Request, &paging)))
+	}
+}
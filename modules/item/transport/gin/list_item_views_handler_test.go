@@ -0,0 +1,104 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+	"time"
+)
+
+func TestListItemViews(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing, done := model.ItemStatusDoing, model.ItemStatusDone
+	low, high := model.ItemPriorityLow, model.ItemPriorityHigh
+	noon := common.StartOfDay(time.Now(), time.UTC).Add(12 * time.Hour)
+	day := func(offset int) *time.Time {
+		due := noon.AddDate(0, 0, offset)
+		return &due
+	}
+
+	items := []model.TodoItem{
+		{Title: "yesterday", DueDate: day(-1)},
+		{Title: "today", DueDate: day(0)},
+		{Title: "tomorrow low", DueDate: day(1), Priority: &low},
+		{Title: "tomorrow high", DueDate: day(1), Priority: &high},
+		{Title: "next week", DueDate: day(7)},
+		{Title: "in 60 days", DueDate: day(60)},
+		{Title: "in 120 days", DueDate: day(120)},
+		{Title: "no due date"},
+		{Title: "done today", DueDate: day(0), Status: &done},
+		{Title: "other user today", DueDate: day(0), UserId: 2},
+	}
+
+	for i := range items {
+		if items[i].UserId == 0 {
+			items[i].UserId = 1
+		}
+
+		if items[i].Status == nil {
+			items[i].Status = &doing
+		}
+
+		if err := db.Create(&items[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items/views/today", ListTodayItems(db))
+	r.GET("/items/views/upcoming", ListUpcomingItems(db))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       []string
+	}{
+		{"today includes overdue", "/items/views/today", http.StatusOK, []string{"yesterday", "today"}},
+		{"upcoming defaults to 7 days", "/items/views/upcoming", http.StatusOK, []string{"tomorrow high", "tomorrow low", "next week"}},
+		{"upcoming 1 day", "/items/views/upcoming?days=1", http.StatusOK, []string{"tomorrow high", "tomorrow low"}},
+		{"upcoming is capped", "/items/views/upcoming?days=1000", http.StatusOK, []string{"tomorrow high", "tomorrow low", "next week", "in 60 days"}},
+		{"zero days", "/items/views/upcoming?days=0", http.StatusBadRequest, nil},
+		{"negative days", "/items/views/upcoming?days=-3", http.StatusBadRequest, nil},
+		{"days not a number", "/items/views/upcoming?days=week", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var resp listItemsResponse
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			titles := make([]string, len(resp.Data))
+
+			for i, item := range resp.Data {
+				titles[i] = item["title"].(string)
+			}
+
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("titles = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
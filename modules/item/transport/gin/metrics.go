@@ -0,0 +1,17 @@
+package ginitem
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"social-todo-list/common"
+)
+
+// Dùng counter vì số item đã tạo chỉ tăng, Prometheus tính tốc độ bằng rate().
+// Request tạo lại với cùng Idempotency-Key cũng được đếm vì handler không phân biệt được
+var itemsCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "items_created_total",
+	Help: "Number of todo items created, including batch create and import.",
+})
+
+func init() {
+	common.RegisterMetrics(itemsCreatedTotal)
+}
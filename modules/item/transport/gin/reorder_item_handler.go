@@ -0,0 +1,55 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+func ReorderItem(db *gorm.DB, cache storage.ItemCache) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var data model.TodoItemReorder
+
+		if err := c.ShouldBindJSON(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		var afterId *int
+
+		if data.AfterId != nil && *data.AfterId != "" {
+			v, err := common.ParseLocalId(*data.AfterId, common.DbTypeItem)
+
+			if err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+				return
+			}
+
+			afterId = &v
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewReorderItemBiz(store, requester)
+
+		if err := business.ReorderItem(c.Request.Context(), id, afterId); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
@@ -0,0 +1,50 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+// ReplaceItem (PUT) bắt buộc gửi title, description, status và ghi đè toàn bộ item,
+// khác với UpdateItem (PATCH) chỉ sửa các field được gửi
+func ReplaceItem(
+	db *gorm.DB,
+	hideForbidden bool,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	cache storage.ItemCache,
+) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var data model.TodoItemReplace
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		if err := c.ShouldBind(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewUpdateItemBiz(store, hideForbidden, lengthLimits, sanitizer, requester)
+
+		if err := business.ReplaceItemById(c.Request.Context(), id, &data); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
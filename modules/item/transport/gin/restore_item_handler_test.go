@@ -0,0 +1,82 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"testing"
+)
+
+func TestRestoreItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items", ListItem(db))
+	r.DELETE("/items/:id", DeleteItem(db, false, nil))
+	r.POST("/items/:id/restore", RestoreItem(db, nil))
+
+	listed := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+		var body struct {
+			Data []json.RawMessage `json:"data"`
+		}
+
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("list: %v: %s", err, w.Body)
+		}
+
+		return len(body.Data)
+	}
+
+	// Các bước chạy theo thứ tự trên cùng một item, wantListed là số item list thấy sau bước đó
+	steps := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantListed int
+	}{
+		{"restore a live item", http.MethodPost, itemPath(item.Id) + "/restore", http.StatusNotFound, 1},
+		{"delete", http.MethodDelete, itemPath(item.Id), http.StatusOK, 0},
+		{"restore", http.MethodPost, itemPath(item.Id) + "/restore", http.StatusOK, 1},
+		{"restore again", http.MethodPost, itemPath(item.Id) + "/restore", http.StatusNotFound, 1},
+		{"restore a missing item", http.MethodPost, itemPath(item.Id+1) + "/restore", http.StatusNotFound, 1},
+	}
+
+	for _, step := range steps {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(step.method, step.path, nil))
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+
+		if got := listed(); got != step.wantListed {
+			t.Fatalf("%s: listed %d items, want %d", step.name, got, step.wantListed)
+		}
+	}
+
+	var restored model.TodoItem
+
+	if err := db.First(&restored, item.Id).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.Status == nil || *restored.Status != model.ItemStatusDoing {
+		t.Errorf("status = %s, want Doing", restored.Status.String())
+	}
+}
@@ -0,0 +1,29 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/storage"
+)
+
+// SuggestTitles gợi ý title theo prefix ?q= cho ô tìm kiếm, trả tối đa limit gợi ý
+func SuggestTitles(db *gorm.DB, limit int) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewSuggestTitlesBiz(store, limit, requester)
+
+		data, err := business.SuggestTitles(c.Request.Context(), c.Query("q"))
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data))
+	}
+}
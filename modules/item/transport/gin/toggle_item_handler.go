@@ -0,0 +1,48 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/biz"
+	"social-todo-list/modules/item/model"
+	"social-todo-list/modules/item/storage"
+)
+
+// ToggleItem đảo Doing <-> Done, body {"done": true|false} (không bắt buộc) để đặt thẳng status
+func ToggleItem(db *gorm.DB, hideForbidden bool, cache storage.ItemCache) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var data model.TodoItemToggle
+
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBind(&data); err != nil {
+				appErr := common.ErrInvalidRequest(err)
+				c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+				return
+			}
+		}
+
+		store := storage.NewCachedStorage(storage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		// Chỉ đổi status nên không cần sanitizer và giới hạn độ dài
+		business := biz.NewUpdateItemBiz(store, hideForbidden, model.LengthLimits{}, nil, requester)
+
+		status, err := business.ToggleItemById(c.Request.Context(), id, &data)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(&status))
+	}
+}
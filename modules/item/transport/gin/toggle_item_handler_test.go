@@ -0,0 +1,74 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+func TestToggleItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing, deleted := model.ItemStatusDoing, model.ItemStatusDeleted
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+	removed := model.TodoItem{Title: "removed", UserId: 1, Status: &deleted}
+
+	for _, data := range []*model.TodoItem{&item, &removed} {
+		if err := db.Create(data).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.POST("/items/:id/toggle", ToggleItem(db, false, nil))
+
+	// Các bước chạy theo thứ tự trên cùng một item
+	steps := []struct {
+		name          string
+		id            int
+		body          string
+		wantStatus    int
+		wantItem      model.ItemStatus
+		wantCompleted bool
+	}{
+		{"toggle to done", item.Id, "", http.StatusOK, model.ItemStatusDone, true},
+		{"toggle back to doing", item.Id, "", http.StatusOK, model.ItemStatusDoing, false},
+		{"explicit done", item.Id, `{"done":true}`, http.StatusOK, model.ItemStatusDone, true},
+		{"explicit done again is idempotent", item.Id, `{"done":true}`, http.StatusOK, model.ItemStatusDone, true},
+		{"explicit undone", item.Id, `{"done":false}`, http.StatusOK, model.ItemStatusDoing, false},
+		{"explicit undone again is idempotent", item.Id, `{"done":false}`, http.StatusOK, model.ItemStatusDoing, false},
+		{"deleted item is rejected", removed.Id, "", http.StatusConflict, model.ItemStatusDeleted, false},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(http.MethodPost, itemPath(step.id)+"/toggle", strings.NewReader(step.body))
+		req.Header.Set("Content-Type", common.MIMEJSON)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+
+		if step.wantStatus == http.StatusOK && !strings.Contains(w.Body.String(), `"data":"`+step.wantItem.String()+`"`) {
+			t.Errorf("%s: body = %s, want %v", step.name, w.Body, step.wantItem)
+		}
+
+		var stored model.TodoItem
+
+		if err := db.First(&stored, step.id).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if *stored.Status != step.wantItem || (stored.CompletedAt != nil) != step.wantCompleted {
+			t.Errorf("%s: stored status = %v completed_at = %v, want %v completed %v", step.name, *stored.Status, stored.CompletedAt, step.wantItem, step.wantCompleted)
+		}
+	}
+}
@@ -8,36 +8,62 @@ import (
 	"social-todo-list/modules/item/biz"
 	"social-todo-list/modules/item/model"
 	"social-todo-list/modules/item/storage"
-	"strconv"
 )
 
-func UpdateItem(db *gorm.DB) func(c *gin.Context) {
+// UpdateItem với ?dry_run=true vẫn kiểm tra item tồn tại, thuộc requester và validate như thật,
+// trả về item sau khi sửa nhưng rollback lại nên DB không đổi
+func UpdateItem(
+	db *gorm.DB,
+	hideForbidden bool,
+	lengthLimits model.LengthLimits,
+	sanitizer *common.HTMLSanitizer,
+	cache storage.ItemCache,
+) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var data model.TodoItemUpdate
-		id, err := strconv.Atoi(c.Param("id"))
+		id, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
 
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
 			return
 		}
 
 		if err := c.ShouldBind(&data); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 			return
 		}
 
-		//data.Id = id
-		store := storage.NewSQLStorage(db)
-		business := biz.NewUpdateItemBiz(store)
+		dryRun, err := parseDryRun(c)
 
-		if err := business.UpdateItemById(c.Request.Context(), id, &data); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": err.Error(),
-			})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+
+		update := func(db *gorm.DB) error {
+			business := biz.NewUpdateItemBiz(storage.NewCachedStorage(storage.NewSQLStorage(db), cache), hideForbidden, lengthLimits, sanitizer, requester)
+			return business.UpdateItemById(c.Request.Context(), id, &data)
+		}
+
+		if dryRun {
+			item, err := dryRunItem(c.Request.Context(), db, update, func() int { return id })
+
+			if err != nil {
+				appErr := common.ToAppError(err)
+				c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+				return
+			}
+
+			c.JSON(http.StatusOK, common.SimpleSuccessResponse(item).WithDryRun())
+			return
+		}
+
+		if err := update(db); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
 
 			return
 		}
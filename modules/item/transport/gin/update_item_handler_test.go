@@ -0,0 +1,59 @@
+package ginitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+func TestUpdateItemVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: 1, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+	r.GET("/items/:id", GetItem(db, false, nil))
+	r.PATCH("/items/:id", UpdateItem(db, false, model.LengthLimits{}, nil, nil))
+
+	// Các bước chạy theo thứ tự, hai client cùng đọc version 1 rồi cùng sửa
+	steps := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"get returns version", http.MethodGet, "", http.StatusOK, `"version":1`},
+		{"update with current version", http.MethodPatch, `{"title":"buy bread","version":1}`, http.StatusOK, ""},
+		{"update with stale version", http.MethodPatch, `{"title":"buy eggs","version":1}`, http.StatusConflict, ""},
+		{"get returns next version", http.MethodGet, "", http.StatusOK, `"version":2`},
+		{"stale update was not written", http.MethodGet, "", http.StatusOK, `"title":"buy bread"`},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(step.method, itemPath(item.Id), strings.NewReader(step.body))
+		req.Header.Set("Content-Type", common.MIMEJSON)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, step.wantStatus, w.Body)
+		}
+
+		if !strings.Contains(w.Body.String(), step.wantBody) {
+			t.Fatalf("%s: body = %s, want %s", step.name, w.Body, step.wantBody)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ginitem
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	"strings"
+	"testing"
+)
+
+func TestUpdateItemsStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		status      string
+		wantStatus  int
+		wantUpdated string
+		wantDone    int
+	}{
+		{"owned and unowned ids", `"Done"`, http.StatusOK, `"updated":2`, 2},
+		{"invalid status", `"Finished"`, http.StatusBadRequest, "", 0},
+		{"deleted is not allowed", `"Deleted"`, http.StatusBadRequest, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			doing := model.ItemStatusDoing
+
+			items := []model.TodoItem{
+				{Title: "mine 1", UserId: 1, Status: &doing},
+				{Title: "mine 2", UserId: 1, Status: &doing},
+				{Title: "someone else's", UserId: 2, Status: &doing},
+			}
+
+			if err := db.Create(&items).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) })
+			r.PATCH("/items/status", UpdateItemsStatus(db, nil))
+
+			// Id cuối không tồn tại
+			body := fmt.Sprintf(`{"ids":[%d,%d,%d,%d],"status":%s}`, items[0].Id, items[1].Id, items[2].Id, items[2].Id+100, tt.status)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/items/status", strings.NewReader(body)))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantUpdated) {
+				t.Errorf("body = %s, want %s", w.Body, tt.wantUpdated)
+			}
+
+			var done int64
+
+			if err := db.Model(&model.TodoItem{}).Where("status = ?", model.ItemStatusDone).Count(&done).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if done != int64(tt.wantDone) {
+				t.Errorf("%d items are Done, want %d", done, tt.wantDone)
+			}
+		})
+	}
+}
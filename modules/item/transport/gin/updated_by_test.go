@@ -0,0 +1,138 @@
+package ginitem
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/item/model"
+	usermodel "social-todo-list/modules/user/model"
+	"strings"
+	"testing"
+)
+
+func TestListItemUpdatedBy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db := newTestDB(t)
+	alice, bob := usermodel.User{Email: "alice@example.com", Name: "Alice"}, usermodel.User{Email: "bob@example.com", Name: "Bob"}
+
+	for _, user := range []*usermodel.User{&alice, &bob} {
+		if err := db.Create(user).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Item tạo trước khi có cột updated_by
+	doing := model.ItemStatusDoing
+	item := model.TodoItem{Title: "buy milk", UserId: alice.Id, Status: &doing}
+
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// userId là requester của request tiếp theo
+	userId := alice.Id
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(userId)) })
+	r.GET("/items", ListItem(db))
+	r.GET("/items/:id", GetItem(db, false, nil))
+	r.PATCH("/items/:id", UpdateItem(db, false, model.LengthLimits{}, nil, nil))
+	r.POST("/items/:id/assign", AssignItem(db, nil))
+
+	userUID := func(id int) string { return common.NewUID(uint32(id), common.DbTypeUser, 1).String() }
+
+	// Các bước chạy theo thứ tự, sau mỗi bước owner hiện tại đọc lại item bằng list và get
+	steps := []struct {
+		name          string
+		requester     int
+		path          string
+		body          string
+		wantOwner     int
+		wantUpdatedBy int
+	}{
+		{"legacy item has no updated_by", 0, "", "", alice.Id, 0},
+		{"owner edits", alice.Id, itemPath(item.Id), `{"title":"buy bread"}`, alice.Id, alice.Id},
+		{"owner reassigns to bob", alice.Id, itemPath(item.Id) + "/assign", `{"user_id":"` + userUID(bob.Id) + `"}`, bob.Id, alice.Id},
+		{"new owner edits", bob.Id, itemPath(item.Id), `{"title":"buy eggs"}`, bob.Id, bob.Id},
+	}
+
+	names := map[int]string{alice.Id: alice.Name, bob.Id: bob.Name}
+
+	for _, step := range steps {
+		if step.path != "" {
+			method := http.MethodPatch
+
+			if strings.HasSuffix(step.path, "/assign") {
+				method = http.MethodPost
+			}
+
+			userId = step.requester
+
+			req := httptest.NewRequest(method, step.path, strings.NewReader(step.body))
+			req.Header.Set("Content-Type", common.MIMEJSON)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s: status = %d, body = %s", step.name, w.Code, w.Body)
+			}
+		}
+
+		userId = step.wantOwner
+
+		var list listItemsResponse
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+		if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Data) != 1 {
+			t.Fatalf("%s: list status = %d, body = %s", step.name, w.Code, w.Body)
+		}
+
+		var detail struct {
+			Data map[string]interface{} `json:"data"`
+		}
+
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, itemPath(item.Id), nil))
+
+		if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%s: get status = %d, body = %s", step.name, w.Code, w.Body)
+		}
+
+		wantUpdatedBy := interface{}(nil)
+
+		if step.wantUpdatedBy != 0 {
+			wantUpdatedBy = userUID(step.wantUpdatedBy)
+		}
+
+		if got := detail.Data["updated_by"]; got != wantUpdatedBy {
+			t.Errorf("%s: get updated_by = %v, want %v", step.name, got, wantUpdatedBy)
+		}
+
+		got := list.Data[0]
+		owner, _ := got["owner"].(map[string]interface{})
+
+		if owner == nil || owner["id"] != userUID(step.wantOwner) || owner["name"] != names[step.wantOwner] {
+			t.Errorf("%s: list owner = %v, want %s", step.name, got["owner"], names[step.wantOwner])
+		}
+
+		updatedByUser, _ := got["updated_by_user"].(map[string]interface{})
+
+		if step.wantUpdatedBy == 0 {
+			if got["updated_by"] != nil || updatedByUser != nil {
+				t.Errorf("%s: list updated_by = %v %v, want none", step.name, got["updated_by"], updatedByUser)
+			}
+
+			continue
+		}
+
+		if got["updated_by"] != wantUpdatedBy || updatedByUser == nil || updatedByUser["id"] != wantUpdatedBy || updatedByUser["name"] != names[step.wantUpdatedBy] {
+			t.Errorf("%s: list updated_by = %v %v, want %s", step.name, got["updated_by"], updatedByUser, names[step.wantUpdatedBy])
+		}
+	}
+}
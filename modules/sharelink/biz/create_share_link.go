@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/sharelink/model"
+	"time"
+)
+
+type CreateShareLinkStorage interface {
+	UpsertShareLink(ctx context.Context, data *model.ShareLink) error
+}
+
+type createShareLinkBiz struct {
+	store     CreateShareLinkStorage
+	requester common.Requester
+}
+
+func NewCreateShareLinkBiz(store CreateShareLinkStorage, requester common.Requester) *createShareLinkBiz {
+	return &createShareLinkBiz{store: store, requester: requester}
+}
+
+// CreateShareLink sinh token mới cho requester, link đã chia sẻ trước đó sẽ không dùng được nữa
+func (biz *createShareLinkBiz) CreateShareLink(ctx context.Context) (*model.ShareLink, error) {
+	token, err := model.NewShareToken()
+
+	if err != nil {
+		return nil, common.ErrInternal(err)
+	}
+
+	now := time.Now().UTC()
+	data := model.ShareLink{UserId: biz.requester.GetUserId(), Token: token, CreatedAt: &now}
+
+	if err := biz.store.UpsertShareLink(ctx, &data); err != nil {
+		return nil, common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return &data, nil
+}
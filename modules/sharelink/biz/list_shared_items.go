@@ -0,0 +1,57 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/sharelink/model"
+)
+
+type FindShareLinkStorage interface {
+	FindShareLink(ctx context.Context, cond map[string]interface{}) (*model.ShareLink, error)
+}
+
+// ItemStorage lấy item của chủ link, được implement bởi storage của module item (đã loại item bị xoá)
+type ItemStorage interface {
+	ListItem(
+		ctx context.Context,
+		filter *itemmodel.Filter,
+		paging *common.Paging,
+		moreKeys ...string,
+	) ([]itemmodel.TodoItem, error)
+}
+
+type listSharedItemsBiz struct {
+	store     FindShareLinkStorage
+	itemStore ItemStorage
+}
+
+// NewListSharedItemsBiz không có requester vì link chia sẻ được xem không cần đăng nhập
+func NewListSharedItemsBiz(store FindShareLinkStorage, itemStore ItemStorage) *listSharedItemsBiz {
+	return &listSharedItemsBiz{store: store, itemStore: itemStore}
+}
+
+// ListSharedItems trả về item chưa xoá của chủ token, token không tồn tại hoặc đã thu hồi thì trả về not found
+func (biz *listSharedItemsBiz) ListSharedItems(
+	ctx context.Context,
+	token string,
+	paging *common.Paging,
+) ([]itemmodel.TodoItem, error) {
+	link, err := biz.store.FindShareLink(ctx, map[string]interface{}{"token": token})
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return nil, common.ErrCannotListEntity(itemmodel.EntityName, err)
+	}
+
+	data, err := biz.itemStore.ListItem(ctx, &itemmodel.Filter{UserId: link.UserId}, paging)
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(itemmodel.EntityName, err)
+	}
+
+	return data, nil
+}
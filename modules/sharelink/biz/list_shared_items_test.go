@@ -0,0 +1,115 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/sharelink/model"
+	"testing"
+)
+
+// mockShareLinkStorage giữ token theo user id, upsert thay token cũ của user
+type mockShareLinkStorage struct {
+	tokens map[int]string
+}
+
+func (s *mockShareLinkStorage) UpsertShareLink(ctx context.Context, data *model.ShareLink) error {
+	s.tokens[data.UserId] = data.Token
+
+	return nil
+}
+
+func (s *mockShareLinkStorage) FindShareLink(ctx context.Context, cond map[string]interface{}) (*model.ShareLink, error) {
+	for userId, token := range s.tokens {
+		if token == cond["token"] {
+			return &model.ShareLink{UserId: userId, Token: token}, nil
+		}
+	}
+
+	return nil, common.RecordNotFound
+}
+
+func (s *mockShareLinkStorage) DeleteShareLink(ctx context.Context, userId int) error {
+	delete(s.tokens, userId)
+
+	return nil
+}
+
+// mockItemStorage trả về item của filter.UserId
+type mockItemStorage struct {
+	items map[int][]itemmodel.TodoItem
+}
+
+func (s *mockItemStorage) ListItem(ctx context.Context, filter *itemmodel.Filter, paging *common.Paging, moreKeys ...string) ([]itemmodel.TodoItem, error) {
+	return s.items[filter.UserId], nil
+}
+
+func TestShareLink(t *testing.T) {
+	ctx := context.Background()
+	store := &mockShareLinkStorage{tokens: map[int]string{2: "other-user-token"}}
+	itemStore := &mockItemStorage{items: map[int][]itemmodel.TodoItem{
+		1: {{Title: "mine"}},
+		2: {{Title: "not mine"}},
+	}}
+
+	first, err := NewCreateShareLinkBiz(store, common.NewRequester(1)).CreateShareLink(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := NewCreateShareLinkBiz(store, common.NewRequester(1)).CreateShareLink(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Token == "" || first.Token == rotated.Token {
+		t.Fatalf("tokens = %q then %q, want a new random token each time", first.Token, rotated.Token)
+	}
+
+	tests := []struct {
+		name       string
+		revoke     bool
+		token      string
+		wantStatus int
+		wantTitle  string
+	}{
+		{"fetch by token", false, rotated.Token, 0, "mine"},
+		{"rotated token", false, first.Token, http.StatusNotFound, ""},
+		{"unknown token", false, "nope", http.StatusNotFound, ""},
+		{"revoked token", true, rotated.Token, http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.revoke {
+				if err := NewRevokeShareLinkBiz(store, common.NewRequester(1)).RevokeShareLink(ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			paging := common.Paging{}
+			_ = paging.Process()
+
+			items, err := NewListSharedItemsBiz(store, itemStore).ListSharedItems(ctx, tt.token, &paging)
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(items) != 1 || items[0].Title != tt.wantTitle {
+				t.Errorf("items = %+v, want only %q", items, tt.wantTitle)
+			}
+		})
+	}
+}
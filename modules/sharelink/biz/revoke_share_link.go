@@ -0,0 +1,29 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/sharelink/model"
+)
+
+type RevokeShareLinkStorage interface {
+	DeleteShareLink(ctx context.Context, userId int) error
+}
+
+type revokeShareLinkBiz struct {
+	store     RevokeShareLinkStorage
+	requester common.Requester
+}
+
+func NewRevokeShareLinkBiz(store RevokeShareLinkStorage, requester common.Requester) *revokeShareLinkBiz {
+	return &revokeShareLinkBiz{store: store, requester: requester}
+}
+
+// RevokeShareLink xoá token của requester, chưa có token thì coi như đã thu hồi
+func (biz *revokeShareLinkBiz) RevokeShareLink(ctx context.Context) error {
+	if err := biz.store.DeleteShareLink(ctx, biz.requester.GetUserId()); err != nil {
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	return nil
+}
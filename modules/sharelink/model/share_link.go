@@ -0,0 +1,33 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+)
+
+const (
+	EntityName = "ShareLink"
+	// 24 byte ngẫu nhiên, encode base64 URL được token 32 ký tự
+	shareTokenBytes = 24
+)
+
+// ShareLink là link xem (chỉ đọc) danh sách item của user, mỗi user có tối đa một token
+type ShareLink struct {
+	UserId    int        `json:"-" gorm:"column:user_id;primaryKey;autoIncrement:false;"`
+	Token     string     `json:"token" gorm:"column:token;size:64;uniqueIndex;not null;"`
+	CreatedAt *time.Time `json:"created_at" gorm:"column:created_at;"`
+}
+
+func (ShareLink) TableName() string { return "share_links" }
+
+// NewShareToken sinh token ngẫu nhiên, không đoán được từ user id
+func NewShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
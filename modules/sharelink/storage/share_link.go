@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	"social-todo-list/modules/sharelink/model"
+)
+
+// UpsertShareLink tạo token mới cho user, user đã có token thì token cũ bị thay thế (link cũ hết hiệu lực)
+func (s *sqlStore) UpsertShareLink(ctx context.Context, data *model.ShareLink) error {
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "created_at"}),
+	}).Create(data).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (s *sqlStore) FindShareLink(ctx context.Context, cond map[string]interface{}) (*model.ShareLink, error) {
+	var data model.ShareLink
+
+	if err := s.db.WithContext(ctx).Where(cond).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.RecordNotFound
+		}
+
+		return nil, common.ErrDB(err)
+	}
+
+	return &data, nil
+}
+
+func (s *sqlStore) DeleteShareLink(ctx context.Context, userId int) error {
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Delete(&model.ShareLink{}).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package ginsharelink
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/sharelink/biz"
+	"social-todo-list/modules/sharelink/storage"
+)
+
+func CreateShareLink(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewCreateShareLinkBiz(store, requester)
+
+		data, err := business.CreateShareLink(c.Request.Context())
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data))
+	}
+}
@@ -0,0 +1,49 @@
+package ginsharelink
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	itemstorage "social-todo-list/modules/item/storage"
+	"social-todo-list/modules/sharelink/biz"
+	"social-todo-list/modules/sharelink/storage"
+)
+
+// ListSharedItems là route public (không cần đăng nhập), chỉ đọc
+func ListSharedItems(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var paging common.Paging
+
+		if err := c.ShouldBind(&paging); err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		_, paging.CursorMode = c.GetQuery("cursor")
+
+		if err := paging.Process(); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		business := biz.NewListSharedItemsBiz(store, itemStore)
+
+		result, err := business.ListSharedItems(c.Request.Context(), c.Param("token"), &paging)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.NewSuccessResponse(result, paging, nil).WithLinks(common.NewPagingLinks(c.Request, &paging)))
+	}
+}
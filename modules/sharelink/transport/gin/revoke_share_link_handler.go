@@ -0,0 +1,26 @@
+package ginsharelink
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/sharelink/biz"
+	"social-todo-list/modules/sharelink/storage"
+)
+
+func RevokeShareLink(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewRevokeShareLinkBiz(store, requester)
+
+		if err := business.RevokeShareLink(c.Request.Context()); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
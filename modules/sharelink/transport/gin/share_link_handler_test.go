@@ -0,0 +1,106 @@
+package ginsharelink
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/sharelink/model"
+	"strings"
+	"testing"
+)
+
+func TestShareLinkRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&itemmodel.TodoItem{}, &model.ShareLink{}); err != nil {
+		t.Fatal(err)
+	}
+
+	doing, deleted := itemmodel.ItemStatusDoing, itemmodel.ItemStatusDeleted
+
+	for _, item := range []itemmodel.TodoItem{
+		{Title: "shared", UserId: 1, Status: &doing},
+		{Title: "deleted", UserId: 1, Status: &deleted},
+		{Title: "other user", UserId: 2, Status: &doing},
+	} {
+		if err := db.Create(&item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := gin.New()
+	auth := func(c *gin.Context) { c.Set(common.CurrentUser, common.NewRequester(1)) }
+	r.GET("/share/:token", ListSharedItems(db))
+	r.POST("/v1/share", auth, CreateShareLink(db))
+	r.DELETE("/v1/share", auth, RevokeShareLink(db))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+
+		return w
+	}
+
+	w := serve(http.MethodPost, "/v1/share")
+
+	var created struct {
+		Data model.ShareLink `json:"data"`
+	}
+
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusOK || created.Data.Token == "" {
+		t.Fatalf("create: status = %d, body = %s", w.Code, w.Body)
+	}
+
+	tests := []struct {
+		name       string
+		revoke     bool
+		wantStatus int
+	}{
+		{"fetch by token", false, http.StatusOK},
+		{"revoked token", true, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.revoke {
+				if w := serve(http.MethodDelete, "/v1/share"); w.Code != http.StatusOK {
+					t.Fatalf("revoke: status = %d, body = %s", w.Code, w.Body)
+				}
+			}
+
+			w := serve(http.MethodGet, "/share/"+created.Data.Token)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			body := w.Body.String()
+
+			if !strings.Contains(body, `"title":"shared"`) || strings.Contains(body, `"title":"deleted"`) || strings.Contains(body, "other user") {
+				t.Errorf("body = %s, want only the owner's non-deleted item", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/subtask/model"
+)
+
+type CreateSubtaskStorage interface {
+	CreateSubtask(ctx context.Context, data *model.SubtaskCreation) error
+}
+
+type createSubtaskBiz struct {
+	store     CreateSubtaskStorage
+	itemStore ItemStorage
+	requester common.Requester
+}
+
+func NewCreateSubtaskBiz(store CreateSubtaskStorage, itemStore ItemStorage, requester common.Requester) *createSubtaskBiz {
+	return &createSubtaskBiz{store: store, itemStore: itemStore, requester: requester}
+}
+
+func (biz *createSubtaskBiz) CreateSubtask(ctx context.Context, itemId int, data *model.SubtaskCreation) error {
+	if err := data.Validate(); err != nil {
+		return common.ErrInvalidRequest(err)
+	}
+
+	if _, err := findParentItem(ctx, biz.itemStore, itemId, biz.requester.GetUserId()); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	data.ItemId = itemId
+
+	if err := biz.store.CreateSubtask(ctx, data); err != nil {
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
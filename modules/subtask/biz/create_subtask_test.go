@@ -0,0 +1,126 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/subtask/model"
+	"testing"
+)
+
+// mockItemStorage tìm item theo id và user_id giống storage của module item
+type mockItemStorage struct {
+	items map[int]*itemmodel.TodoItem
+}
+
+func (s *mockItemStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error) {
+	item, ok := s.items[cond["id"].(int)]
+
+	if !ok || item.UserId != cond["user_id"].(int) {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
+
+// mockSubtaskStorage giữ subtask theo id, đếm tổng số và số đã xong từ chính map đó
+type mockSubtaskStorage struct {
+	subtasks map[int]*model.Subtask
+	created  []*model.SubtaskCreation
+}
+
+func (s *mockSubtaskStorage) CreateSubtask(ctx context.Context, data *model.SubtaskCreation) error {
+	s.created = append(s.created, data)
+
+	return nil
+}
+
+func (s *mockSubtaskStorage) GetSubtask(ctx context.Context, cond map[string]interface{}) (*model.Subtask, error) {
+	subtask, ok := s.subtasks[cond["id"].(int)]
+
+	if !ok || subtask.ItemId != cond["item_id"].(int) {
+		return nil, common.RecordNotFound
+	}
+
+	data := *subtask
+
+	return &data, nil
+}
+
+func (s *mockSubtaskStorage) UpdateSubtaskDone(ctx context.Context, id int, done bool) error {
+	s.subtasks[id].Done = done
+
+	return nil
+}
+
+func (s *mockSubtaskStorage) CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error) {
+	total, done := map[int]int{}, map[int]int{}
+
+	for _, subtask := range s.subtasks {
+		total[subtask.ItemId]++
+
+		if subtask.Done {
+			done[subtask.ItemId]++
+		}
+	}
+
+	return total, done, nil
+}
+
+func newTestParentItem(id, userId int, status itemmodel.ItemStatus) *itemmodel.TodoItem {
+	item := &itemmodel.TodoItem{UserId: userId, Status: &status}
+	item.Id = id
+
+	return item
+}
+
+func TestCreateSubtask(t *testing.T) {
+	itemStore := &mockItemStorage{items: map[int]*itemmodel.TodoItem{
+		1: newTestParentItem(1, 1, itemmodel.ItemStatusDoing),
+		2: newTestParentItem(2, 1, itemmodel.ItemStatusDeleted),
+		3: newTestParentItem(3, 2, itemmodel.ItemStatusDoing),
+	}}
+
+	tests := []struct {
+		name       string
+		itemId     int
+		title      string
+		wantStatus int
+	}{
+		{"created", 1, "  buy milk ", 0},
+		{"blank title", 1, "   ", http.StatusBadRequest},
+		{"parent not found", 9, "buy milk", http.StatusNotFound},
+		{"parent deleted", 2, "buy milk", http.StatusNotFound},
+		{"parent of another user", 3, "buy milk", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockSubtaskStorage{}
+			data := &model.SubtaskCreation{Title: tt.title}
+
+			err := NewCreateSubtaskBiz(store, itemStore, common.NewRequester(1)).CreateSubtask(context.Background(), tt.itemId, data)
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				if len(store.created) != 0 {
+					t.Errorf("created = %d, want 0", len(store.created))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(store.created) != 1 || data.ItemId != tt.itemId || data.Title != "buy milk" {
+				t.Errorf("created = %+v, want one subtask of item %d titled %q", data, tt.itemId, "buy milk")
+			}
+		})
+	}
+}
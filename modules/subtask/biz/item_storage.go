@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+)
+
+// ItemStorage dùng để kiểm tra item cha có tồn tại, được implement bởi storage của module item
+type ItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error)
+}
+
+// findParentItem lấy item cha mà requester được phép xem, item đã xoá coi như không tồn tại
+func findParentItem(ctx context.Context, store ItemStorage, itemId, userId int) (*itemmodel.TodoItem, error) {
+	item, err := store.GetItem(ctx, map[string]interface{}{"id": itemId, "user_id": userId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status != nil && *item.Status == itemmodel.ItemStatusDeleted {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
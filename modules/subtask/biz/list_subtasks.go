@@ -0,0 +1,40 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/subtask/model"
+)
+
+type ListSubtasksStorage interface {
+	ListSubtasks(ctx context.Context, cond map[string]interface{}) ([]model.Subtask, error)
+}
+
+type listSubtasksBiz struct {
+	store     ListSubtasksStorage
+	itemStore ItemStorage
+	requester common.Requester
+}
+
+func NewListSubtasksBiz(store ListSubtasksStorage, itemStore ItemStorage, requester common.Requester) *listSubtasksBiz {
+	return &listSubtasksBiz{store: store, itemStore: itemStore, requester: requester}
+}
+
+func (biz *listSubtasksBiz) ListSubtasks(ctx context.Context, itemId int) ([]model.Subtask, error) {
+	if _, err := findParentItem(ctx, biz.itemStore, itemId, biz.requester.GetUserId()); err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	data, err := biz.store.ListSubtasks(ctx, map[string]interface{}{"item_id": itemId})
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	return data, nil
+}
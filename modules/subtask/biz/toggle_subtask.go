@@ -0,0 +1,85 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/subtask/model"
+)
+
+type ToggleSubtaskStorage interface {
+	GetSubtask(ctx context.Context, cond map[string]interface{}) (*model.Subtask, error)
+	UpdateSubtaskDone(ctx context.Context, id int, done bool) error
+	CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error)
+}
+
+// ItemUpdater cập nhật item cha, được implement bởi biz update item của module item
+type ItemUpdater interface {
+	UpdateItemById(ctx context.Context, id int, dataUpdate *itemmodel.TodoItemUpdate) error
+}
+
+type toggleSubtaskBiz struct {
+	store       ToggleSubtaskStorage
+	itemStore   ItemStorage
+	itemUpdater ItemUpdater
+	requester   common.Requester
+}
+
+func NewToggleSubtaskBiz(
+	store ToggleSubtaskStorage,
+	itemStore ItemStorage,
+	itemUpdater ItemUpdater,
+	requester common.Requester,
+) *toggleSubtaskBiz {
+	return &toggleSubtaskBiz{store: store, itemStore: itemStore, itemUpdater: itemUpdater, requester: requester}
+}
+
+// ToggleSubtask đảo trạng thái done của subtask. autoComplete = true thì khi mọi subtask đã xong
+// item cha được chuyển sang Done
+func (biz *toggleSubtaskBiz) ToggleSubtask(ctx context.Context, itemId, subtaskId int, autoComplete bool) (*model.Subtask, error) {
+	item, err := findParentItem(ctx, biz.itemStore, itemId, biz.requester.GetUserId())
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		return nil, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	data, err := biz.store.GetSubtask(ctx, map[string]interface{}{"id": subtaskId, "item_id": itemId})
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return nil, common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return nil, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	data.Done = !data.Done
+
+	if err := biz.store.UpdateSubtaskDone(ctx, data.Id, data.Done); err != nil {
+		return nil, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if !autoComplete || !data.Done || (item.Status != nil && *item.Status == itemmodel.ItemStatusDone) {
+		return data, nil
+	}
+
+	total, done, err := biz.store.CountSubtasks(ctx, []int{itemId})
+
+	if err != nil {
+		return nil, common.ErrCannotUpdateEntity(model.EntityName, err)
+	}
+
+	if total[itemId] > 0 && done[itemId] == total[itemId] {
+		doneStatus := itemmodel.ItemStatusDone
+
+		if err := biz.itemUpdater.UpdateItemById(ctx, itemId, &itemmodel.TodoItemUpdate{Status: &doneStatus}); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
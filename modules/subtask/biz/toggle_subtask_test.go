@@ -0,0 +1,87 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/subtask/model"
+	"testing"
+)
+
+// mockItemUpdater ghi lại các lần cập nhật item cha
+type mockItemUpdater struct {
+	updates []*itemmodel.TodoItemUpdate
+}
+
+func (u *mockItemUpdater) UpdateItemById(ctx context.Context, id int, dataUpdate *itemmodel.TodoItemUpdate) error {
+	u.updates = append(u.updates, dataUpdate)
+
+	return nil
+}
+
+func newTestSubtask(id, itemId int, done bool) *model.Subtask {
+	subtask := &model.Subtask{ItemId: itemId, Done: done}
+	subtask.Id = id
+
+	return subtask
+}
+
+func TestToggleSubtask(t *testing.T) {
+	tests := []struct {
+		name         string
+		parent       itemmodel.ItemStatus
+		otherDone    bool
+		initialDone  bool
+		subtaskId    int
+		autoComplete bool
+		wantStatus   int
+		wantDone     bool
+		wantComplete bool
+	}{
+		{"mark done", itemmodel.ItemStatusDoing, false, false, 1, false, 0, true, false},
+		{"mark undone", itemmodel.ItemStatusDoing, true, true, 1, true, 0, false, false},
+		{"last done without auto complete", itemmodel.ItemStatusDoing, true, false, 1, false, 0, true, false},
+		{"last done with auto complete", itemmodel.ItemStatusDoing, true, false, 1, true, 0, true, true},
+		{"not all done with auto complete", itemmodel.ItemStatusDoing, false, false, 1, true, 0, true, false},
+		{"parent already done", itemmodel.ItemStatusDone, true, false, 1, true, 0, true, false},
+		{"subtask not found", itemmodel.ItemStatusDoing, false, false, 9, false, http.StatusNotFound, false, false},
+		{"parent deleted", itemmodel.ItemStatusDeleted, false, false, 1, false, http.StatusNotFound, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			itemStore := &mockItemStorage{items: map[int]*itemmodel.TodoItem{1: newTestParentItem(1, 1, tt.parent)}}
+			store := &mockSubtaskStorage{subtasks: map[int]*model.Subtask{
+				1: newTestSubtask(1, 1, tt.initialDone),
+				2: newTestSubtask(2, 1, tt.otherDone),
+			}}
+			updater := &mockItemUpdater{}
+
+			business := NewToggleSubtaskBiz(store, itemStore, updater, common.NewRequester(1))
+			data, err := business.ToggleSubtask(context.Background(), 1, tt.subtaskId, tt.autoComplete)
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if data.Done != tt.wantDone || store.subtasks[1].Done != tt.wantDone {
+				t.Errorf("done = %v, stored %v, want %v", data.Done, store.subtasks[1].Done, tt.wantDone)
+			}
+
+			completed := len(updater.updates) == 1 && *updater.updates[0].Status == itemmodel.ItemStatusDone
+
+			if completed != tt.wantComplete || len(updater.updates) > 1 {
+				t.Errorf("parent updates = %d, want completed %v", len(updater.updates), tt.wantComplete)
+			}
+		})
+	}
+}
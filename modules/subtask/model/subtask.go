@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"strings"
+	"time"
+)
+
+const (
+	EntityName = "Subtask"
+)
+
+var (
+	ErrTitleIsBlank = errors.New("title cannot be blank")
+)
+
+type Subtask struct {
+	common.SQLModel
+	ItemId int    `json:"-" gorm:"column:item_id;index;"`
+	Title  string `json:"title" gorm:"column:title;size:255;"`
+	Done   bool   `json:"done" gorm:"column:done;not null;default:false;"`
+	// Position là thứ tự hiển thị trong checklist, subtask mới luôn ở cuối
+	Position int `json:"position" gorm:"column:position;not null;default:0;"`
+}
+
+func (Subtask) TableName() string { return "subtasks" }
+
+func (s *Subtask) Mask() {
+	s.SQLModel.Mask(common.DbTypeSubtask)
+}
+
+type SubtaskCreation struct {
+	Id        int        `json:"-" gorm:"column:id;"`
+	ItemId    int        `json:"-" gorm:"column:item_id;"`
+	Title     string     `json:"title" gorm:"column:title;"`
+	Position  int        `json:"-" gorm:"column:position;"`
+	CreatedAt *time.Time `json:"-" gorm:"column:created_at;"`
+	UpdatedAt *time.Time `json:"-" gorm:"column:updated_at;"`
+}
+
+func (SubtaskCreation) TableName() string { return Subtask{}.TableName() }
+
+func (s *SubtaskCreation) Validate() error {
+	s.Title = strings.TrimSpace(s.Title)
+
+	if s.Title == "" {
+		return ErrTitleIsBlank
+	}
+
+	return nil
+}
+
+func (s *SubtaskCreation) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	s.CreatedAt = &now
+	s.UpdatedAt = &now
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+)
+
+// CountSubtasks đếm tổng số subtask và số subtask đã xong theo item_id
+func (s *sqlStore) CountSubtasks(ctx context.Context, itemIds []int) (map[int]int, map[int]int, error) {
+	type sqlData struct {
+		ItemId int `gorm:"column:item_id;"`
+		Total  int `gorm:"column:total;"`
+		Done   int `gorm:"column:done;"`
+	}
+
+	var rows []sqlData
+
+	if err := s.db.WithContext(ctx).Table(model.Subtask{}.TableName()).
+		Select("item_id, COUNT(*) AS total, SUM(CASE WHEN done THEN 1 ELSE 0 END) AS done").
+		Where("item_id IN ?", itemIds).
+		Group("item_id").
+		Find(&rows).Error; err != nil {
+		return nil, nil, common.ErrDB(err)
+	}
+
+	total := make(map[int]int, len(rows))
+	done := make(map[int]int, len(rows))
+
+	for _, row := range rows {
+		total[row.ItemId] = row.Total
+		done[row.ItemId] = row.Done
+	}
+
+	return total, done, nil
+}
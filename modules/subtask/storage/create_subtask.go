@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+)
+
+// CreateSubtask đặt subtask mới ở cuối checklist của item
+func (s *sqlStore) CreateSubtask(ctx context.Context, data *model.SubtaskCreation) error {
+	var maxPosition int
+
+	if err := s.db.WithContext(ctx).Table(model.Subtask{}.TableName()).
+		Where("item_id = ?", data.ItemId).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&maxPosition).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	data.Position = maxPosition + 1
+
+	if err := s.db.WithContext(ctx).Create(data).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
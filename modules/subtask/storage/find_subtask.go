@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+)
+
+func (s *sqlStore) GetSubtask(ctx context.Context, cond map[string]interface{}) (*model.Subtask, error) {
+	var data model.Subtask
+
+	if err := s.db.WithContext(ctx).Where(cond).First(&data).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.RecordNotFound
+		}
+
+		return nil, common.ErrDB(err)
+	}
+
+	return &data, nil
+}
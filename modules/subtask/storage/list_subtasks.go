@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+)
+
+func (s *sqlStore) ListSubtasks(ctx context.Context, cond map[string]interface{}) ([]model.Subtask, error) {
+	var result []model.Subtask
+
+	if err := s.db.WithContext(ctx).Where(cond).Order("position asc, id asc").Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
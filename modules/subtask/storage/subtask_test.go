@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+	"testing"
+)
+
+// newTestStore tạo store trên sqlite in-memory, một connection để mọi query thấy cùng một DB
+func newTestStore(t *testing.T) *sqlStore {
+	t.Helper()
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&model.Subtask{}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewSQLStorage(db)
+}
+
+func TestSubtaskStorage(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	for _, s := range []struct {
+		itemId int
+		title  string
+	}{{1, "first"}, {1, "second"}, {1, "third"}, {2, "other item"}} {
+		if err := store.CreateSubtask(ctx, &model.SubtaskCreation{ItemId: s.itemId, Title: s.title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subtasks, err := store.ListSubtasks(ctx, map[string]interface{}{"item_id": 1})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"first", "second", "third"} {
+		if subtasks[i].Title != want || subtasks[i].Position != i+1 {
+			t.Errorf("subtask %d = %q at %d, want %q at %d", i, subtasks[i].Title, subtasks[i].Position, want, i+1)
+		}
+	}
+
+	if err := store.UpdateSubtaskDone(ctx, subtasks[0].Id, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		itemId    int
+		wantTotal int
+		wantDone  int
+	}{
+		{"partly done", 1, 3, 1},
+		{"none done", 2, 1, 0},
+		{"no subtasks", 3, 0, 0},
+	}
+
+	total, done, err := store.CountSubtasks(ctx, []int{1, 2, 3})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if total[tt.itemId] != tt.wantTotal || done[tt.itemId] != tt.wantDone {
+				t.Errorf("count = %d/%d, want %d/%d", done[tt.itemId], total[tt.itemId], tt.wantDone, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/subtask/model"
+	"time"
+)
+
+func (s *sqlStore) UpdateSubtaskDone(ctx context.Context, id int, done bool) error {
+	if err := s.db.WithContext(ctx).Table(model.Subtask{}.TableName()).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"done":       done,
+			"updated_at": time.Now().UTC(),
+		}).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
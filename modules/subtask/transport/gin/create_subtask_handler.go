@@ -0,0 +1,44 @@
+package ginsubtask
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	itemstorage "social-todo-list/modules/item/storage"
+	"social-todo-list/modules/subtask/biz"
+	"social-todo-list/modules/subtask/model"
+	"social-todo-list/modules/subtask/storage"
+)
+
+func CreateSubtask(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		var data model.SubtaskCreation
+
+		if err := c.ShouldBind(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewCreateSubtaskBiz(store, itemStore, requester)
+
+		if err := business.CreateSubtask(c.Request.Context(), itemId, &data); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(common.NewUID(uint32(data.Id), common.DbTypeSubtask, 1)))
+	}
+}
@@ -0,0 +1,41 @@
+package ginsubtask
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	itemstorage "social-todo-list/modules/item/storage"
+	"social-todo-list/modules/subtask/biz"
+	"social-todo-list/modules/subtask/storage"
+)
+
+func ListSubtasks(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListSubtasksBiz(store, itemStore, requester)
+
+		result, err := business.ListSubtasks(c.Request.Context(), itemId)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,61 @@
+package ginsubtask
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	itembiz "social-todo-list/modules/item/biz"
+	itemmodel "social-todo-list/modules/item/model"
+	itemstorage "social-todo-list/modules/item/storage"
+	"social-todo-list/modules/subtask/biz"
+	"social-todo-list/modules/subtask/storage"
+	"strconv"
+)
+
+// ToggleSubtask nhận ?auto_complete=true để tự chuyển item sang Done khi mọi subtask đã xong
+func ToggleSubtask(db *gorm.DB, cache itemstorage.ItemCache) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		subtaskId, err := common.ParseLocalId(c.Param("subtask_id"), common.DbTypeSubtask)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		autoComplete := false
+
+		if v := c.Query("auto_complete"); v != "" {
+			if autoComplete, err = strconv.ParseBool(v); err != nil {
+				c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+				return
+			}
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewCachedStorage(itemstorage.NewSQLStorage(db), cache)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		// Biz toggle đã kiểm tra item thuộc requester trước khi complete item, chỉ đổi status nên không cần sanitizer và giới hạn độ dài
+		itemUpdater := itembiz.NewUpdateItemBiz(itemStore, true, itemmodel.LengthLimits{}, nil, requester)
+		business := biz.NewToggleSubtaskBiz(store, itemStore, itemUpdater, requester)
+
+		data, err := business.ToggleSubtask(c.Request.Context(), itemId, subtaskId, autoComplete)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		data.Mask()
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(data))
+	}
+}
@@ -0,0 +1,57 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"social-todo-list/common"
+	"social-todo-list/modules/upload/model"
+	"time"
+)
+
+type uploadImageBiz struct {
+	uploader common.Uploader
+	maxSize  int64
+}
+
+func NewUploadImageBiz(uploader common.Uploader, maxSize int64) *uploadImageBiz {
+	return &uploadImageBiz{uploader: uploader, maxSize: maxSize}
+}
+
+// UploadImage kiểm tra data là ảnh (png, jpeg, gif) không vượt quá maxSize rồi lưu qua uploader
+func (biz *uploadImageBiz) UploadImage(ctx context.Context, data []byte, folder string) (*common.Image, error) {
+	if len(data) == 0 {
+		return nil, common.ErrInvalidRequest(model.ErrFileIsEmpty)
+	}
+
+	if int64(len(data)) > biz.maxSize {
+		return nil, common.NewErrorResponse(model.ErrFileTooLarge, fmt.Sprintf("file must not exceed %d bytes", biz.maxSize), "ErrFileTooLarge")
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, common.NewErrorResponse(model.ErrFileIsNotImage, model.ErrFileIsNotImage.Error(), "ErrFileIsNotImage")
+	}
+
+	// Đặt tên file theo thời gian, không dùng tên client gửi lên
+	ext := "." + format
+	dst := fmt.Sprintf("%s/%d%s", folder, time.Now().UTC().UnixNano(), ext)
+
+	url, err := biz.uploader.SaveFile(ctx, data, dst)
+
+	if err != nil {
+		return nil, common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return &common.Image{
+		Url:       url,
+		Width:     cfg.Width,
+		Height:    cfg.Height,
+		Extension: ext,
+	}, nil
+}
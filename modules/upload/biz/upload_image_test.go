@@ -0,0 +1,93 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/upload/model"
+	"strings"
+	"testing"
+)
+
+// mockUploader ghi lại dst của lần lưu cuối, err khác nil thì lưu lỗi
+type mockUploader struct {
+	dst string
+	err error
+}
+
+func (u *mockUploader) SaveFile(ctx context.Context, data []byte, dst string) (string, error) {
+	if u.err != nil {
+		return "", u.err
+	}
+
+	u.dst = dst
+
+	return "/static/" + dst, nil
+}
+
+func newTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestUploadImage(t *testing.T) {
+	validPNG := newTestPNG(t, 4, 3)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		maxSize    int64
+		uploadErr  error
+		wantStatus int
+		wantErr    error
+	}{
+		{"valid png", validPNG, 1 << 20, nil, 0, nil},
+		{"oversized", validPNG, int64(len(validPNG) - 1), nil, http.StatusBadRequest, model.ErrFileTooLarge},
+		{"not an image", []byte("hello, not an image"), 1 << 20, nil, http.StatusBadRequest, model.ErrFileIsNotImage},
+		{"empty", nil, 1 << 20, nil, http.StatusBadRequest, model.ErrFileIsEmpty},
+		{"uploader error", validPNG, 1 << 20, errors.New("disk full"), http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploader := &mockUploader{err: tt.uploadErr}
+
+			img, err := NewUploadImageBiz(uploader, tt.maxSize).UploadImage(context.Background(), tt.data, "images")
+
+			if tt.wantStatus != 0 {
+				if common.ToAppError(err).StatusCode != tt.wantStatus {
+					t.Fatalf("err = %v, want status %d", err, tt.wantStatus)
+				}
+
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if img.Width != 4 || img.Height != 3 || img.Extension != ".png" {
+				t.Errorf("image = %+v, want 4x3 .png", img)
+			}
+
+			if !strings.HasPrefix(uploader.dst, "images/") || img.Url != "/static/"+uploader.dst {
+				t.Errorf("dst = %q, url = %q", uploader.dst, img.Url)
+			}
+		})
+	}
+}
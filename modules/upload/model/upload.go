@@ -0,0 +1,13 @@
+package model
+
+import "errors"
+
+const (
+	EntityName = "Upload"
+)
+
+var (
+	ErrFileTooLarge   = errors.New("file too large")
+	ErrFileIsEmpty    = errors.New("file is empty")
+	ErrFileIsNotImage = errors.New("file is not an image")
+)
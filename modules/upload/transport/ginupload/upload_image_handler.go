@@ -0,0 +1,51 @@
+package ginupload
+
+import (
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/upload/biz"
+)
+
+func UploadImage(uploader common.Uploader, maxSize int64) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		// Body vượt BodyLimit thì ErrInvalidRequest trả 413
+		fileHeader, err := c.FormFile("file")
+
+		if err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		file, err := fileHeader.Open()
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		defer file.Close()
+
+		// Đọc thêm 1 byte để biz phát hiện được file vượt quá giới hạn
+		data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		business := biz.NewUploadImageBiz(uploader, maxSize)
+
+		img, err := business.UploadImage(c.Request.Context(), data, "images")
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(img))
+	}
+}
@@ -0,0 +1,132 @@
+package ginupload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"strings"
+	"testing"
+)
+
+// mockUploader ghi lại dst của lần lưu cuối
+type mockUploader struct {
+	dst string
+}
+
+func (u *mockUploader) SaveFile(ctx context.Context, data []byte, dst string) (string, error) {
+	u.dst = dst
+
+	return "/static/" + dst, nil
+}
+
+func newTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// newMultipartBody tạo body multipart có một file ở field, data nil thì không có file
+func newMultipartBody(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	if data != nil {
+		part, err := writer.CreateFormFile(field, "image.png")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := part.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return &body, writer.FormDataContentType()
+}
+
+func TestUploadImage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validPNG := newTestPNG(t, 4, 3)
+
+	tests := []struct {
+		name       string
+		field      string
+		data       []byte
+		maxSize    int64
+		bodyLimit  int64
+		wantStatus int
+		wantKey    string
+	}{
+		{"valid png", "file", validPNG, 1 << 20, 2 << 20, http.StatusOK, ""},
+		{"wrong field", "image", validPNG, 1 << 20, 2 << 20, http.StatusBadRequest, "ErrInvalidRequest"},
+		{"no file", "file", nil, 1 << 20, 2 << 20, http.StatusBadRequest, "ErrInvalidRequest"},
+		{"not an image", "file", []byte("hello, not an image"), 1 << 20, 2 << 20, http.StatusBadRequest, "ErrFileIsNotImage"},
+		{"over max size", "file", validPNG, int64(len(validPNG) - 1), 2 << 20, http.StatusBadRequest, "ErrFileTooLarge"},
+		{"over body limit", "file", validPNG, 1 << 20, 64, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploader := &mockUploader{}
+
+			r := gin.New()
+			r.POST("/upload", common.BodyLimit(tt.bodyLimit), UploadImage(uploader, tt.maxSize))
+
+			body, contentType := newMultipartBody(t, tt.field, tt.data)
+			req := httptest.NewRequest(http.MethodPost, "/upload", body)
+			req.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			if tt.wantKey != "" && !strings.Contains(w.Body.String(), `"error_key":"`+tt.wantKey+`"`) {
+				t.Errorf("body = %s, want error_key %s", w.Body.String(), tt.wantKey)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				if uploader.dst != "" {
+					t.Errorf("saved %q, want nothing saved", uploader.dst)
+				}
+
+				return
+			}
+
+			var resp struct {
+				Data common.Image `json:"data"`
+			}
+
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Data.Width != 4 || resp.Data.Height != 3 || !strings.HasPrefix(uploader.dst, "images/") || resp.Data.Url != "/static/"+uploader.dst {
+				t.Errorf("image = %+v, dst = %q", resp.Data, uploader.dst)
+			}
+		})
+	}
+}
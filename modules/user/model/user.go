@@ -0,0 +1,35 @@
+package model
+
+import "social-todo-list/common"
+
+const (
+	EntityName = "User"
+)
+
+type User struct {
+	common.SQLModel
+	Email  string        `json:"email" gorm:"column:email;size:255;uniqueIndex;"`
+	Name   string        `json:"name" gorm:"column:name;size:100;"`
+	Avatar *common.Image `json:"avatar,omitempty" gorm:"column:avatar;"`
+}
+
+func (User) TableName() string { return "users" }
+
+func (u *User) Mask() {
+	u.SQLModel.Mask(common.DbTypeUser)
+}
+
+// UserInfo là thông tin rút gọn của user, dùng để gắn vào entity khác (ví dụ owner của item)
+type UserInfo struct {
+	Id     int           `json:"-" xml:"-" gorm:"column:id;"`
+	FakeId *common.UID   `json:"id" xml:"id" gorm:"-"`
+	Name   string        `json:"name" xml:"name" gorm:"column:name;"`
+	Avatar *common.Image `json:"avatar,omitempty" xml:"avatar,omitempty" gorm:"column:avatar;"`
+}
+
+func (UserInfo) TableName() string { return User{}.TableName() }
+
+func (u *UserInfo) Mask() {
+	uid := common.NewUID(uint32(u.Id), common.DbTypeUser, 1)
+	u.FakeId = &uid
+}
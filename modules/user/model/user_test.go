@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"social-todo-list/common"
+	"testing"
+)
+
+func TestUserInfoMask(t *testing.T) {
+	tests := []struct {
+		name string
+		info UserInfo
+		want map[string]interface{}
+	}{
+		{"name only", UserInfo{Id: 7, Name: "Alice"}, map[string]interface{}{
+			"id":   common.NewUID(7, common.DbTypeUser, 1).String(),
+			"name": "Alice",
+		}},
+		{"with avatar", UserInfo{Id: 8, Name: "Bob", Avatar: &common.Image{Url: "https://cdn.example.com/bob.png"}}, map[string]interface{}{
+			"id":     common.NewUID(8, common.DbTypeUser, 1).String(),
+			"name":   "Bob",
+			"avatar": map[string]interface{}{"url": "https://cdn.example.com/bob.png", "width": float64(0), "height": float64(0)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.info
+			info.Mask()
+
+			body, err := json.Marshal(&info)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]interface{}
+
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %s, want %v", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/user/model"
+)
+
+// GetUsers lấy thông tin rút gọn của nhiều user trong một query
+func (s *sqlStore) GetUsers(ctx context.Context, ids []int) ([]model.UserInfo, error) {
+	var result []model.UserInfo
+
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
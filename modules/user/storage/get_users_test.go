@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"social-todo-list/common"
+	"social-todo-list/modules/user/model"
+	"sort"
+	"testing"
+)
+
+// newTestStore tạo store trên sqlite in-memory, một connection để mọi query thấy cùng một DB
+func newTestStore(t *testing.T) *sqlStore {
+	t.Helper()
+
+	db, err := common.NewDatabase(common.Config{DBDriver: "sqlite", DBDsn: "file::memory:"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(&model.User{}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewSQLStorage(db)
+}
+
+func TestGetUsers(t *testing.T) {
+	store := newTestStore(t)
+	names := map[int]string{}
+
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		user := model.User{Email: name + "@example.com", Name: name}
+
+		if err := store.db.Create(&user).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		names[user.Id] = name
+	}
+
+	tests := []struct {
+		name string
+		ids  []int
+		want []string
+	}{
+		{"some users", []int{1, 3}, []string{"Alice", "Carol"}},
+		{"missing ids are skipped", []int{2, 99}, []string{"Bob"}},
+		{"duplicate ids", []int{1, 1}, []string{"Alice"}},
+		{"no ids", []int{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := store.GetUsers(context.Background(), tt.ids)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, len(users))
+
+			for i, user := range users {
+				if names[user.Id] != user.Name {
+					t.Errorf("user %d name = %q, want %q", user.Id, user.Name, names[user.Id])
+				}
+
+				got[i] = user.Name
+			}
+
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("users = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+)
+
+var ErrItemNotShared = errors.New("item is not shared by its owner")
+
+// ItemStorage dùng để kiểm tra item được like có tồn tại, được implement bởi storage của module item
+type ItemStorage interface {
+	GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error)
+}
+
+// ShareLinkStorage dùng để biết chủ item có đang chia sẻ danh sách item không, được implement bởi storage của module sharelink
+type ShareLinkStorage interface {
+	FindShareLink(ctx context.Context, cond map[string]interface{}) (*sharelinkmodel.ShareLink, error)
+}
+
+// findItem lấy item theo id, item đã xoá coi như không tồn tại
+func findItem(ctx context.Context, store ItemStorage, itemId int) (*itemmodel.TodoItem, error) {
+	item, err := store.GetItem(ctx, map[string]interface{}{"id": itemId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status != nil && *item.Status == itemmodel.ItemStatusDeleted {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
+
+// checkCanViewItem cho phép chủ item, hoặc user khác nếu chủ item đang có link chia sẻ (item công khai)
+func checkCanViewItem(ctx context.Context, shareStore ShareLinkStorage, item *itemmodel.TodoItem, userId int) error {
+	if item.UserId == userId {
+		return nil
+	}
+
+	if _, err := shareStore.FindShareLink(ctx, map[string]interface{}{"user_id": item.UserId}); err != nil {
+		if err == common.RecordNotFound {
+			return ErrItemNotShared
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/userlikeitem/model"
+)
+
+type UserLikeItemStorage interface {
+	CreateLike(ctx context.Context, data *model.Like) error
+}
+
+type userLikeItemBiz struct {
+	store      UserLikeItemStorage
+	itemStore  ItemStorage
+	shareStore ShareLinkStorage
+	requester  common.Requester
+}
+
+func NewUserLikeItemBiz(
+	store UserLikeItemStorage,
+	itemStore ItemStorage,
+	shareStore ShareLinkStorage,
+	requester common.Requester,
+) *userLikeItemBiz {
+	return &userLikeItemBiz{store: store, itemStore: itemStore, shareStore: shareStore, requester: requester}
+}
+
+func (biz *userLikeItemBiz) LikeItem(ctx context.Context, itemId int) error {
+	item, err := findItem(ctx, biz.itemStore, itemId)
+
+	if err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	if err := checkCanViewItem(ctx, biz.shareStore, item, biz.requester.GetUserId()); err != nil {
+		if err == ErrItemNotShared {
+			return common.ErrForbidden(itemmodel.EntityName, err)
+		}
+
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	data := model.Like{ItemId: itemId, UserId: biz.requester.GetUserId()}
+
+	if err := biz.store.CreateLike(ctx, &data); err != nil {
+		return common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package biz
+
+import (
+	"context"
+	"net/http"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	sharelinkmodel "social-todo-list/modules/sharelink/model"
+	"social-todo-list/modules/userlikeitem/model"
+	"testing"
+)
+
+// mockItemStorage tìm item theo id giống storage của module item (không lọc theo user_id)
+type mockItemStorage struct {
+	items map[int]*itemmodel.TodoItem
+}
+
+func (s *mockItemStorage) GetItem(ctx context.Context, cond map[string]interface{}) (*itemmodel.TodoItem, error) {
+	if _, ok := cond["user_id"]; ok {
+		panic("liked item must be looked up by id only")
+	}
+
+	item, ok := s.items[cond["id"].(int)]
+
+	if !ok {
+		return nil, common.RecordNotFound
+	}
+
+	return item, nil
+}
+
+// mockShareLinkStorage coi sharedBy là danh sách user đang có link chia sẻ
+type mockShareLinkStorage struct {
+	sharedBy map[int]bool
+}
+
+func (s *mockShareLinkStorage) FindShareLink(ctx context.Context, cond map[string]interface{}) (*sharelinkmodel.ShareLink, error) {
+	userId := cond["user_id"].(int)
+
+	if !s.sharedBy[userId] {
+		return nil, common.RecordNotFound
+	}
+
+	return &sharelinkmodel.ShareLink{UserId: userId}, nil
+}
+
+type mockLikeStorage struct {
+	likes []model.Like
+}
+
+func (s *mockLikeStorage) CreateLike(ctx context.Context, data *model.Like) error {
+	s.likes = append(s.likes, *data)
+	return nil
+}
+
+func newTestItem(id, userId int, status itemmodel.ItemStatus) *itemmodel.TodoItem {
+	item := &itemmodel.TodoItem{UserId: userId, Status: &status}
+	item.Id = id
+
+	return item
+}
+
+func TestLikeItem(t *testing.T) {
+	const userA, userB = 1, 2
+
+	itemStore := &mockItemStorage{items: map[int]*itemmodel.TodoItem{
+		10: newTestItem(10, userA, itemmodel.ItemStatusDoing),
+		11: newTestItem(11, userA, itemmodel.ItemStatusDeleted),
+	}}
+
+	cases := []struct {
+		name       string
+		userId     int
+		itemId     int
+		shared     bool
+		wantStatus int
+	}{
+		{name: "owner likes own item", userId: userA, itemId: 10},
+		{name: "user B likes user A's shared item", userId: userB, itemId: 10, shared: true},
+		{name: "user B likes user A's private item", userId: userB, itemId: 10, wantStatus: http.StatusForbidden},
+		{name: "deleted item", userId: userB, itemId: 11, shared: true, wantStatus: http.StatusNotFound},
+		{name: "missing item", userId: userB, itemId: 12, shared: true, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockLikeStorage{}
+			shareStore := &mockShareLinkStorage{sharedBy: map[int]bool{userA: tc.shared}}
+			business := NewUserLikeItemBiz(store, itemStore, shareStore, common.NewRequester(tc.userId))
+
+			err := business.LikeItem(context.Background(), tc.itemId)
+
+			if tc.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				want := model.Like{ItemId: tc.itemId, UserId: tc.userId}
+
+				if len(store.likes) != 1 || store.likes[0] != want {
+					t.Fatalf("likes = %+v, want [%+v]", store.likes, want)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected status %d, got nil error", tc.wantStatus)
+			}
+
+			if got := common.ToAppError(err).StatusCode; got != tc.wantStatus {
+				t.Fatalf("status = %d, want %d", got, tc.wantStatus)
+			}
+
+			if len(store.likes) != 0 {
+				t.Fatal("like must not be created")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	itemmodel "social-todo-list/modules/item/model"
+	"social-todo-list/modules/userlikeitem/model"
+)
+
+type UserUnlikeItemStorage interface {
+	DeleteLike(ctx context.Context, itemId, userId int) error
+}
+
+type userUnlikeItemBiz struct {
+	store     UserUnlikeItemStorage
+	itemStore ItemStorage
+	requester common.Requester
+}
+
+func NewUserUnlikeItemBiz(store UserUnlikeItemStorage, itemStore ItemStorage, requester common.Requester) *userUnlikeItemBiz {
+	return &userUnlikeItemBiz{store: store, itemStore: itemStore, requester: requester}
+}
+
+// UnlikeItem chưa like mà unlike thì vẫn thành công, không kiểm tra link chia sẻ để user luôn bỏ được like của mình
+func (biz *userUnlikeItemBiz) UnlikeItem(ctx context.Context, itemId int) error {
+	if _, err := findItem(ctx, biz.itemStore, itemId); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(itemmodel.EntityName, err)
+		}
+
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	if err := biz.store.DeleteLike(ctx, itemId, biz.requester.GetUserId()); err != nil {
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	EntityName = "UserLikeItem"
+)
+
+// Like mỗi user chỉ like một item một lần, khoá chính là (item_id, user_id)
+type Like struct {
+	ItemId    int        `json:"item_id" gorm:"column:item_id;primaryKey;autoIncrement:false;"`
+	UserId    int        `json:"user_id" gorm:"column:user_id;primaryKey;autoIncrement:false;"`
+	CreatedAt *time.Time `json:"created_at" gorm:"column:created_at;"`
+}
+
+func (Like) TableName() string { return "item_likes" }
+
+func (l *Like) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	l.CreatedAt = &now
+
+	return nil
+}
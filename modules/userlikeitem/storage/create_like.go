@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"gorm.io/gorm/clause"
+	"social-todo-list/common"
+	"social-todo-list/modules/userlikeitem/model"
+)
+
+// CreateLike bỏ qua nếu user đã like item này rồi, nên gọi nhiều lần vẫn chỉ có một dòng
+func (s *sqlStore) CreateLike(ctx context.Context, data *model.Like) error {
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(data).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/userlikeitem/model"
+)
+
+func (s *sqlStore) DeleteLike(ctx context.Context, itemId, userId int) error {
+	if err := s.db.WithContext(ctx).Table(model.Like{}.TableName()).
+		Where("item_id = ? AND user_id = ?", itemId, userId).
+		Delete(nil).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/userlikeitem/model"
+)
+
+// GetItemLikes trả về số like theo item_id, item không có like thì không có trong map
+func (s *sqlStore) GetItemLikes(ctx context.Context, ids []int) (map[int]int, error) {
+	result := make(map[int]int)
+
+	type sqlData struct {
+		ItemId int `gorm:"column:item_id;"`
+		Count  int `gorm:"column:count;"`
+	}
+
+	var listLike []sqlData
+
+	if err := s.db.WithContext(ctx).Table(model.Like{}.TableName()).
+		Select("item_id, COUNT(item_id) AS count").
+		Where("item_id IN ?", ids).
+		Group("item_id").
+		Find(&listLike).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	for _, item := range listLike {
+		result[item.ItemId] = item.Count
+	}
+
+	return result, nil
+}
+
+// GetLikedItemIds trả về các item trong ids mà userId đã like
+func (s *sqlStore) GetLikedItemIds(ctx context.Context, userId int, ids []int) (map[int]bool, error) {
+	result := make(map[int]bool)
+
+	var itemIds []int
+
+	if err := s.db.WithContext(ctx).Table(model.Like{}.TableName()).
+		Where("user_id = ? AND item_id IN ?", userId, ids).
+		Pluck("item_id", &itemIds).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	for _, id := range itemIds {
+		result[id] = true
+	}
+
+	return result, nil
+}
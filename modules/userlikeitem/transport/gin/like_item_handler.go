@@ -0,0 +1,37 @@
+package ginuserlikeitem
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	itemstorage "social-todo-list/modules/item/storage"
+	sharelinkstorage "social-todo-list/modules/sharelink/storage"
+	"social-todo-list/modules/userlikeitem/biz"
+	"social-todo-list/modules/userlikeitem/storage"
+)
+
+func LikeItem(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		itemId, err := common.ParseLocalId(c.Param("id"), common.DbTypeItem)
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.Localize(c, common.ErrInvalidRequest(err)))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		itemStore := itemstorage.NewSQLStorage(db)
+		shareStore := sharelinkstorage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewUserLikeItemBiz(store, itemStore, shareStore, requester)
+
+		if err := business.LikeItem(c.Request.Context(), itemId); err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(true))
+	}
+}
@@ -0,0 +1,49 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+type CreateWebhookStorage interface {
+	CreateWebhook(ctx context.Context, data *model.WebhookCreation) error
+}
+
+type createWebhookBiz struct {
+	store        CreateWebhookStorage
+	allowPrivate bool
+	requester    common.Requester
+}
+
+// NewCreateWebhookBiz với allowPrivate = false thì URL trỏ tới localhost/IP nội bộ bị từ chối (chống SSRF)
+func NewCreateWebhookBiz(store CreateWebhookStorage, allowPrivate bool, requester common.Requester) *createWebhookBiz {
+	return &createWebhookBiz{store: store, allowPrivate: allowPrivate, requester: requester}
+}
+
+// CreateWebhook lưu webhook kèm secret mới sinh, secret chỉ được trả về ở đây
+func (biz *createWebhookBiz) CreateWebhook(ctx context.Context, data *model.WebhookCreation) (*model.Webhook, error) {
+	if err := data.Validate(biz.allowPrivate); err != nil {
+		return nil, common.ErrInvalidRequest(err)
+	}
+
+	secret, err := model.NewWebhookSecret()
+
+	if err != nil {
+		return nil, common.ErrInternal(err)
+	}
+
+	data.UserId = biz.requester.GetUserId()
+	data.Secret = secret
+
+	if err := biz.store.CreateWebhook(ctx, data); err != nil {
+		return nil, common.ErrCannotCreateEntity(model.EntityName, err)
+	}
+
+	return &model.Webhook{
+		SQLModel: common.SQLModel{Id: data.Id, CreatedAt: data.CreatedAt, UpdatedAt: data.UpdatedAt},
+		UserId:   data.UserId,
+		Url:      data.Url,
+		Secret:   data.Secret,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+	"testing"
+)
+
+type mockCreateWebhookStorage struct {
+	created []*model.WebhookCreation
+}
+
+func (s *mockCreateWebhookStorage) CreateWebhook(ctx context.Context, data *model.WebhookCreation) error {
+	data.Id = len(s.created) + 1
+	s.created = append(s.created, data)
+
+	return nil
+}
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      error
+	}{
+		{"public url", " https://example.com/hook ", false, nil},
+		{"private url rejected", "http://192.168.0.10/hook", false, model.ErrURLNotAllowed},
+		{"private url allowed", "http://192.168.0.10/hook", true, nil},
+		{"invalid url", "example.com/hook", false, model.ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockCreateWebhookStorage{}
+			business := NewCreateWebhookBiz(store, tt.allowPrivate, common.NewRequester(3))
+
+			webhook, err := business.CreateWebhook(context.Background(), &model.WebhookCreation{Url: tt.url})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				if common.ToAppError(err).StatusCode != http.StatusBadRequest || len(store.created) != 0 {
+					t.Fatalf("rejected url: status %d, stored %d", common.ToAppError(err).StatusCode, len(store.created))
+				}
+
+				return
+			}
+
+			if store.created[0].UserId != 3 || webhook.UserId != 3 {
+				t.Errorf("webhook not owned by the requester")
+			}
+
+			if len(webhook.Secret) != 64 || webhook.Secret != store.created[0].Secret {
+				t.Errorf("secret = %q, want the stored 64 hex characters", webhook.Secret)
+			}
+		})
+	}
+}
+
+func TestCreateWebhookDefaultConfigRejectsPrivateURLs(t *testing.T) {
+	for _, env := range []string{"development", "production"} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv("DB_DSN", "dsn")
+			t.Setenv("JWT_SECRET", "secret")
+			t.Setenv("APP_ENV", env)
+			t.Setenv("WEBHOOK_ALLOW_PRIVATE_URLS", "")
+
+			cfg, err := common.LoadConfig()
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, url := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest/meta-data"} {
+				store := &mockCreateWebhookStorage{}
+				business := NewCreateWebhookBiz(store, cfg.WebhookAllowPrivateURLs, common.NewRequester(3))
+
+				if _, err := business.CreateWebhook(context.Background(), &model.WebhookCreation{Url: url}); !errors.Is(err, model.ErrURLNotAllowed) || len(store.created) != 0 {
+					t.Errorf("%s: err = %v, stored %d, want %v", url, err, len(store.created), model.ErrURLNotAllowed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+type DeleteWebhookStorage interface {
+	DeleteWebhook(ctx context.Context, userId, id int) error
+}
+
+type deleteWebhookBiz struct {
+	store     DeleteWebhookStorage
+	requester common.Requester
+}
+
+func NewDeleteWebhookBiz(store DeleteWebhookStorage, requester common.Requester) *deleteWebhookBiz {
+	return &deleteWebhookBiz{store: store, requester: requester}
+}
+
+// DeleteWebhook xoá webhook của requester, webhook của user khác được coi như không tồn tại
+func (biz *deleteWebhookBiz) DeleteWebhook(ctx context.Context, id int) error {
+	if err := biz.store.DeleteWebhook(ctx, biz.requester.GetUserId(), id); err != nil {
+		if err == common.RecordNotFound {
+			return common.ErrEntityNotFound(model.EntityName, err)
+		}
+
+		return common.ErrCannotDeleteEntity(model.EntityName, err)
+	}
+
+	return nil
+}
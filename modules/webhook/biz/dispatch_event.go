@@ -0,0 +1,136 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+	"syscall"
+	"time"
+)
+
+// Mỗi lần Dispatch chỉ gửi một lần, không chờ retry tại chỗ: lỗi trả về để outbox hẹn gửi lại (attempts/next_attempt_at)
+const deliveryTimeout = 10 * time.Second
+
+// webhookPayload là body JSON gửi tới webhook
+type webhookPayload struct {
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+type webhookDispatcher struct {
+	store  ListWebhooksStorage
+	client *http.Client
+}
+
+// NewWebhookDispatcher là EventDispatcher gửi event tới mọi webhook của user.
+// allowPrivate = false thì không kết nối tới IP nội bộ kể cả khi hostname resolve ra IP đó
+func NewWebhookDispatcher(store ListWebhooksStorage, allowPrivate bool) common.EventDispatcher {
+	return &webhookDispatcher{store: store, client: newWebhookClient(allowPrivate)}
+}
+
+// Dispatch gửi event tới từng webhook của user, mỗi webhook một lần. Có webhook lỗi (non-2xx, lỗi mạng)
+// thì trả về lỗi để relay hẹn gửi lại cả event sau, webhook đã nhận có thể nhận trùng.
+// Webhook trỏ tới IP nội bộ không bao giờ gửi được nên chỉ ghi log, không làm event bị gửi lại
+func (d *webhookDispatcher) Dispatch(ctx context.Context, userId int, event common.Event) error {
+	webhooks, err := d.store.ListWebhooks(ctx, userId)
+
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookPayload{Type: event.Type, Data: event.Data, Timestamp: time.Now().UTC()})
+
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, webhook := range webhooks {
+		err := d.post(ctx, webhook, body)
+
+		if errors.Is(err, model.ErrURLNotAllowed) {
+			slog.WarnContext(ctx, "webhook url not allowed", slog.Int("webhook_id", webhook.Id), slog.Any("error", err))
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %d: %w", webhook.Id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *webhookDispatcher) post(ctx context.Context, webhook model.Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(model.SignatureHeader, model.Sign(webhook.Secret, body))
+
+	resp, err := d.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// newWebhookClient không đi theo redirect và không dùng proxy của môi trường (cả hai có thể vòng vào mạng nội bộ),
+// allowPrivate = false thì IP sau khi resolve được kiểm tra ngay lúc kết nối
+func newWebhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: deliveryTimeout}
+
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !model.IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", model.ErrURLNotAllowed, host)
+			}
+
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   deliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+	"sync"
+	"testing"
+)
+
+type mockWebhookStorage struct {
+	webhooks []model.Webhook
+}
+
+func (s *mockWebhookStorage) ListWebhooks(ctx context.Context, userId int) ([]model.Webhook, error) {
+	return s.webhooks, nil
+}
+
+// webhookServer đếm số request nhận được và kiểm tra chữ ký bằng secret
+type webhookServer struct {
+	mu       sync.Mutex
+	hits     int
+	badSigs  int
+	lastType string
+}
+
+func (s *webhookServer) handler(secret string, status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var payload webhookPayload
+		_ = json.Unmarshal(body, &payload)
+
+		s.mu.Lock()
+		s.hits++
+		s.lastType = payload.Type
+
+		if r.Header.Get(model.SignatureHeader) != model.Sign(secret, body) {
+			s.badSigs++
+		}
+
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+	}
+}
+
+func TestWebhookDispatcherDispatch(t *testing.T) {
+	event := common.Event{Type: "item.created", Data: map[string]interface{}{"title": "a"}}
+
+	tests := []struct {
+		name     string
+		statuses []int
+		wantErr  bool
+	}{
+		{"all succeed", []int{http.StatusOK, http.StatusNoContent}, false},
+		{"one fails, single attempt each", []int{http.StatusOK, http.StatusInternalServerError}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var webhooks []model.Webhook
+			servers := make([]*webhookServer, len(tt.statuses))
+
+			for i, status := range tt.statuses {
+				servers[i] = &webhookServer{}
+				secret := "secret-" + string(rune('a'+i))
+				srv := httptest.NewServer(servers[i].handler(secret, status))
+				t.Cleanup(srv.Close)
+
+				webhook := model.Webhook{Url: srv.URL, Secret: secret}
+				webhook.Id = i + 1
+				webhooks = append(webhooks, webhook)
+			}
+
+			// httptest chạy trên 127.0.0.1 nên phải cho phép IP nội bộ
+			dispatcher := NewWebhookDispatcher(&mockWebhookStorage{webhooks: webhooks}, true)
+
+			err := dispatcher.Dispatch(context.Background(), 1, event)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+
+			for i, srv := range servers {
+				if srv.hits != 1 {
+					t.Errorf("webhook %d got %d requests, want exactly 1 (retries belong to the outbox)", i+1, srv.hits)
+				}
+
+				if srv.badSigs != 0 {
+					t.Errorf("webhook %d got a body with a wrong signature", i+1)
+				}
+
+				if srv.lastType != event.Type {
+					t.Errorf("webhook %d got type %q, want %q", i+1, srv.lastType, event.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookClientDialGuard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name         string
+		allowPrivate bool
+		wantErr      error
+	}{
+		{"private blocked at dial", false, model.ErrURLNotAllowed},
+		{"private allowed", true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &webhookDispatcher{client: newWebhookClient(tt.allowPrivate)}
+
+			err := d.post(context.Background(), model.Webhook{Url: srv.URL, Secret: "s"}, []byte("{}"))
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookDispatcherSkipsBlockedURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	store := &mockWebhookStorage{webhooks: []model.Webhook{{Url: srv.URL, Secret: "s"}}}
+	dispatcher := NewWebhookDispatcher(store, false)
+
+	// URL trỏ vào IP nội bộ không bao giờ gửi được, không trả lỗi để outbox khỏi gửi lại mãi
+	if err := dispatcher.Dispatch(context.Background(), 1, common.Event{Type: "item.created"}); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
+
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	target := &webhookServer{}
+	targetSrv := httptest.NewServer(target.handler("s", http.StatusOK))
+	t.Cleanup(targetSrv.Close)
+
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"found", http.StatusFound},
+		{"temporary redirect", http.StatusTemporaryRedirect},
+		{"permanent redirect", http.StatusPermanentRedirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, targetSrv.URL, tt.status)
+			}))
+			t.Cleanup(redirect.Close)
+
+			d := &webhookDispatcher{client: newWebhookClient(true)}
+
+			if err := d.post(context.Background(), model.Webhook{Url: redirect.URL, Secret: "s"}, []byte("{}")); err == nil {
+				t.Fatal("redirect response was treated as delivered")
+			}
+
+			if target.hits != 0 {
+				t.Fatalf("redirect target got %d requests, want 0", target.hits)
+			}
+		})
+	}
+}
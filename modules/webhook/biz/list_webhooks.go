@@ -0,0 +1,35 @@
+package biz
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+type ListWebhooksStorage interface {
+	ListWebhooks(ctx context.Context, userId int) ([]model.Webhook, error)
+}
+
+type listWebhooksBiz struct {
+	store     ListWebhooksStorage
+	requester common.Requester
+}
+
+func NewListWebhooksBiz(store ListWebhooksStorage, requester common.Requester) *listWebhooksBiz {
+	return &listWebhooksBiz{store: store, requester: requester}
+}
+
+// ListWebhooks trả về webhook của requester, không kèm secret
+func (biz *listWebhooksBiz) ListWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	data, err := biz.store.ListWebhooks(ctx, biz.requester.GetUserId())
+
+	if err != nil {
+		return nil, common.ErrCannotListEntity(model.EntityName, err)
+	}
+
+	for i := range data {
+		data[i].Secret = ""
+	}
+
+	return data, nil
+}
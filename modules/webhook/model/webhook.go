@@ -0,0 +1,80 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"gorm.io/gorm"
+	"social-todo-list/common"
+	"strings"
+	"time"
+)
+
+const (
+	EntityName = "Webhook"
+	// 32 byte ngẫu nhiên, encode hex được secret 64 ký tự
+	webhookSecretBytes = 32
+	// SignatureHeader chứa "sha256=" + HMAC-SHA256 (hex) của body tính bằng secret của webhook
+	SignatureHeader = "X-Signature"
+)
+
+// Webhook là URL nhận event thay đổi item của user, mỗi lần gửi body được ký bằng Secret
+type Webhook struct {
+	common.SQLModel
+	UserId int    `json:"-" gorm:"column:user_id;index;"`
+	Url    string `json:"url" gorm:"column:url;size:2048;not null;"`
+	// Secret chỉ được trả về lúc tạo, sau đó không xem lại được
+	Secret string `json:"secret,omitempty" gorm:"column:secret;size:64;not null;"`
+}
+
+func (Webhook) TableName() string { return "webhooks" }
+
+func (w *Webhook) Mask() {
+	w.SQLModel.Mask(common.DbTypeWebhook)
+}
+
+type WebhookCreation struct {
+	Id        int        `json:"-" gorm:"column:id;"`
+	UserId    int        `json:"-" gorm:"column:user_id;"`
+	Url       string     `json:"url" gorm:"column:url;"`
+	Secret    string     `json:"-" gorm:"column:secret;"`
+	CreatedAt *time.Time `json:"-" gorm:"column:created_at;"`
+	UpdatedAt *time.Time `json:"-" gorm:"column:updated_at;"`
+}
+
+func (WebhookCreation) TableName() string { return Webhook{}.TableName() }
+
+// Validate kiểm tra URL, allowPrivate = false thì không được trỏ tới localhost/IP nội bộ
+func (w *WebhookCreation) Validate(allowPrivate bool) error {
+	w.Url = strings.TrimSpace(w.Url)
+
+	return ValidateURL(w.Url, allowPrivate)
+}
+
+func (w *WebhookCreation) BeforeCreate(tx *gorm.DB) error {
+	now := time.Now().UTC()
+	w.CreatedAt = &now
+	w.UpdatedAt = &now
+
+	return nil
+}
+
+// NewWebhookSecret sinh secret ngẫu nhiên để ký body gửi tới webhook
+func NewWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Sign trả về giá trị của header X-Signature cho body, bên nhận tính lại bằng secret để kiểm tra
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
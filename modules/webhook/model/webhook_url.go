@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+const maxURLLength = 2048
+
+var (
+	ErrInvalidURL    = errors.New("url must be an absolute http or https url")
+	ErrURLNotAllowed = errors.New("url must not point to localhost or a private network")
+)
+
+// Các dải IP không phải internet công cộng ngoài những dải net.IP đã nhận ra (loopback, private, link-local...)
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// ValidateURL kiểm tra URL của webhook. allowPrivate = false thì host không được là localhost hay IP nội bộ,
+// hostname được resolve lúc gửi nên IP thật còn được kiểm tra lại khi kết nối (IsPublicIP)
+func ValidateURL(raw string, allowPrivate bool) error {
+	if raw == "" || len(raw) > maxURLLength {
+		return ErrInvalidURL
+	}
+
+	u, err := url.Parse(raw)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+
+	if allowPrivate {
+		return nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") ||
+		strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return fmt.Errorf("%w: %s", ErrURLNotAllowed, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrURLNotAllowed, host)
+	}
+
+	return nil
+}
+
+// IsPublicIP cho biết ip có thuộc internet công cộng không (không phải loopback, private, link-local, multicast...)
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	addr, ok := netip.AddrFromSlice(ip)
+
+	if !ok {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
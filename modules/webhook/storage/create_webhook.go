@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+func (s *sqlStore) CreateWebhook(ctx context.Context, data *model.WebhookCreation) error {
+	if err := s.db.WithContext(ctx).Create(data).Error; err != nil {
+		return common.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+// DeleteWebhook xoá webhook id của user, không có dòng nào bị xoá thì trả về RecordNotFound
+func (s *sqlStore) DeleteWebhook(ctx context.Context, userId, id int) error {
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userId).
+		Delete(&model.Webhook{})
+
+	if result.Error != nil {
+		return common.ErrDB(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.RecordNotFound
+	}
+
+	return nil
+}
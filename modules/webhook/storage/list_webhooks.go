@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/model"
+)
+
+// ListWebhooks trả về mọi webhook của user (kèm secret để ký), cũ nhất trước
+func (s *sqlStore) ListWebhooks(ctx context.Context, userId int) ([]model.Webhook, error) {
+	var result []model.Webhook
+
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Order("id asc").
+		Find(&result).Error; err != nil {
+		return nil, common.ErrDB(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package ginwebhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/biz"
+	"social-todo-list/modules/webhook/model"
+	"social-todo-list/modules/webhook/storage"
+)
+
+func CreateWebhook(db *gorm.DB, allowPrivate bool) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var data model.WebhookCreation
+
+		if err := c.ShouldBind(&data); err != nil {
+			appErr := common.ErrInvalidRequest(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewCreateWebhookBiz(store, allowPrivate, requester)
+
+		result, err := business.CreateWebhook(c.Request.Context(), &data)
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		result.Mask()
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
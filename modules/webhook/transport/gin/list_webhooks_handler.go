@@ -0,0 +1,32 @@
+package ginwebhook
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"social-todo-list/common"
+	"social-todo-list/modules/webhook/biz"
+	"social-todo-list/modules/webhook/storage"
+)
+
+func ListWebhooks(db *gorm.DB) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		store := storage.NewSQLStorage(db)
+		requester := c.MustGet(common.CurrentUser).(common.Requester)
+		business := biz.NewListWebhooksBiz(store, requester)
+
+		result, err := business.ListWebhooks(c.Request.Context())
+
+		if err != nil {
+			appErr := common.ToAppError(err)
+			c.JSON(appErr.StatusCode, common.Localize(c, appErr))
+			return
+		}
+
+		for i := range result {
+			result[i].Mask()
+		}
+
+		c.JSON(http.StatusOK, common.SimpleSuccessResponse(result))
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/brianvoe/gofakeit/v7"
+	"gorm.io/gorm"
+	"log"
+	"social-todo-list/modules/item/model"
+	itemstorage "social-todo-list/modules/item/storage"
+	usermodel "social-todo-list/modules/user/model"
+	"time"
+)
+
+const (
+	seedDemoEmail    = "demo@example.com"
+	seedDemoName     = "Demo User"
+	defaultSeedCount = 50
+)
+
+var (
+	seedTitleVerbs = []string{"Review", "Write", "Fix", "Plan", "Call", "Prepare", "Buy", "Update", "Clean", "Schedule"}
+	seedTags       = []string{"work", "home", "urgent", "shopping", "health", "learning"}
+)
+
+// runSeed tạo user demo và count item giả cho user đó, tất cả nằm trong một transaction.
+// User demo đã có item thì bỏ qua, trừ khi chạy với --force
+func runSeed(db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	count := fs.Int("count", defaultSeedCount, "number of fake items to insert")
+	force := fs.Bool("force", false, "insert items even if the demo user already has some")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *count <= 0 {
+		return errors.New("seed: count must be greater than 0")
+	}
+
+	ctx := context.Background()
+
+	return itemstorage.NewSQLStorage(db).WithTransactionDB(ctx, func(tx *gorm.DB) error {
+		user := usermodel.User{Email: seedDemoEmail, Name: seedDemoName}
+
+		if err := tx.Where("email = ?", user.Email).FirstOrCreate(&user).Error; err != nil {
+			return fmt.Errorf("seed: cannot create demo user: %w", err)
+		}
+
+		if !*force {
+			var existing int64
+
+			if err := tx.Model(&model.TodoItem{}).Where("user_id = ?", user.Id).Count(&existing).Error; err != nil {
+				return fmt.Errorf("seed: cannot count items: %w", err)
+			}
+
+			if existing > 0 {
+				log.Printf("seed: %s already has %d items, skipped (use --force to seed anyway)", user.Email, existing)
+				return nil
+			}
+		}
+
+		items := fakeItems(gofakeit.New(0), user.Id, *count, time.Now())
+
+		if err := itemstorage.NewSQLStorage(tx).CreateItems(ctx, items); err != nil {
+			return fmt.Errorf("seed: cannot create items: %w", err)
+		}
+
+		log.Printf("seed: inserted %d items for %s (user id %d)", len(items), user.Email, user.Id)
+
+		return nil
+	})
+}
+
+// fakeItems sinh item với status, hạn (quá hạn, sắp tới hoặc không có) và priority khác nhau
+func fakeItems(f *gofakeit.Faker, userId, count int, now time.Time) []*model.TodoItemCreation {
+	items := make([]*model.TodoItemCreation, count)
+
+	for i := range items {
+		item := &model.TodoItemCreation{
+			UserId:      userId,
+			Title:       fmt.Sprintf("%s %s %s", f.RandomString(seedTitleVerbs), f.BuzzWord(), f.NounCommon()),
+			Description: f.Sentence(12),
+			Status:      fakeStatus(f),
+			Tags:        fakeTags(f),
+		}
+
+		switch n := f.Number(1, 10); {
+		case n <= 2:
+			// Quá hạn trong vòng 2 tuần trước
+			due := f.DateRange(now.AddDate(0, 0, -14), now)
+			item.DueDate = &due
+		case n <= 7:
+			due := f.DateRange(now, now.AddDate(0, 0, 30))
+			item.DueDate = &due
+		}
+
+		if f.Number(1, 4) > 1 {
+			priority := model.ItemPriority(f.Number(int(model.ItemPriorityLow), int(model.ItemPriorityHigh)))
+			item.Priority = &priority
+		}
+
+		items[i] = item
+	}
+
+	return items
+}
+
+// fakeStatus: khoảng 60% Doing, 30% Done, 10% Deleted
+func fakeStatus(f *gofakeit.Faker) *model.ItemStatus {
+	status := model.ItemStatusDoing
+
+	switch n := f.Number(1, 10); {
+	case n == 10:
+		status = model.ItemStatusDeleted
+	case n >= 7:
+		status = model.ItemStatusDone
+	}
+
+	return &status
+}
+
+func fakeTags(f *gofakeit.Faker) model.ItemTags {
+	tags := make(model.ItemTags, f.Number(0, 2))
+
+	for i := range tags {
+		tags[i] = f.RandomString(seedTags)
+	}
+
+	return tags.Normalize()
+}
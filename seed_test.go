@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/brianvoe/gofakeit/v7"
+	"testing"
+	"time"
+)
+
+func TestFakeItems(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		userId int
+		count  int
+	}{
+		{"one item", 1, 1},
+		{"default count", 7, defaultSeedCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := fakeItems(gofakeit.New(1), tt.userId, tt.count, now)
+
+			if len(items) != tt.count {
+				t.Fatalf("got %d items, want %d", len(items), tt.count)
+			}
+
+			for i, item := range items {
+				if item.UserId != tt.userId {
+					t.Errorf("items[%d].UserId = %d, want %d", i, item.UserId, tt.userId)
+				}
+
+				if item.Title == "" || item.Status == nil || !item.Status.IsValid() {
+					t.Errorf("items[%d] = %+v, want a title and a valid status", i, item)
+				}
+
+				if item.Priority != nil && !item.Priority.IsValid() {
+					t.Errorf("items[%d].Priority = %v is invalid", i, *item.Priority)
+				}
+
+				if len(item.Tags) != len(item.Tags.Normalize()) {
+					t.Errorf("items[%d].Tags = %v are not normalized", i, item.Tags)
+				}
+			}
+		})
+	}
+}
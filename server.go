@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// runServer phục vụ trên listener tới khi quit nhận được tín hiệu (SIGINT/SIGTERM) rồi shutdown,
+// các request đang chạy có tối đa timeout để xử lý xong. Trả lỗi khi server dừng vì lỗi hoặc shutdown quá timeout
+func runServer(server *http.Server, listener net.Listener, quit <-chan os.Signal, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-quit:
+	}
+
+	log.Println("shutting down server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	// Serve trả về ErrServerClosed ngay khi Shutdown bắt đầu, đọc để goroutine không bị rò
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunServerGracefulShutdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		handlerTime time.Duration
+		timeout     time.Duration
+		wantErr     error
+		wantServed  bool
+	}{
+		{"in-flight request finishes", 100 * time.Millisecond, 2 * time.Second, nil, true},
+		{"timeout cuts the request", 2 * time.Second, 50 * time.Millisecond, context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{})
+			server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				time.Sleep(tt.handlerTime)
+				w.WriteHeader(http.StatusOK)
+			})}
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			quit := make(chan os.Signal, 1)
+			done := make(chan error, 1)
+
+			go func() { done <- runServer(server, listener, quit, tt.timeout) }()
+
+			served := make(chan bool, 1)
+
+			go func() {
+				resp, err := http.Get("http://" + listener.Addr().String())
+
+				if err == nil {
+					resp.Body.Close()
+				}
+
+				served <- err == nil && resp.StatusCode == http.StatusOK
+			}()
+
+			<-started
+			quit <- syscall.SIGTERM
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("runServer = %v, want %v", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("runServer did not return after the signal")
+			}
+
+			if tt.wantServed && !<-served {
+				t.Error("in-flight request was dropped")
+			}
+
+			// Sau shutdown không nhận request mới
+			if _, err := http.Get("http://" + listener.Addr().String()); err == nil {
+				t.Error("server still accepts requests after shutdown")
+			}
+		})
+	}
+}
+
+func TestRunServerListenError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Listener đã đóng thì Serve lỗi ngay, runServer phải trả lỗi thay vì chờ tín hiệu mãi
+	listener.Close()
+
+	if err := runServer(&http.Server{}, listener, make(chan os.Signal), time.Second); err == nil {
+		t.Fatal("runServer = nil, want the serve error")
+	}
+}